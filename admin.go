@@ -0,0 +1,148 @@
+package nano
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// AdminConfig defines admin api configuration.
+type AdminConfig struct {
+	// Auth protects all admin endpoints, e.g. BasicAuth middleware. It's required.
+	Auth HandlerFunc
+	// Stats provides runtime state of subsystems, e.g. rate limiter counters or cache stats.
+	// each provider is served under its key in GET /stats response.
+	Stats map[string]func() interface{}
+}
+
+// adminGroup defines router group entry in admin middleware listing.
+type adminGroup struct {
+	Prefix      string   `json:"prefix"`
+	Middlewares []string `json:"middlewares"`
+	After       []string `json:"after,omitempty"`
+}
+
+// MountAdmin mounts admin api under given prefix. The admin api provides json endpoints:
+// GET /routes lists registered routes, GET /middlewares lists middlewares of each router group,
+// GET /stats serves configured stats providers, GET /maintenance shows maintenance mode state,
+// and PUT /maintenance with {"enabled": true} body toggles the maintenance mode.
+// Admin endpoints are still accessible in maintenance mode.
+func (ng *Engine) MountAdmin(prefix string, config AdminConfig) *RouterGroup {
+	if config.Auth == nil {
+		panic("admin api requires auth middleware")
+	}
+
+	ng.adminPrefix = prefix
+
+	admin := ng.Group(prefix)
+	admin.Use(config.Auth)
+
+	admin.GET("/routes", func(c *Context) {
+		c.JSON(http.StatusOK, ng.adminRoutes())
+	})
+
+	admin.GET("/middlewares", func(c *Context) {
+		groups := make([]adminGroup, 0, len(ng.groups))
+		for _, group := range ng.groups {
+			names := make([]string, 0, len(group.middlewares))
+			for _, middleware := range group.middlewares {
+				names = append(names, nameOfFunction(middleware))
+			}
+
+			var after []string
+			for _, middleware := range group.after {
+				after = append(after, nameOfFunction(middleware))
+			}
+
+			groups = append(groups, adminGroup{Prefix: group.prefix, Middlewares: names, After: after})
+		}
+
+		c.JSON(http.StatusOK, groups)
+	})
+
+	admin.GET("/stats", func(c *Context) {
+		stats := make(H, len(config.Stats))
+		for name, provider := range config.Stats {
+			stats[name] = provider()
+		}
+
+		c.JSON(http.StatusOK, stats)
+	})
+
+	admin.GET("/maintenance", func(c *Context) {
+		c.JSON(http.StatusOK, H{"enabled": ng.IsMaintenance()})
+	})
+
+	admin.PUT("/maintenance", func(c *Context) {
+		var state struct {
+			Enabled bool `json:"enabled"`
+		}
+
+		if err := c.BindJSON(&state); err != nil {
+			var errBinding ErrBinding
+			if errors.As(err, &errBinding) {
+				c.JSON(errBinding.Status, H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusBadRequest, H{"error": err.Error()})
+			}
+
+			return
+		}
+
+		ng.SetMaintenance(state.Enabled)
+		c.JSON(http.StatusOK, H{"enabled": ng.IsMaintenance()})
+	})
+
+	return admin
+}
+
+// adminRoutes returns registered routes sorted by path & method.
+func (ng *Engine) adminRoutes() []RouteInfo {
+	routes := ng.Routes()
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+
+		return routes[i].Path < routes[j].Path
+	})
+
+	return routes
+}
+
+// SetMaintenance functions to toggle maintenance mode.
+// In maintenance mode, all requests except admin api are responded with 503 status code.
+func (ng *Engine) SetMaintenance(enabled bool) {
+	var state int32
+	if enabled {
+		state = 1
+	}
+
+	atomic.StoreInt32(&ng.maintenance, state)
+}
+
+// IsMaintenance returns true when maintenance mode is enabled.
+func (ng *Engine) IsMaintenance() bool {
+	return atomic.LoadInt32(&ng.maintenance) == 1
+}
+
+// nameOfFunction returns function name, it's used to describe handlers & middlewares.
+func nameOfFunction(f interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// isAdminPath returns true when url path is under admin api prefix, e.g. /_admin/routes but not /_administrator.
+func (ng *Engine) isAdminPath(urlPath string) bool {
+	if ng.adminPrefix == "" {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(ng.adminPrefix, "/")
+
+	return urlPath == prefix || strings.HasPrefix(urlPath, prefix+"/")
+}
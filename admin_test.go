@@ -0,0 +1,85 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdmin(t *testing.T) {
+	app := New()
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	app.MountAdmin("/_admin", AdminConfig{
+		Auth: BasicAuth(map[string]string{"admin": "secret"}),
+		Stats: map[string]func() interface{}{
+			"answer": func() interface{} { return 42 },
+		},
+	})
+
+	request := func(method, url, body string, auth bool) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		if body != "" {
+			req.Header.Set(HeaderContentType, MimeJSON)
+		}
+
+		if auth {
+			req.SetBasicAuth("admin", "secret")
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if rec := request(http.MethodGet, "/_admin/routes", "", false); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected admin api without auth to be 401; got %d", rec.Code)
+	}
+
+	rec := request(http.MethodGet, "/_admin/routes", "", true)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `{"method":"GET","path":"/","handler":`) {
+		t.Errorf("expected route listing to contain root route; got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request(http.MethodGet, "/_admin/stats", "", true)
+	if body := rec.Body.String(); body != `{"answer":42}` {
+		t.Errorf("expected stats to be served; got %s", body)
+	}
+
+	t.Run("maintenance mode", func(st *testing.T) {
+		if rec := request(http.MethodPut, "/_admin/maintenance", `{"enabled":true}`, true); rec.Code != http.StatusOK {
+			st.Fatalf("expected maintenance toggle to be 200; got %d", rec.Code)
+		}
+
+		if rec := request(http.MethodGet, "/", "", false); rec.Code != http.StatusServiceUnavailable {
+			st.Errorf("expected request in maintenance mode to be 503; got %d", rec.Code)
+		}
+
+		if rec := request(http.MethodGet, "/_admin/maintenance", "", true); rec.Body.String() != `{"enabled":true}` {
+			st.Errorf("expected admin api to be accessible; got %s", rec.Body.String())
+		}
+
+		if rec := request(http.MethodGet, "/_administrator", "", false); rec.Code != http.StatusServiceUnavailable {
+			st.Errorf("expected path sharing admin prefix in maintenance mode to be 503; got %d", rec.Code)
+		}
+
+		request(http.MethodPut, "/_admin/maintenance", `{"enabled":false}`, true)
+
+		if rec := request(http.MethodGet, "/", "", false); rec.Code != http.StatusOK {
+			st.Errorf("expected request after maintenance mode to be 200; got %d", rec.Code)
+		}
+
+		if rec := request(http.MethodPut, "/_admin/maintenance", `{"enabled":`, true); rec.Code != http.StatusBadRequest {
+			st.Errorf("expected malformed maintenance toggle to be 400; got %d", rec.Code)
+		}
+	})
+}
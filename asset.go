@@ -0,0 +1,163 @@
+package nano
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// immutableCacheControl caches fingerprinted asset forever, its name changes when the content changes.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// Assets is fingerprinted static files served by StaticVersioned.
+type Assets struct {
+	baseURL string
+	fsys    fs.FS
+	engine  *Engine
+
+	mu        sync.RWMutex
+	versioned map[string]string // original name to fingerprinted name.
+	originals map[string]string // fingerprinted name to original name.
+}
+
+// SetFuncMap functions to add functions available in templates loaded by LoadHTMLGlob & LoadHTMLFS,
+// call it before loading the templates.
+func (ng *Engine) SetFuncMap(funcs template.FuncMap) {
+	if ng.templateFuncs == nil {
+		ng.templateFuncs = make(template.FuncMap)
+	}
+
+	for name, fn := range funcs {
+		ng.templateFuncs[name] = fn
+	}
+}
+
+// StaticVersioned functions to serve files of fsys under content-hashed names, e.g. css/app.css is served
+// as css/app.1f2e3d4c5b6a7988.css with immutable cache headers, so browsers fetch it again only when it changes.
+// original name is served too, but it's revalidated on every request.
+// Use assetPath template function (or Assets.Path) to get url of the current version, call it before loading templates.
+//
+//	<link rel="stylesheet" href="{{ assetPath "css/app.css" }}">
+func (rg *RouterGroup) StaticVersioned(baseURL string, fsys fs.FS) *Assets {
+	if strings.ContainsAny(baseURL, ":*{") {
+		panic("cannot use dynamic url parameter in file server base url")
+	}
+
+	assets := &Assets{
+		baseURL:   rg.prefix + strings.TrimSuffix(baseURL, "/"),
+		fsys:      fsys,
+		engine:    rg.engine,
+		versioned: make(map[string]string),
+		originals: make(map[string]string),
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		return assets.fingerprint(name)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not fingerprint assets: %v", err))
+	}
+
+	rg.engine.SetFuncMap(template.FuncMap{"assetPath": assets.Path})
+
+	urlPattern := baseURL + "/*filepath"
+	rg.GET(urlPattern, assets.serve)
+	rg.HEAD(urlPattern, assets.serve)
+
+	return assets
+}
+
+// Path returns url of the current version of asset, e.g. /assets/css/app.1f2e3d4c5b6a7988.css of css/app.css.
+// In debug mode, the file is fingerprinted again so edited asset gets new url.
+// url of the original name is returned when the asset doesn't exist.
+func (a *Assets) Path(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	if a.engine != nil && a.engine.debug {
+		a.fingerprint(name)
+	}
+
+	a.mu.RLock()
+	versioned, ok := a.versioned[name]
+	a.mu.RUnlock()
+
+	if !ok {
+		versioned = name
+	}
+
+	return a.baseURL + "/" + versioned
+}
+
+// fingerprint hashes file content and records its fingerprinted name.
+func (a *Assets) fingerprint(name string) error {
+	file, err := a.fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := fnv.New64a()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+
+	ext := path.Ext(name)
+	versioned := fmt.Sprintf("%s.%016x%s", strings.TrimSuffix(name, ext), hash.Sum64(), ext)
+
+	a.mu.Lock()
+	a.versioned[name] = versioned
+	a.originals[versioned] = name
+	a.mu.Unlock()
+
+	return nil
+}
+
+// serve serves asset by fingerprinted or original name.
+func (a *Assets) serve(c *Context) {
+	name := strings.TrimPrefix(path.Clean("/"+c.Param("filepath")), "/")
+
+	a.mu.RLock()
+	original, versioned := a.originals[name]
+	a.mu.RUnlock()
+
+	if versioned {
+		name = original
+	}
+
+	file, err := a.fsys.Open(name)
+	if err != nil {
+		c.String(http.StatusNotFound, "file not found")
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
+		c.String(http.StatusNotFound, "file not found")
+		return
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if versioned {
+		c.SetHeader(HeaderCacheControl, immutableCacheControl)
+	} else {
+		c.SetHeader(HeaderCacheControl, "no-cache")
+	}
+
+	http.ServeContent(c.Writer, c.Request, stat.Name(), stat.ModTime(), content)
+}
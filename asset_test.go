@@ -0,0 +1,87 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"testing/fstest"
+)
+
+func TestStaticVersioned(t *testing.T) {
+	assetFS := fstest.MapFS{
+		"css/app.css": {Data: []byte("body{}")},
+		"js/app.js":   {Data: []byte("alert(1)")},
+	}
+
+	templateFS := fstest.MapFS{
+		"layout.html": {Data: []byte(`{{define "layout"}}<link href="{{ assetPath "css/app.css" }}">{{end}}`)},
+	}
+
+	app := New()
+	assets := app.StaticVersioned("/assets", assetFS)
+
+	if err := app.LoadHTMLFS(templateFS, "*.html"); err != nil {
+		t.Fatalf("expected templates to be loaded; got %v", err)
+	}
+
+	app.GET("/", func(c *Context) {
+		c.Render(http.StatusOK, "layout", nil)
+	})
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	cssPath := assets.Path("/css/app.css")
+	if !regexp.MustCompile(`^/assets/css/app\.[0-9a-f]{16}\.css$`).MatchString(cssPath) {
+		t.Fatalf("expected css path to be fingerprinted; got %s", cssPath)
+	}
+
+	if body := request("/").Body.String(); body != `<link href="`+cssPath+`">` {
+		t.Errorf("expected template to use fingerprinted path; got %s", body)
+	}
+
+	rec := request(cssPath)
+	if rec.Code != http.StatusOK || rec.Body.String() != "body{}" || rec.Header().Get(HeaderCacheControl) != immutableCacheControl {
+		t.Errorf("expected fingerprinted asset to be served with immutable cache; got %d %s %s", rec.Code, rec.Body.String(), rec.Header().Get(HeaderCacheControl))
+	}
+
+	rec = request("/assets/js/app.js")
+	if rec.Code != http.StatusOK || rec.Header().Get(HeaderCacheControl) != "no-cache" {
+		t.Errorf("expected original name to be served with no-cache; got %d %s", rec.Code, rec.Header().Get(HeaderCacheControl))
+	}
+
+	if rec := request("/assets/css/app.0000000000000000.css"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected unknown version to be 404; got %d", rec.Code)
+	}
+
+	if path := assets.Path("missing.css"); path != "/assets/missing.css" {
+		t.Errorf("expected missing asset to use original name; got %s", path)
+	}
+
+	t.Run("debug mode fingerprints changed file", func(st *testing.T) {
+		app.SetDebug(true)
+		defer app.SetDebug(false)
+
+		assetFS["css/app.css"] = &fstest.MapFile{Data: []byte("body{color:red}")}
+
+		changed := assets.Path("css/app.css")
+		if changed == cssPath {
+			st.Fatalf("expected changed asset to get new path")
+		}
+
+		if rec := request(changed); rec.Body.String() != "body{color:red}" {
+			st.Errorf("expected changed asset to be served; got %s", rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package nano
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+)
+
+// HeaderWWWAuthenticate is authentication method that should be used to gain access.
+const HeaderWWWAuthenticate = "WWW-Authenticate"
+
+// BasicAuthConfig defines nano basic auth middleware configuration.
+type BasicAuthConfig struct {
+	// Accounts is map of username and password.
+	Accounts map[string]string
+	// Realm is protection space name, default is "Restricted".
+	Realm string
+	// Skipper returns true when the request should be served without authentication.
+	Skipper func(c *Context) bool
+}
+
+// BasicAuth returns http basic authentication middleware.
+func BasicAuth(accounts map[string]string) HandlerFunc {
+	return BasicAuthWithConfig(BasicAuthConfig{Accounts: accounts})
+}
+
+// BasicAuthWithConfig returns http basic authentication middleware.
+// authenticated username is stored in context bag with "user" key.
+func BasicAuthWithConfig(config BasicAuthConfig) HandlerFunc {
+	if config.Realm == "" {
+		config.Realm = "Restricted"
+	}
+
+	challenge := "Basic realm=" + strconv.Quote(config.Realm)
+
+	return func(c *Context) {
+		if config.Skipper != nil && config.Skipper(c) {
+			c.Next()
+			return
+		}
+
+		username, password, ok := c.Request.BasicAuth()
+
+		if ok {
+			expected, found := config.Accounts[username]
+			// always compare the password, so response time doesn't tell whether username exists.
+			match := subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1
+
+			if found && match {
+				c.Bag.Set("user", username)
+				c.Next()
+				return
+			}
+		}
+
+		c.SetHeader(HeaderWWWAuthenticate, challenge)
+		c.String(http.StatusUnauthorized, "unauthorized")
+	}
+}
@@ -0,0 +1,87 @@
+package nano
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// backgroundTasks tracks goroutines started by Engine.Go, so graceful shutdown could wait for them.
+type backgroundTasks struct {
+	once   sync.Once
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns context of background tasks, it's cancelled when shutdown gives up waiting.
+func (b *backgroundTasks) context() context.Context {
+	b.once.Do(func() {
+		b.ctx, b.cancel = context.WithCancel(context.Background())
+	})
+
+	return b.ctx
+}
+
+// Go functions to run fire-and-forget task in background, e.g. sending email or writing audit log.
+// The task is tracked by the engine, so Shutdown waits for it to complete. ctx is cancelled when
+// Shutdown deadline is reached before the task completes. Panic of the task is recovered and logged.
+func (ng *Engine) Go(task func(ctx context.Context)) {
+	ctx := ng.tasks.context()
+	ng.tasks.wg.Add(1)
+
+	go func() {
+		defer ng.tasks.wg.Done()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				ng.taskLogger().Error("background task panic", slog.Any("panic", recovered))
+			}
+		}()
+
+		task(ctx)
+	}()
+}
+
+// taskLogger returns logger of background tasks, it's the base logger of Context.Logger.
+func (ng *Engine) taskLogger() *slog.Logger {
+	if ng.logger != nil {
+		return ng.logger
+	}
+
+	return slog.Default()
+}
+
+// WaitTasks functions to wait background tasks started by Go and Context.Defer, it's called by Shutdown
+// after the server is stopped, so call it after shutting down your own http.Server.
+// When ctx is done first, context of the remaining tasks is cancelled and ctx error is returned.
+func (ng *Engine) WaitTasks(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		ng.tasks.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		ng.tasks.context()
+		ng.tasks.cancel()
+
+		return ctx.Err()
+	}
+}
+
+// Defer functions to run task in background after the response is sent, so the client doesn't wait for it.
+// The task is tracked by the engine like Engine.Go. Request is already finished when the task runs,
+// so copy the values it needs instead of reading them from the context.
+func (c *Context) Defer(task func(ctx context.Context)) {
+	c.AfterResponse(func() {
+		if c.engine == nil {
+			go task(context.Background())
+			return
+		}
+
+		c.engine.Go(task)
+	})
+}
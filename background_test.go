@@ -0,0 +1,89 @@
+package nano
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackgroundTasks(t *testing.T) {
+	t.Run("deferred task", func(st *testing.T) {
+		var written, ran int32
+
+		app := New()
+		app.POST("/signup", func(c *Context) {
+			c.Defer(func(ctx context.Context) {
+				if atomic.LoadInt32(&written) == 1 {
+					atomic.StoreInt32(&ran, 1)
+				}
+			})
+
+			c.String(http.StatusCreated, "ok")
+			atomic.StoreInt32(&written, 1)
+		})
+
+		req, err := http.NewRequest(http.MethodPost, "/signup", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if err := app.WaitTasks(context.Background()); err != nil {
+			st.Fatalf("expected wait error to be nil; got %v", err)
+		}
+
+		if atomic.LoadInt32(&ran) != 1 {
+			st.Errorf("expected deferred task to run after the response")
+		}
+	})
+
+	t.Run("wait timeout", func(st *testing.T) {
+		cancelled := make(chan struct{})
+
+		app := New()
+		app.Go(func(ctx context.Context) {
+			<-ctx.Done()
+			close(cancelled)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := app.WaitTasks(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			st.Errorf("expected wait error to be deadline exceeded; got %v", err)
+		}
+
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			st.Errorf("expected context of remaining task to be cancelled")
+		}
+	})
+
+	t.Run("task panic", func(st *testing.T) {
+		var output bytes.Buffer
+
+		app := New()
+		app.SetLogger(slog.New(slog.NewTextHandler(&output, nil)))
+		app.Go(func(ctx context.Context) {
+			panic("smtp is down")
+		})
+
+		if err := app.WaitTasks(context.Background()); err != nil {
+			st.Fatalf("expected wait error to be nil; got %v", err)
+		}
+
+		if !strings.Contains(output.String(), "background task panic") || !strings.Contains(output.String(), "smtp is down") {
+			st.Errorf("expected panic to be logged; got %s", output.String())
+		}
+	})
+}
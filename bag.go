@@ -0,0 +1,132 @@
+package nano
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Bag stores context key:value parameter.
+// It's safe for concurrent use, e.g. by goroutine started from handler.
+type Bag struct {
+	mu   sync.RWMutex
+	data map[string]interface{}
+}
+
+// NewBag creates new bag instance.
+func NewBag() *Bag {
+	return &Bag{
+		data: make(map[string]interface{}),
+	}
+}
+
+// Set bad data.
+func (b *Bag) Set(key string, data interface{}) {
+	b.mu.Lock()
+	b.data[key] = data
+	b.mu.Unlock()
+}
+
+// Get data by given key.
+func (b *Bag) Get(key string) interface{} {
+	data, _ := b.Lookup(key)
+	return data
+}
+
+// Lookup returns data by given key, and whether the key exists.
+func (b *Bag) Lookup(key string) (interface{}, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	data, ok := b.data[key]
+	return data, ok
+}
+
+// MustGet returns data by given key, it panics when the key doesn't exist.
+func (b *Bag) MustGet(key string) interface{} {
+	data, ok := b.Lookup(key)
+	if !ok {
+		panic(fmt.Sprintf("bag key %q does not exist", key))
+	}
+
+	return data
+}
+
+// GetString returns string data by given key, empty string is returned when the key doesn't exist
+// or the data isn't string.
+func (b *Bag) GetString(key string) string {
+	data, _ := b.Get(key).(string)
+	return data
+}
+
+// GetInt returns int data by given key, zero is returned when the key doesn't exist or the data isn't int.
+func (b *Bag) GetInt(key string) int {
+	data, _ := b.Get(key).(int)
+	return data
+}
+
+// GetBool returns bool data by given key, false is returned when the key doesn't exist or the data isn't bool.
+func (b *Bag) GetBool(key string) bool {
+	data, _ := b.Get(key).(bool)
+	return data
+}
+
+// GetTime returns time data by given key, zero time is returned when the key doesn't exist
+// or the data isn't time.Time.
+func (b *Bag) GetTime(key string) time.Time {
+	data, _ := b.Get(key).(time.Time)
+	return data
+}
+
+// GetBag functions to get typed data from context bag, it returns false when the key doesn't exist
+// or the data isn't T.
+//
+//	user, ok := nano.GetBag[*User](c, "user")
+func GetBag[T any](c *Context, key string) (T, bool) {
+	data, _ := c.Bag.Lookup(key)
+	value, ok := data.(T)
+
+	return value, ok
+}
+
+// BagKey is typed key of context bag, declare it once and share it between middleware and handlers
+// so the value is stored and read using the same type.
+//
+//	var UserKey = nano.NewBagKey[*User]("user")
+//
+//	UserKey.Set(c, user)       // in auth middleware.
+//	user := UserKey.MustGet(c) // in handler.
+type BagKey[T any] struct {
+	name string
+}
+
+// NewBagKey creates typed bag key, name is the key used in Bag, so Bag.Get(name) returns the same data.
+func NewBagKey[T any](name string) BagKey[T] {
+	return BagKey[T]{name: name}
+}
+
+// Name returns the key used in Bag.
+func (k BagKey[T]) Name() string {
+	return k.name
+}
+
+// Set stores value in context bag.
+func (k BagKey[T]) Set(c *Context, value T) {
+	c.Bag.Set(k.name, value)
+}
+
+// Get returns value from context bag, it returns false when the key doesn't exist or the data isn't T.
+func (k BagKey[T]) Get(c *Context) (T, bool) {
+	return GetBag[T](c, k.name)
+}
+
+// MustGet returns value from context bag, it panics when the key doesn't exist or the data isn't T.
+func (k BagKey[T]) MustGet(c *Context) T {
+	value, ok := k.Get(c)
+	if !ok {
+		panic(fmt.Sprintf("bag key %q does not hold %s", k.name, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+
+	return value
+}
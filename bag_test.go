@@ -0,0 +1,97 @@
+package nano
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBagAccessors(t *testing.T) {
+	bag := NewBag()
+	now := time.Now()
+
+	bag.Set("name", "foo")
+	bag.Set("age", 20)
+	bag.Set("admin", true)
+	bag.Set("login", now)
+
+	if bag.GetString("name") != "foo" || bag.GetInt("age") != 20 || !bag.GetBool("admin") || !bag.GetTime("login").Equal(now) {
+		t.Errorf("expected typed accessors to return stored values")
+	}
+
+	if bag.GetString("age") != "" || bag.GetInt("missing") != 0 {
+		t.Errorf("expected mismatched type & missing key to return zero value")
+	}
+
+	if _, ok := bag.Lookup("missing"); ok {
+		t.Errorf("expected missing key to be reported")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustGet of missing key to panic")
+		}
+	}()
+
+	bag.MustGet("missing")
+}
+
+func TestBagKey(t *testing.T) {
+	type User struct {
+		Name string
+	}
+
+	userKey := NewBagKey[*User]("user")
+	c := &Context{Bag: NewBag()}
+
+	if _, ok := userKey.Get(c); ok {
+		t.Errorf("expected missing key to be reported")
+	}
+
+	userKey.Set(c, &User{Name: "foo"})
+
+	if user := userKey.MustGet(c); user.Name != "foo" {
+		t.Errorf("expected user name to be foo; got %s", user.Name)
+	}
+
+	if user, ok := GetBag[*User](c, userKey.Name()); !ok || user.Name != "foo" {
+		t.Errorf("expected GetBag to return stored user")
+	}
+
+	if _, ok := GetBag[string](c, "user"); ok {
+		t.Errorf("expected GetBag of another type to be reported")
+	}
+
+	c.Bag.Set("user", "foo")
+
+	defer func() {
+		if message := fmt.Sprint(recover()); message != `bag key "user" does not hold *nano.User` {
+			t.Errorf("expected MustGet of another type to panic; got %s", message)
+		}
+	}()
+
+	userKey.MustGet(c)
+}
+
+func TestBagConcurrency(t *testing.T) {
+	bag := NewBag()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			key := fmt.Sprintf("key-%d", i)
+			bag.Set(key, i)
+			bag.GetInt(key)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if bag.GetInt("key-9") != 9 {
+		t.Errorf("expected key-9 to be 9; got %d", bag.GetInt("key-9"))
+	}
+}
@@ -0,0 +1,167 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hariadivicky/nano"
+)
+
+// product is payload used by binding & rendering benchmarks.
+type product struct {
+	ID          int      `json:"id" form:"id"`
+	Name        string   `json:"name" form:"name" validate:"required"`
+	Description string   `json:"description" form:"description"`
+	Price       float64  `json:"price" form:"price" validate:"gte=0"`
+	Tags        []string `json:"tags" form:"tags"`
+}
+
+var sampleProduct = product{
+	ID:          1,
+	Name:        "nano gopher",
+	Description: "gopher plush toy shipped with nano",
+	Price:       12.5,
+	Tags:        []string{"toy", "gopher", "plush"},
+}
+
+// newRequest creates benchmark request.
+func newRequest(method, url string, body []byte) *http.Request {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	return req
+}
+
+// serve runs handler b.N times. request body is rewound on each iteration.
+func serve(b *testing.B, handler http.Handler, req *http.Request, body []byte) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+func BenchmarkRoutingStatic(b *testing.B) {
+	req := newRequest(http.MethodGet, "/user/repos", nil)
+
+	b.Run("nano", func(b *testing.B) {
+		serve(b, newNanoAPI(), req, nil)
+	})
+
+	b.Run("net/http", func(b *testing.B) {
+		serve(b, newServeMuxAPI(), req, nil)
+	})
+}
+
+func BenchmarkRoutingParam(b *testing.B) {
+	req := newRequest(http.MethodGet, "/repos/hariadivicky/nano/pulls/42/commits", nil)
+
+	b.Run("nano", func(b *testing.B) {
+		serve(b, newNanoAPI(), req, nil)
+	})
+
+	b.Run("net/http", func(b *testing.B) {
+		serve(b, newServeMuxAPI(), req, nil)
+	})
+}
+
+func BenchmarkRoutingWildcard(b *testing.B) {
+	serve(b, newNanoAPI(), newRequest(http.MethodGet, "/repos/hariadivicky/nano/contents/docs/guide/intro.md", nil), nil)
+}
+
+func BenchmarkBindJSON(b *testing.B) {
+	body, _ := json.Marshal(sampleProduct)
+
+	b.Run("nano", func(b *testing.B) {
+		app := nano.New()
+		app.POST("/products", func(c *nano.Context) {
+			var p product
+			if err := c.BindJSON(&p); err != nil {
+				b.Fatalf("could not bind json: %v", err)
+			}
+		})
+
+		req := newRequest(http.MethodPost, "/products", body)
+		req.Header.Set(nano.HeaderContentType, nano.MimeJSON)
+		serve(b, app, req, body)
+	})
+
+	b.Run("net/http", func(b *testing.B) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var p product
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				b.Fatalf("could not decode json: %v", err)
+			}
+		})
+
+		serve(b, handler, newRequest(http.MethodPost, "/products", body), body)
+	})
+}
+
+func BenchmarkBindForm(b *testing.B) {
+	body := []byte("id=1&name=nano+gopher&description=plush&price=12.5&tags=toy&tags=gopher")
+
+	app := nano.New()
+	app.POST("/products", func(c *nano.Context) {
+		var p product
+		if err := c.Bind(&p); err != nil {
+			b.Fatalf("could not bind form: %v", err)
+		}
+	})
+
+	req := newRequest(http.MethodPost, "/products", body)
+	req.Header.Set(nano.HeaderContentType, nano.MimeFormURLEncoded)
+	serve(b, app, req, body)
+}
+
+func BenchmarkRenderJSON(b *testing.B) {
+	req := newRequest(http.MethodGet, "/products/1", nil)
+
+	b.Run("nano", func(b *testing.B) {
+		app := nano.New()
+		app.GET("/products/:id", func(c *nano.Context) {
+			c.JSON(http.StatusOK, sampleProduct)
+		})
+
+		serve(b, app, req, nil)
+	})
+
+	b.Run("net/http", func(b *testing.B) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(nano.HeaderContentType, nano.MimeJSON)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(sampleProduct)
+		})
+
+		serve(b, handler, req, nil)
+	})
+}
+
+func BenchmarkMiddlewareChain(b *testing.B) {
+	middleware := func(c *nano.Context) {
+		c.Next()
+	}
+
+	app := nano.New()
+	app.Use(nano.Recovery(), middleware, middleware)
+
+	api := app.Group("/api")
+	api.Use(middleware, middleware)
+	api.GET("/products/:id", middleware, func(c *nano.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	serve(b, app, newRequest(http.MethodGet, "/api/products/1", nil), nil)
+}
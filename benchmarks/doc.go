@@ -0,0 +1,10 @@
+// Package benchmarks measures nano performance using realistic route table,
+// so performance regressions of new features are visible over time.
+//
+// Run the suite using:
+//
+//	go test -run xxx -bench . -benchmem ./benchmarks
+//
+// net/http benchmarks are included as baseline. third-party routers are not compared here
+// to keep nano module free of their dependencies.
+package benchmarks
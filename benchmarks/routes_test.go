@@ -0,0 +1,88 @@
+package benchmarks
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hariadivicky/nano"
+)
+
+// route defines benchmark route.
+type route struct {
+	method string
+	path   string
+}
+
+// githubAPI is subset of github rest api, it mixes static and parameterized routes.
+var githubAPI = []route{
+	{http.MethodGet, "/authorizations"},
+	{http.MethodGet, "/authorizations/:id"},
+	{http.MethodPost, "/authorizations"},
+	{http.MethodDelete, "/authorizations/:id"},
+	{http.MethodGet, "/applications/:client_id/tokens/:access_token"},
+	{http.MethodGet, "/events"},
+	{http.MethodGet, "/repos/:owner/:repo/events"},
+	{http.MethodGet, "/networks/:owner/:repo/events"},
+	{http.MethodGet, "/orgs/:org/events"},
+	{http.MethodGet, "/users/:user/received_events"},
+	{http.MethodGet, "/users/:user/events"},
+	{http.MethodGet, "/feeds"},
+	{http.MethodGet, "/notifications"},
+	{http.MethodGet, "/repos/:owner/:repo/notifications"},
+	{http.MethodPut, "/notifications"},
+	{http.MethodGet, "/notifications/threads/:id"},
+	{http.MethodGet, "/repos/:owner/:repo/stargazers"},
+	{http.MethodGet, "/users/:user/starred"},
+	{http.MethodGet, "/user/starred"},
+	{http.MethodGet, "/user/starred/:owner/:repo"},
+	{http.MethodPut, "/user/starred/:owner/:repo"},
+	{http.MethodGet, "/gists"},
+	{http.MethodGet, "/gists/:id"},
+	{http.MethodPost, "/gists"},
+	{http.MethodGet, "/repos/:owner/:repo/issues"},
+	{http.MethodGet, "/repos/:owner/:repo/issues/:number"},
+	{http.MethodPost, "/repos/:owner/:repo/issues"},
+	{http.MethodGet, "/repos/:owner/:repo/pulls"},
+	{http.MethodGet, "/repos/:owner/:repo/pulls/:number/commits"},
+	{http.MethodGet, "/user"},
+	{http.MethodGet, "/users"},
+	{http.MethodGet, "/users/:user"},
+	{http.MethodGet, "/user/repos"},
+	{http.MethodGet, "/repos/:owner/:repo/contents/*path"},
+}
+
+// noopHandler is nano handler that writes nothing.
+func noopHandler(c *nano.Context) {}
+
+// newNanoAPI creates nano engine serving github api.
+func newNanoAPI() *nano.Engine {
+	app := nano.New()
+	for _, r := range githubAPI {
+		app.Match([]string{r.method}, r.path, noopHandler)
+	}
+
+	return app
+}
+
+// newServeMuxAPI creates net/http ServeMux serving static routes of github api.
+// ServeMux doesn't support route parameter, so parameterized routes are registered as subtree.
+func newServeMuxAPI() *http.ServeMux {
+	mux := http.NewServeMux()
+	registered := make(map[string]bool)
+
+	for _, r := range githubAPI {
+		path := r.path
+		if index := strings.IndexAny(path, ":*"); index >= 0 {
+			path = path[:index]
+		}
+
+		if registered[path] {
+			continue
+		}
+
+		registered[path] = true
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {})
+	}
+
+	return mux
+}
@@ -1,22 +1,28 @@
 package nano
 
 import (
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 // ErrBinding defines an error interface implementation and it will returned when binding failed.
-// Status will set to 422 when there is error on validation,
+// Status will set to 422 when there is error on validation or type conversion (strict binding),
 // 400 when client sent unsupported/without Content-Type header, and
-// 500 when targetStruct is not pointer or type conversion is fail.
+// 500 when targetStruct is not pointer or field type is not supported.
+// Every Bind method returns this type, use errors.As to get it from wrapped error.
 type ErrBinding struct {
 	Status int
 	Text   string
 	Fields []string
+	// Err is the underlying cause, e.g. ErrPayloadTooLarge or json syntax error. it may be nil.
+	Err error
 }
 
 var (
@@ -42,29 +48,142 @@ func (e ErrBinding) Error() string {
 	return e.Text
 }
 
+// Unwrap returns the underlying cause.
+func (e ErrBinding) Unwrap() error {
+	return e.Err
+}
+
+// bindAndValidate is shared implementation of Bind methods, it binds targetStruct using bind function
+// and validates it once. targetStruct must be pointer.
+func (c *Context) bindAndValidate(targetStruct interface{}, bind func() error) error {
+	// only accept pointer
+	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
+		return ErrBindNonPointer
+	}
+
+	c.rewindBody()
+
+	if err := bind(); err != nil {
+		return err
+	}
+
+	return validate(c, targetStruct)
+}
+
 // Bind request body into defined user struct.
 // This function help you to automatic binding based on request Content-Type & request method.
 // If you want to chooose binding method manually, you could use :
 // BindSimpleForm to bind urlencoded form & url query,
 // BindMultipartForm to bind multipart/form data,
 // and BindJSON to bind application/json request body.
+// Besides the request body, Bind also fills fields that have `query`, `uri`, and `header` tag
+// from url query, route parameter, and request header respectively, and then validates the struct once.
 func (c *Context) Bind(targetStruct interface{}) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		if err := c.bindBody(targetStruct); err != nil {
+			return err
+		}
+
+		return c.bindSources(targetStruct)
+	})
+}
+
+// BindAll functions to bind url query and request body in one call, e.g. search endpoint posting filters
+// as json or multipart form while paging using ?page=2. url query is bound into fields that have `form` tag first,
+// then request body overrides the values it has. other sources are bound and the struct is validated like Bind.
+func (c *Context) BindAll(targetStruct interface{}) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		if err := c.bindForm(c.Request.URL.Query(), targetStruct); err != nil {
+			return errBinding(err)
+		}
+
+		if err := c.bindBody(targetStruct); err != nil {
+			return err
+		}
+
+		return c.bindSources(targetStruct)
+	})
+}
+
+// MustBind functions to bind request like Bind, and responds the binding error when it fails.
+// the error is written as json {"error": text, "fields": [...]}, or as html page when client prefers html,
+// using status of the error (e.g. 400 or 422), then the handlers stack is aborted.
+// It returns false when binding fails, so handler should return immediately.
+//
+//	if !c.MustBind(&post) {
+//		return
+//	}
+func (c *Context) MustBind(targetStruct interface{}) bool {
+	err := c.Bind(targetStruct)
+	if err == nil {
+		return true
+	}
+
+	c.respondBindingError(err)
+	c.Abort()
+
+	return false
+}
+
+// respondBindingError writes binding error as json or html response.
+func (c *Context) respondBindingError(err error) {
+	var failure ErrBinding
+	if !errors.As(err, &failure) {
+		failure = ErrBinding{Status: http.StatusBadRequest, Text: err.Error(), Err: err}
+	}
+
+	if failure.Status == 0 {
+		failure.Status = http.StatusBadRequest
+	}
+
+	if failure.Fields == nil {
+		failure.Fields = []string{}
+	}
+
+	if c.NegotiateFormat(MimeJSON, MimeHTML) != MimeHTML {
+		if c.useProblemDetails() {
+			c.Problem(failure.Status, "", "", failure.Text, H{"fields": failure.Fields})
+			return
+		}
+
+		c.JSON(failure.Status, H{"error": failure.Text, "fields": failure.Fields})
+		return
+	}
+
+	page := new(strings.Builder)
+	fmt.Fprintf(page, "<!DOCTYPE html>\n<html>\n<head><title>%s</title></head>\n<body>\n<h1>%s</h1>\n", html.EscapeString(http.StatusText(failure.Status)), html.EscapeString(failure.Text))
+
+	if len(failure.Fields) > 0 {
+		page.WriteString("<ul>\n")
+		for _, field := range failure.Fields {
+			fmt.Fprintf(page, "<li>%s</li>\n", html.EscapeString(field))
+		}
+		page.WriteString("</ul>\n")
+	}
+
+	page.WriteString("</body>\n</html>")
+	c.HTML(failure.Status, page.String())
+}
+
+// bindBody binds request body based on request Content-Type & request method.
+func (c *Context) bindBody(targetStruct interface{}) error {
 	contentType := c.GetRequestHeader(HeaderContentType)
 
 	// if client request using POST, PUT, & PATCH we will try to bind request using simple form (urlencoded & url query),
-	// multipart form, and JSON. if you need both binding e.g. to bind multipart form & url query,
-	// this method doesn't works. you should call BindSimpleForm & BindMultipartForm manually from your handler.
+	// multipart form, and JSON. url query isn't bound into `form` fields of multipart form & JSON, see BindAll.
 	if c.Method == http.MethodPost || c.Method == http.MethodPut || c.Method == http.MethodPatch || contentType != "" {
-		if strings.Contains(contentType, MimeFormURLEncoded) {
-			return c.BindSimpleForm(targetStruct)
-		}
-
-		if strings.Contains(contentType, MimeMultipartForm) {
-			return c.BindMultipartForm(targetStruct)
+		switch c.ContentType() {
+		case MimeFormURLEncoded:
+			return c.bindSimpleForm(targetStruct)
+		case MimeMultipartForm:
+			return c.bindMultipartForm(targetStruct)
+		case MimeJSON:
+			return c.bindJSON(targetStruct)
 		}
 
-		if c.IsJSON() {
-			return c.BindJSON(targetStruct)
+		// content type served by registered codec, e.g. msgpack or protobuf.
+		if codec := c.codecOf(contentType); codec != nil {
+			return c.bindCodec(codec, targetStruct)
 		}
 
 		return ErrBindContentType
@@ -72,186 +191,672 @@ func (c *Context) Bind(targetStruct interface{}) error {
 
 	// when client request using GET method, we will serve binding using simple form.
 	// it's can binding url-encoded form & url query data.
-	return c.BindSimpleForm(targetStruct)
+	return c.bindSimpleForm(targetStruct)
+}
+
+// bindSources binds url query, route parameter, and request header into fields
+// that have `query`, `uri`, and `header` tag. conversion errors of all sources are merged.
+func (c *Context) bindSources(targetStruct interface{}) error {
+	sources := []struct {
+		tag    string
+		values map[string][]string
+	}{
+		{"query", c.Request.URL.Query()},
+		{"uri", paramValues(c.Params)},
+		{"header", c.Request.Header},
+	}
+
+	var fieldErrors []string
+	for _, source := range sources {
+		err := c.bindTag(source.values, targetStruct, source.tag)
+		if err == nil {
+			continue
+		}
+
+		if errBinding, ok := err.(ErrBinding); ok && errBinding.Status == http.StatusUnprocessableEntity {
+			fieldErrors = append(fieldErrors, errBinding.Fields...)
+			continue
+		}
+
+		return errBinding(err)
+	}
+
+	if len(fieldErrors) > 0 {
+		return ErrBinding{
+			Status: http.StatusUnprocessableEntity,
+			Text:   "validation error",
+			Fields: fieldErrors,
+		}
+	}
+
+	return nil
 }
 
 // BindJSON functions to bind request body (with contet type application/json) to targetStruct.
 // targetStruct must be pointer to user defined struct.
 func (c *Context) BindJSON(targetStruct interface{}) error {
-	// only accept pointer
-	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
-		return ErrBindNonPointer
-	}
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.bindJSON(targetStruct)
+	})
+}
 
+// bindJSON decodes json request body into targetStruct without validation.
+func (c *Context) bindJSON(targetStruct interface{}) error {
 	if c.Request.Body != nil {
 		defer c.Request.Body.Close()
-		err := json.NewDecoder(c.Request.Body).Decode(targetStruct)
+
+		decoder := newGuardedDecoder(c.jsonCodec(), c.payloadLimit)
+		err := decoder.Decode(c.Request.Body, targetStruct)
+		c.decodeStats = decoder.stats
+
 		if err != nil && err != io.EOF {
-			return ErrBinding{
-				Text:   err.Error(),
-				Status: http.StatusBadRequest,
-			}
+			return c.errPayload(err)
 		}
 	}
 
-	return validate(c, targetStruct)
+	return nil
 }
 
 // BindSimpleForm functions to bind request body (with content type form-urlencoded or url query) to targetStruct.
 // targetStruct must be pointer to user defined struct.
 func (c *Context) BindSimpleForm(targetStruct interface{}) error {
-	// only accept pointer
-	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
-		return ErrBinding{
-			Text:   "expected pointer to target struct, got non-pointer",
-			Status: http.StatusInternalServerError,
-		}
-	}
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.bindSimpleForm(targetStruct)
+	})
+}
 
+// bindSimpleForm binds urlencoded form & url query into targetStruct without validation.
+func (c *Context) bindSimpleForm(targetStruct interface{}) error {
 	if err := c.Request.ParseForm(); err != nil {
+		if c.isPayloadTooLarge(err) || c.isBodyTooSlow(err) {
+			return c.errPayload(err)
+		}
+
 		return ErrBinding{
 			Text:   fmt.Sprintf("could not parsing form body: %v", err),
 			Status: http.StatusInternalServerError,
+			Err:    err,
 		}
 	}
 
-	if err := bindForm(c.Request.Form, targetStruct); err != nil {
-		return ErrBinding{
-			Status: http.StatusInternalServerError,
-			Text:   fmt.Sprintf("binding error: %v", err),
-		}
+	if err := c.bindForm(c.Request.Form, targetStruct); err != nil {
+		return errBinding(err)
 	}
 
-	return validate(c, targetStruct)
+	return nil
 }
 
 // BindMultipartForm functions to bind request body (with contet type multipart/form-data) to targetStruct.
+// uploaded files are bound into *multipart.FileHeader or []*multipart.FileHeader fields that have `file` tag.
 // targetStruct must be pointer to user defined struct.
 func (c *Context) BindMultipartForm(targetStruct interface{}) error {
-	// only accept pointer
-	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
-		return ErrBinding{
-			Text:   "expected pointer to target struct, got non-pointer",
-			Status: http.StatusInternalServerError,
-		}
-	}
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.bindMultipartForm(targetStruct)
+	})
+}
 
-	err := c.Request.ParseMultipartForm(16 << 10)
+// bindMultipartForm binds multipart form into targetStruct without validation.
+func (c *Context) bindMultipartForm(targetStruct interface{}) error {
+	return c.bindMultipartFormWith(targetStruct, c.maxMultipartMemory())
+}
+
+// bindMultipartFormWith binds multipart form keeping at most maxMemory bytes in memory.
+func (c *Context) bindMultipartFormWith(targetStruct interface{}, maxMemory int64) error {
+	err := c.parseMultipartForm(maxMemory)
 	if err != nil {
+		if c.isPayloadTooLarge(err) || c.isBodyTooSlow(err) {
+			return c.errPayload(err)
+		}
+
 		return ErrBinding{
 			Text:   fmt.Sprintf("could not parsing form body: %v", err),
 			Status: http.StatusBadRequest,
+			Err:    err,
 		}
 	}
 
-	err = bindForm(c.Request.MultipartForm.Value, targetStruct)
-	if err != nil {
+	if err := c.bindForm(c.Request.MultipartForm.Value, targetStruct); err != nil {
+		return errBinding(err)
+	}
+
+	if target := reflect.ValueOf(targetStruct).Elem(); target.Kind() == reflect.Struct {
+		bindFiles(c.Request.MultipartForm.File, target)
+	}
+
+	return nil
+}
+
+// BindHeader functions to bind request header into targetStruct fields that have `header` tag.
+// header name in the tag is case-insensitive, e.g. `header:"x-request-id"`.
+// targetStruct must be pointer to user defined struct.
+func (c *Context) BindHeader(targetStruct interface{}) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		if err := c.bindTag(c.Request.Header, targetStruct, "header"); err != nil {
+			return errBinding(err)
+		}
+
+		return nil
+	})
+}
+
+// BindURI functions to bind route parameters into targetStruct fields that have `uri` tag.
+// e.g. route /users/:id fills field with `uri:"id"` tag.
+// targetStruct must be pointer to user defined struct.
+func (c *Context) BindURI(targetStruct interface{}) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		if err := c.bindTag(paramValues(c.Params), targetStruct, "uri"); err != nil {
+			return errBinding(err)
+		}
+
+		return nil
+	})
+}
+
+// paramValues converts route parameters into multi-values map.
+func paramValues(params map[string]string) map[string][]string {
+	values := make(map[string][]string, len(params))
+	for key, value := range params {
+		values[key] = []string{value}
+	}
+
+	return values
+}
+
+// errBinding converts binder error into ErrBinding.
+func errBinding(err error) error {
+	var failure ErrBinding
+	if errors.As(err, &failure) {
+		return failure
+	}
+
+	return ErrBinding{
+		Status: http.StatusInternalServerError,
+		Text:   fmt.Sprintf("binding error: %v", err),
+		Err:    err,
+	}
+}
+
+// errPayload converts error while reading request body into ErrBinding.
+// it sets status to 413 when body exceeds the limit, 408 when body is sent too slow, and 400 otherwise.
+func (c *Context) errPayload(err error) ErrBinding {
+	if c.isBodyTooSlow(err) {
 		return ErrBinding{
-			Status: http.StatusInternalServerError,
-			Text:   fmt.Sprintf("binding error: %v", err),
+			Text:   ErrBodyTooSlow.Error(),
+			Status: http.StatusRequestTimeout,
+			Err:    ErrBodyTooSlow,
 		}
 	}
 
-	return validate(c, targetStruct)
+	if c.isPayloadTooLarge(err) {
+		return ErrBinding{
+			Text:   ErrPayloadTooLarge.Error(),
+			Status: http.StatusRequestEntityTooLarge,
+			Err:    ErrPayloadTooLarge,
+		}
+	}
+
+	return ErrBinding{
+		Text:   err.Error(),
+		Status: http.StatusBadRequest,
+		Err:    err,
+	}
 }
 
 // bindForm maps each field in request body into targetStruct.
-func bindForm(form map[string][]string, targetStruct interface{}) error {
+// In strict mode, value that could not be converted into its field type is reported as field error
+// (http status 422) instead of silently set to zero value.
+func (c *Context) bindForm(form map[string][]string, targetStruct interface{}) error {
+	return c.bindTag(form, targetStruct, "form")
+}
+
+// bindTag maps values into targetStruct fields which have the given tag.
+func (c *Context) bindTag(form map[string][]string, targetStruct interface{}, tag string) error {
 	targetPtr := reflect.ValueOf(targetStruct).Elem()
-	targetType := targetPtr.Type()
 
 	// only accept struct as target binding
 	if targetPtr.Kind() != reflect.Struct {
 		return fmt.Errorf("expected target binding to be struct")
 	}
 
-	for i := 0; i < targetPtr.NumField(); i++ {
-		fieldValue := targetPtr.Field(i)
+	binder := &formBinder{tag: tag, strict: c.isStrictBinding()}
+	if c.engine != nil {
+		binder.converters = c.engine.formConverters
+	}
+
+	if err := binder.bindStruct(form, targetPtr, ""); err != nil {
+		return err
+	}
+
+	if len(binder.fieldErrors) > 0 {
+		return ErrBinding{
+			Status: http.StatusUnprocessableEntity,
+			Text:   "validation error",
+			Fields: binder.fieldErrors,
+		}
+	}
+
+	return nil
+}
+
+// formBinder holds state of single form binding process.
+type formBinder struct {
+	tag         string
+	strict      bool
+	fieldErrors []string
+	// converters are registered by Engine.RegisterFormConverter.
+	converters map[reflect.Type]FormConverter
+}
+
+// conversionError is returned when form value could not be converted into field type.
+type conversionError struct {
+	kind reflect.Kind
+	// typ is set when the field is converted by form converter or encoding.TextUnmarshaler.
+	typ reflect.Type
+}
+
+// Error implements error interface.
+func (e conversionError) Error() string {
+	return "invalid " + e.typeName() + " value"
+}
+
+// typeName returns name of field type used in error message, e.g. int or uuid.UUID.
+func (e conversionError) typeName() string {
+	if e.typ != nil {
+		return e.typ.String()
+	}
+
+	return e.kind.String()
+}
+
+// bindStruct maps form values into each settable field of structValue.
+// namespace is used to prefix field name in error message, e.g. items[0].
+func (b *formBinder) bindStruct(form map[string][]string, structValue reflect.Value, namespace string) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structValue.NumField(); i++ {
+		fieldValue := structValue.Field(i)
 		// this is used to get field tag.
-		fieldType := targetType.Field(i)
+		fieldType := structType.Field(i)
 
 		// continue iteration when field is not settable.
 		if !fieldValue.CanSet() {
 			continue
 		}
 
-		// check if current field nested struct.
-		// this is possible when current request body is json type.
-		if fieldValue.Kind() == reflect.Struct {
-			// bind recursively.
-			err := bindForm(form, fieldValue.Addr().Interface())
-			if err != nil {
+		// web use tag "form" as field name in request body.
+		// so make sure you have matching name at field name in request body and field tag in your target struct
+		formFieldName, options := parseFormTag(fieldType.Tag.Get(b.tag))
+		if formFieldName == "-" {
+			continue
+		}
+
+		// header keys are stored in canonical format.
+		if b.tag == "header" {
+			formFieldName = http.CanonicalHeaderKey(formFieldName)
+		}
+
+		// uploaded files are bound by bindFiles.
+		if isFileField(fieldType.Type) {
+			continue
+		}
+
+		// check if current field nested struct (or pointer to struct).
+		// tagged nested struct reads prefixed keys, e.g. address.city or address[city] of `form:"address"`,
+		// untagged and embedded struct shares the same form namespace with its parent.
+		if indirectType(fieldType.Type).Kind() == reflect.Struct && !b.isText(indirectType(fieldType.Type)) {
+			nestedForm, nestedNamespace := form, namespace
+			if formFieldName != "" {
+				prefix := strings.TrimSuffix(formFieldName, ".")
+				nestedForm, nestedNamespace = prefixedForm(form, prefix), namespace+prefix+"."
+			}
+
+			if err := b.bindNestedStruct(nestedForm, fieldValue, nestedNamespace); err != nil {
 				return err
 			}
-		} else {
-			// web use tag "form" as field name in request body.
-			// so make sure you have matching name at field name in request body and field tag in your target struct
-			formFieldName := fieldType.Tag.Get("form")
-			// continue iteration when field doesnt have form tag.
-			if formFieldName == "" {
-				continue
+
+			continue
+		}
+
+		// continue iteration when field doesnt have form tag.
+		if formFieldName == "" {
+			continue
+		}
+
+		err := b.bindField(form, formFieldName, options, fieldValue, namespace)
+		if err := b.catch(err, namespace+formFieldName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// catch records conversion error as field error in strict mode, and ignores it otherwise.
+// other kind of errors are returned as is.
+func (b *formBinder) catch(err error, fieldName string) error {
+	convErr, ok := err.(conversionError)
+	if !ok {
+		return err
+	}
+
+	if b.strict {
+		b.fieldErrors = append(b.fieldErrors, fmt.Sprintf("%s must be a valid %s", fieldName, convErr.typeName()))
+	}
+
+	return nil
+}
+
+// bindNestedStruct binds form into nested struct field.
+// nil pointer to struct will only be allocated when at least one of its field is filled.
+func (b *formBinder) bindNestedStruct(form map[string][]string, fieldValue reflect.Value, namespace string) error {
+	if fieldValue.Kind() == reflect.Struct {
+		return b.bindStruct(form, fieldValue, namespace)
+	}
+
+	nested := reflect.New(fieldValue.Type().Elem())
+	if !fieldValue.IsNil() {
+		nested.Elem().Set(fieldValue.Elem())
+	}
+
+	if err := b.bindStruct(form, nested.Elem(), namespace); err != nil {
+		return err
+	}
+
+	if !fieldValue.IsNil() || !nested.Elem().IsZero() {
+		fieldValue.Set(nested)
+	}
+
+	return nil
+}
+
+// bindField binds form value(s) of given name into single struct field.
+func (b *formBinder) bindField(form map[string][]string, name string, options []string, fieldValue reflect.Value, namespace string) error {
+	switch fieldValue.Kind() {
+	case reflect.Ptr:
+		// leave pointer nil when client doesn't send the field.
+		if !hasFormValue(form, name) {
+			return nil
+		}
+
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+
+		return b.bindField(form, name, options, fieldValue.Elem(), namespace)
+	}
+
+	// type having its own text conversion is bound from single value, even when it's map or slice.
+	switch {
+	case b.isText(fieldValue.Type()):
+	case fieldValue.Kind() == reflect.Map:
+		return b.bindMap(form, name, fieldValue, namespace)
+	case fieldValue.Kind() == reflect.Slice:
+		return b.bindSlice(form, name, options, fieldValue, namespace)
+	}
+
+	formValue, exists := form[name]
+	// could not find value in request body, let it empty
+	if !exists || len(formValue) == 0 {
+		return nil
+	}
+
+	// it's a single value. just do direct set.
+	return b.setValue(formValue[0], fieldValue)
+}
+
+// bindSlice binds repeated form values into slice field.
+// slice of struct is filled by indexed keys, e.g. items[0].name or items[0][name].
+// when "split" tag option is set, each value is also splitted by comma, e.g. ids=1,2,3.
+func (b *formBinder) bindSlice(form map[string][]string, name string, options []string, fieldValue reflect.Value, namespace string) error {
+	sliceType := fieldValue.Type()
+
+	if indirectType(sliceType.Elem()).Kind() == reflect.Struct && !b.isText(indirectType(sliceType.Elem())) {
+		subForms := indexedForms(form, name)
+		if len(subForms) == 0 {
+			return nil
+		}
+
+		slice := reflect.MakeSlice(sliceType, len(subForms), len(subForms))
+		for i, subForm := range subForms {
+			subNamespace := fmt.Sprintf("%s%s[%d].", namespace, name, i)
+			if err := b.bindNestedStruct(subForm, slice.Index(i), subNamespace); err != nil {
+				return err
 			}
+		}
+		fieldValue.Set(slice)
 
-			formValue, exists := form[formFieldName]
-			// could not find value in request body, let it empty
-			if !exists {
-				continue
+		return nil
+	}
+
+	formValue := make([]string, 0, len(form[name])+len(form[name+"[]"]))
+	formValue = append(formValue, form[name]...)
+	formValue = append(formValue, form[name+"[]"]...)
+	if hasTagOption(options, "split") {
+		splitted := make([]string, 0, len(formValue))
+		for _, value := range formValue {
+			for _, part := range strings.Split(value, ",") {
+				splitted = append(splitted, strings.TrimSpace(part))
 			}
+		}
+		formValue = splitted
+	}
+
+	formValueCount := len(formValue)
+	if formValueCount == 0 {
+		return nil
+	}
+
+	slice := reflect.MakeSlice(sliceType, formValueCount, formValueCount)
+	for i := 0; i < formValueCount; i++ {
+		err := b.setValue(formValue[i], slice.Index(i))
+		if err := b.catch(err, fmt.Sprintf("%s%s[%d]", namespace, name, i)); err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(slice)
+
+	return nil
+}
+
+// bindMap binds bracketed form keys into map field, e.g. meta[color]=red.
+func (b *formBinder) bindMap(form map[string][]string, name string, fieldValue reflect.Value, namespace string) error {
+	mapType := fieldValue.Type()
+	prefix := name + "["
+
+	for key, values := range form {
+		if !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+
+		mapKey := key[len(prefix) : len(key)-1]
+		// nested brackets are not supported as map key.
+		if strings.ContainsAny(mapKey, "[]") {
+			continue
+		}
+
+		keyValue := reflect.New(mapType.Key()).Elem()
+		if err := b.catch(b.setValue(mapKey, keyValue), namespace+key); err != nil {
+			return err
+		}
 
-			formValueCount := len(formValue)
-			// it's possible if current field value is an array.
-			if fieldValue.Kind() == reflect.Slice && formValueCount > 0 {
-				sliceKind := fieldValue.Type().Elem().Kind()
-				slice := reflect.MakeSlice(fieldValue.Type(), formValueCount, formValueCount)
-				for i := 0; i < formValueCount; i++ {
-					if err := setFieldValue(sliceKind, formValue[i], slice.Index(i)); err != nil {
-						return err
-					}
-				}
-				fieldValue.Field(i).Set(slice)
-			} else {
-				// it's a single value. just do direct set.
-				if err := setFieldValue(fieldValue.Kind(), formValue[0], fieldValue); err != nil {
-					return err
-				}
+		elemValue := reflect.New(mapType.Elem()).Elem()
+		if elemValue.Kind() == reflect.Slice {
+			if err := b.bindSlice(map[string][]string{key: values}, key, nil, elemValue, namespace); err != nil {
+				return err
 			}
+		} else if err := b.catch(b.setValue(values[0], elemValue), namespace+key); err != nil {
+			return err
+		}
+
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.MakeMap(mapType))
 		}
+		fieldValue.SetMapIndex(keyValue, elemValue)
 	}
 
 	return nil
 }
 
+// prefixedForm returns sub form of keys having the prefix, e.g. address.city or address[city] of address,
+// keyed by the rest of the key. nested brackets are kept, so address[geo][lat] became geo[lat].
+func prefixedForm(form map[string][]string, prefix string) map[string][]string {
+	subForm := make(map[string][]string)
+
+	for key, values := range form {
+		var rest string
+
+		switch {
+		case strings.HasPrefix(key, prefix+"."):
+			rest = key[len(prefix)+1:]
+		case strings.HasPrefix(key, prefix+"["):
+			closing := strings.IndexByte(key, ']')
+			if closing < 0 {
+				continue
+			}
+
+			rest = key[len(prefix)+1:closing] + key[closing+1:]
+		}
+
+		if rest != "" {
+			subForm[rest] = values
+		}
+	}
+
+	return subForm
+}
+
+// indexedForms groups indexed form keys (name[0].field) into sub form per index.
+// sub forms are ordered by their index, gaps between indexes are removed.
+func indexedForms(form map[string][]string, name string) []map[string][]string {
+	prefix := name + "["
+	groups := make(map[int]map[string][]string)
+
+	for key, values := range form {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		closing := strings.IndexByte(key[len(prefix):], ']')
+		if closing < 0 {
+			continue
+		}
+
+		index, err := strconv.Atoi(key[len(prefix) : len(prefix)+closing])
+		if err != nil || index < 0 {
+			continue
+		}
+
+		// normalize rest of the key, both items[0].name and items[0][name] became name.
+		rest := key[len(prefix)+closing+1:]
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+		} else if strings.HasPrefix(rest, "[") {
+			if end := strings.IndexByte(rest, ']'); end > 0 {
+				rest = rest[1:end] + rest[end+1:]
+			}
+		}
+
+		if rest == "" {
+			continue
+		}
+
+		if _, ok := groups[index]; !ok {
+			groups[index] = make(map[string][]string)
+		}
+		groups[index][rest] = values
+	}
+
+	indexes := make([]int, 0, len(groups))
+	for index := range groups {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	subForms := make([]map[string][]string, len(indexes))
+	for i, index := range indexes {
+		subForms[i] = groups[index]
+	}
+
+	return subForms
+}
+
+// hasFormValue returns true when form contains given name, either as plain or bracketed key.
+func hasFormValue(form map[string][]string, name string) bool {
+	if _, ok := form[name]; ok {
+		return true
+	}
+
+	prefix := name + "["
+	for key := range form {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseFormTag splits binding tag into field name and its options.
+func parseFormTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+
+	return parts[0], parts[1:]
+}
+
+// hasTagOption returns true when option found in tag options.
+func hasTagOption(options []string, option string) bool {
+	for _, opt := range options {
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// indirectType returns the underlying type of pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
 // setFieldValue sets field with typed value.
 // we will find the best type & size for your field value.
 // if empty string provided to value parameter, we will use zero type value as default field value.
+// conversionError is returned when value is not valid for the field type, field will be set to zero value.
 func setFieldValue(kind reflect.Kind, value string, fieldValue reflect.Value) error {
+	var err error
+
 	switch kind {
 	case reflect.Int:
-		setIntField(value, 0, fieldValue)
+		err = setIntField(value, 0, fieldValue)
 	case reflect.Int8:
-		setIntField(value, 8, fieldValue)
+		err = setIntField(value, 8, fieldValue)
 	case reflect.Int16:
-		setIntField(value, 16, fieldValue)
+		err = setIntField(value, 16, fieldValue)
 	case reflect.Int32:
-		setIntField(value, 32, fieldValue)
+		err = setIntField(value, 32, fieldValue)
 	case reflect.Int64:
-		setIntField(value, 64, fieldValue)
+		err = setIntField(value, 64, fieldValue)
 	case reflect.Uint:
-		setUintField(value, 0, fieldValue)
+		err = setUintField(value, 0, fieldValue)
 	case reflect.Uint8:
-		setUintField(value, 8, fieldValue)
+		err = setUintField(value, 8, fieldValue)
 	case reflect.Uint16:
-		setUintField(value, 16, fieldValue)
+		err = setUintField(value, 16, fieldValue)
 	case reflect.Uint32:
-		setUintField(value, 32, fieldValue)
+		err = setUintField(value, 32, fieldValue)
 	case reflect.Uint64:
-		setUintField(value, 64, fieldValue)
+		err = setUintField(value, 64, fieldValue)
 	case reflect.Bool:
-		setBoolField(value, fieldValue)
+		err = setBoolField(value, fieldValue)
 	case reflect.Float32:
-		setFloatField(value, 32, fieldValue)
+		err = setFloatField(value, 32, fieldValue)
 	case reflect.Float64:
-		setFloatField(value, 64, fieldValue)
+		err = setFloatField(value, 64, fieldValue)
 	case reflect.String:
 		// no conversion needed. because value already a string.
 		fieldValue.SetString(value)
@@ -259,45 +864,78 @@ func setFieldValue(kind reflect.Kind, value string, fieldValue reflect.Value) er
 		// whoopss..
 		return fmt.Errorf("unknown type")
 	}
+
+	if err != nil {
+		return conversionError{kind: kind}
+	}
+
 	return nil
 }
 
 // setIntField converts input string (value) into integer.
-func setIntField(value string, size int, field reflect.Value) {
+func setIntField(value string, size int, field reflect.Value) error {
+	if value == "" {
+		field.SetInt(0)
+		return nil
+	}
+
 	convertedValue, err := strconv.ParseInt(value, 10, size)
 	// set default empty value when conversion.
 	if err != nil {
 		convertedValue = 0
 	}
 	field.SetInt(convertedValue)
+
+	return err
 }
 
 // setUintField converts input string (value) into unsigned integer.
-func setUintField(value string, size int, field reflect.Value) {
+func setUintField(value string, size int, field reflect.Value) error {
+	if value == "" {
+		field.SetUint(0)
+		return nil
+	}
+
 	convertedValue, err := strconv.ParseUint(value, 10, size)
 	// set default empty value when conversion.
 	if err != nil {
 		convertedValue = 0
 	}
 	field.SetUint(convertedValue)
+
+	return err
 }
 
 // setBoolField converts input string (value) into boolean.
-func setBoolField(value string, field reflect.Value) {
+func setBoolField(value string, field reflect.Value) error {
+	if value == "" {
+		field.SetBool(false)
+		return nil
+	}
+
 	convertedValue, err := strconv.ParseBool(value)
 	// set default empty value when conversion.
 	if err != nil {
 		convertedValue = false
 	}
 	field.SetBool(convertedValue)
+
+	return err
 }
 
 // setFloatField converts input string (value) into floating.
-func setFloatField(value string, size int, field reflect.Value) {
+func setFloatField(value string, size int, field reflect.Value) error {
+	if value == "" {
+		field.SetFloat(0)
+		return nil
+	}
+
 	convertedValue, err := strconv.ParseFloat(value, size)
 	// set default empty value when conversion.
 	if err != nil {
 		convertedValue = 0.0
 	}
 	field.SetFloat(convertedValue)
+
+	return err
 }
@@ -2,6 +2,7 @@ package nano
 
 import (
 	"bytes"
+	"errors"
 	"log"
 	"mime/multipart"
 	"net/http"
@@ -184,3 +185,458 @@ func TestBindJSON(t *testing.T) {
 
 	})
 }
+
+func TestBindFormPointerMapAndSlice(t *testing.T) {
+	query := url.Values{}
+	query.Set("age", "21")
+	query.Set("nickname", "foo")
+	query.Set("meta[color]", "red")
+	query.Set("meta[size]", "xl")
+	query.Set("items[1].name", "second")
+	query.Set("items[0].name", "first")
+	query.Set("items[0][qty]", "2")
+	query.Set("ids", "1, 2,3")
+	query.Add("tags[]", "a")
+	query.Add("tags[]", "b")
+
+	req, err := http.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	type Item struct {
+		Name string `form:"name"`
+		Qty  int    `form:"qty"`
+	}
+
+	var target struct {
+		Age      *int              `form:"age"`
+		Nickname *string           `form:"nickname"`
+		Missing  *string           `form:"missing"`
+		Meta     map[string]string `form:"meta"`
+		Items    []Item            `form:"items"`
+		IDs      []int             `form:"ids,split"`
+		Tags     []string          `form:"tags"`
+	}
+
+	if err := ctx.Bind(&target); err != nil {
+		t.Fatalf("expected err binding to be nil; got %v", err)
+	}
+
+	if target.Age == nil || *target.Age != 21 {
+		t.Errorf("expected age to be 21; got %v", target.Age)
+	}
+
+	if target.Nickname == nil || *target.Nickname != "foo" {
+		t.Errorf("expected nickname to be foo; got %v", target.Nickname)
+	}
+
+	if target.Missing != nil {
+		t.Errorf("expected missing field to be nil; got %v", *target.Missing)
+	}
+
+	if target.Meta["color"] != "red" || target.Meta["size"] != "xl" {
+		t.Errorf("expected meta to be filled; got %v", target.Meta)
+	}
+
+	if len(target.Items) != 2 {
+		t.Fatalf("expected num of items to be 2; got %d", len(target.Items))
+	}
+
+	if target.Items[0].Name != "first" || target.Items[0].Qty != 2 || target.Items[1].Name != "second" {
+		t.Errorf("expected items to be ordered by index; got %v", target.Items)
+	}
+
+	if len(target.IDs) != 3 || target.IDs[2] != 3 {
+		t.Errorf("expected ids to be [1 2 3]; got %v", target.IDs)
+	}
+
+	if len(target.Tags) != 2 || target.Tags[1] != "b" {
+		t.Errorf("expected tags to be [a b]; got %v", target.Tags)
+	}
+}
+
+func TestBindNestedStructPrefix(t *testing.T) {
+	type Geo struct {
+		Lat float64 `form:"lat"`
+	}
+
+	type Address struct {
+		City string `form:"city"`
+		Zip  int    `form:"zip"`
+		Geo  Geo    `form:"geo"`
+	}
+
+	type Audit struct {
+		Note string `form:"note"`
+	}
+
+	type Order struct {
+		Audit
+		Shipping Address  `form:"shipping"`
+		Billing  *Address `form:"billing."`
+		Gift     *Address `form:"gift"`
+	}
+
+	form := url.Values{}
+	form.Set("note", "fragile")
+	form.Set("shipping.city", "Jakarta")
+	form.Set("shipping.geo.lat", "-6.2")
+	form.Set("billing[city]", "Bandung")
+	form.Set("billing[geo][lat]", "-6.9")
+	form.Set("city", "Surabaya")
+
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeFormURLEncoded)
+
+	var order Order
+	if err := newContext(httptest.NewRecorder(), req).Bind(&order); err != nil {
+		t.Fatalf("expected err binding to be nil; got %v", err)
+	}
+
+	if order.Note != "fragile" {
+		t.Errorf("expected embedded struct to be bound from flat keys; got %q", order.Note)
+	}
+
+	if order.Shipping.City != "Jakarta" || order.Shipping.Geo.Lat != -6.2 {
+		t.Errorf("expected shipping to be bound from dotted keys; got %+v", order.Shipping)
+	}
+
+	if order.Billing == nil || order.Billing.City != "Bandung" || order.Billing.Geo.Lat != -6.9 {
+		t.Errorf("expected billing to be bound from bracketed keys; got %+v", order.Billing)
+	}
+
+	if order.Gift != nil {
+		t.Errorf("expected gift to be nil when none of its keys is sent; got %+v", order.Gift)
+	}
+
+	t.Run("conversion error", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("shipping.zip=abc"))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeFormURLEncoded)
+
+		var order Order
+		err = newContext(httptest.NewRecorder(), req).Bind(&order)
+
+		errBinding, ok := err.(ErrBinding)
+		if !ok || len(errBinding.Fields) != 1 || errBinding.Fields[0] != "shipping.zip must be a valid int" {
+			st.Errorf("expected shipping.zip conversion error; got %v", err)
+		}
+	})
+}
+
+func TestStrictBinding(t *testing.T) {
+	type Person struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+
+	t.Run("conversion error on strict binding", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/?name=foo&age=abc", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		ctx := newContext(httptest.NewRecorder(), req)
+
+		var person Person
+		err = ctx.Bind(&person)
+		errBinding, ok := err.(ErrBinding)
+		if !ok {
+			st.Fatalf("expected ErrBinding type returned, got %T", err)
+		}
+
+		if errBinding.Status != http.StatusUnprocessableEntity {
+			st.Errorf("expected error status to be %d; got %d", http.StatusUnprocessableEntity, errBinding.Status)
+		}
+
+		if len(errBinding.Fields) != 1 || errBinding.Fields[0] != "age must be a valid int" {
+			st.Errorf("expected age conversion error; got %v", errBinding.Fields)
+		}
+	})
+
+	t.Run("conversion error on loose binding", func(st *testing.T) {
+		app := New()
+		app.SetStrictBinding(false)
+
+		var person Person
+		var bindErr error
+		app.GET("/", func(c *Context) {
+			bindErr = c.Bind(&person)
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/?name=foo&age=abc", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if bindErr != nil {
+			st.Fatalf("expected err binding to be nil; got %v", bindErr)
+		}
+
+		if person.Age != 0 || person.Name != "foo" {
+			st.Errorf("expected age to be zero value; got %v", person)
+		}
+	})
+}
+
+func TestBindHeaderAndURI(t *testing.T) {
+	type Request struct {
+		ID        int    `uri:"id"`
+		RequestID string `header:"x-request-id"`
+		Page      int    `query:"page"`
+		Name      string `json:"name"`
+	}
+
+	app := New()
+
+	var request Request
+	var bindErr error
+	app.POST("/users/:id", func(c *Context) {
+		bindErr = c.Bind(&request)
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/users/7?page=2", strings.NewReader(`{"name":"foo"}`))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeJSON)
+	req.Header.Set("X-Request-ID", "abc")
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	if bindErr != nil {
+		t.Fatalf("expected err binding to be nil; got %v", bindErr)
+	}
+
+	if request.ID != 7 || request.RequestID != "abc" || request.Page != 2 || request.Name != "foo" {
+		t.Errorf("expected all sources to be bound; got %+v", request)
+	}
+
+	t.Run("bind header only", func(st *testing.T) {
+		ctx := newContext(httptest.NewRecorder(), req)
+
+		var header struct {
+			RequestID string `header:"X-Request-Id"`
+		}
+
+		if err := ctx.BindHeader(&header); err != nil {
+			st.Fatalf("expected err binding to be nil; got %v", err)
+		}
+
+		if header.RequestID != "abc" {
+			st.Errorf("expected request id to be abc; got %s", header.RequestID)
+		}
+	})
+
+	t.Run("bind uri only", func(st *testing.T) {
+		ctx := newContext(httptest.NewRecorder(), req)
+		ctx.Params = map[string]string{"id": "x"}
+
+		var uri struct {
+			ID int `uri:"id"`
+		}
+
+		err := ctx.BindURI(&uri)
+		if errBinding, ok := err.(ErrBinding); !ok || errBinding.Status != http.StatusUnprocessableEntity {
+			st.Errorf("expected 422 ErrBinding; got %v", err)
+		}
+	})
+}
+
+func TestBindAll(t *testing.T) {
+	type Search struct {
+		Keyword string `form:"keyword" json:"keyword"`
+		Page    int    `form:"page" json:"page"`
+		Sort    string `form:"sort" json:"sort" validate:"required"`
+	}
+
+	multipartBody := new(bytes.Buffer)
+	writer := multipart.NewWriter(multipartBody)
+	writer.WriteField("keyword", "shoes")
+	writer.WriteField("sort", "price")
+	writer.Close()
+
+	tt := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		expected    Search
+	}{
+		{"json body", http.MethodPost, "/search?page=2&sort=name", MimeJSON, `{"keyword":"shoes","sort":"price"}`, Search{"shoes", 2, "price"}},
+		{"multipart body", http.MethodPost, "/search?page=3&sort=name", writer.FormDataContentType(), multipartBody.String(), Search{"shoes", 3, "price"}},
+		{"url query only", http.MethodGet, "/search?keyword=hat&page=4&sort=name", "", "", Search{"hat", 4, "name"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			if tc.contentType != "" {
+				req.Header.Set(HeaderContentType, tc.contentType)
+			}
+
+			ctx := newContext(httptest.NewRecorder(), req)
+
+			var search Search
+			if err := ctx.BindAll(&search); err != nil {
+				st.Fatalf("expected err binding to be nil; got %v", err)
+			}
+
+			if search != tc.expected {
+				st.Errorf("expected search to be %+v; got %+v", tc.expected, search)
+			}
+		})
+	}
+
+	t.Run("invalid url query", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/search?page=abc", strings.NewReader(`{"sort":"price"}`))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeJSON)
+
+		var search Search
+		err = newContext(httptest.NewRecorder(), req).BindAll(&search)
+		if errBinding, ok := err.(ErrBinding); !ok || errBinding.Status != http.StatusUnprocessableEntity {
+			st.Errorf("expected 422 ErrBinding; got %v", err)
+		}
+	})
+}
+
+func TestMustBind(t *testing.T) {
+	type Person struct {
+		Name string `form:"name" json:"name" validate:"required"`
+	}
+
+	app := New()
+	nextCalled := false
+
+	app.POST("/people", func(c *Context) {
+		var person Person
+		if !c.MustBind(&person) {
+			c.Next()
+			return
+		}
+
+		c.String(http.StatusCreated, person.Name)
+	}, func(c *Context) {
+		nextCalled = true
+	})
+
+	tt := []struct {
+		name        string
+		contentType string
+		accept      string
+		body        string
+		status      int
+		response    string
+	}{
+		{"valid request", MimeJSON, "", `{"name":"foo"}`, http.StatusCreated, "foo"},
+		{"validation error", MimeJSON, MimeJSON, `{}`, http.StatusUnprocessableEntity, `{"error":"validation error","fields":["name is a required field"]}`},
+		{"malformed json", MimeJSON, "", `{"name"`, http.StatusBadRequest, `"fields":[]`},
+		{"unknown content type", "text/csv", "", `name`, http.StatusBadRequest, `{"error":"unknown content type of request body","fields":[]}`},
+		{"html error page", MimeFormURLEncoded, "text/html,*/*;q=0.8", `name=`, http.StatusUnprocessableEntity, "<li>name is a required field</li>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if tc.status == http.StatusUnprocessableEntity {
+				skipWithoutValidator(st)
+			}
+
+			nextCalled = false
+
+			req, err := http.NewRequest(http.MethodPost, "/people", strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, tc.contentType)
+			req.Header.Set(HeaderAccept, tc.accept)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if !strings.Contains(rec.Body.String(), tc.response) {
+				st.Errorf("expected response to contain %s; got %s", tc.response, rec.Body.String())
+			}
+
+			if nextCalled {
+				st.Errorf("expected handlers stack to be aborted")
+			}
+		})
+	}
+}
+
+func TestErrBindingUnwrap(t *testing.T) {
+	type Person struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("payload too large", func(st *testing.T) {
+		app := New()
+
+		var bindErr error
+		app.POST("/", PayloadLimit(PayloadLimitConfig{MaxBodySize: 8}), func(c *Context) {
+			var person Person
+			bindErr = c.BindJSON(&person)
+		})
+
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"more than eight bytes"}`))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeJSON)
+		req.ContentLength = -1
+
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if !errors.Is(bindErr, ErrPayloadTooLarge) {
+			st.Errorf("expected error to wrap ErrPayloadTooLarge; got %v", bindErr)
+		}
+
+		var failure ErrBinding
+		if !errors.As(bindErr, &failure) || failure.Status != http.StatusRequestEntityTooLarge {
+			st.Errorf("expected error to be 413 ErrBinding; got %v", bindErr)
+		}
+	})
+
+	t.Run("non pointer target", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		ctx := newContext(httptest.NewRecorder(), req)
+
+		for name, bind := range map[string]func(interface{}) error{
+			"Bind":              ctx.Bind,
+			"BindJSON":          ctx.BindJSON,
+			"BindSimpleForm":    ctx.BindSimpleForm,
+			"BindMultipartForm": ctx.BindMultipartForm,
+			"BindHeader":        ctx.BindHeader,
+			"BindURI":           ctx.BindURI,
+			"BindMsgPack":       ctx.BindMsgPack,
+		} {
+			var failure ErrBinding
+			if err := bind(Person{}); !errors.As(err, &failure) || failure.Status != http.StatusInternalServerError {
+				st.Errorf("expected %s to return ErrBindNonPointer; got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package nano
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// replayBody is request body buffered by RawBody, it's rewound before each binding.
+type replayBody struct {
+	*bytes.Reader
+}
+
+// Close implements io.Closer, buffered body has nothing to close.
+func (replayBody) Close() error {
+	return nil
+}
+
+// RawBody functions to read whole request body and cache it, e.g. to verify HMAC signature of webhook.
+// The body is replaced with the buffered copy, so it could still be bound afterward (also more than once),
+// this makes signature middleware followed by c.Bind works. Body limit of PayloadLimit middleware still applies,
+// otherwise body larger than DefaultMaxBufferedBody is rejected with ErrPayloadTooLarge.
+func (c *Context) RawBody() ([]byte, error) {
+	if c.rawBody != nil {
+		return c.rawBody, nil
+	}
+
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		c.rawBody = []byte{}
+		return c.rawBody, nil
+	}
+
+	body, err := readBody(c.Request.Body, bufferLimit(c.payloadLimit))
+	c.Request.Body.Close()
+	if err != nil {
+		return nil, c.errPayload(err)
+	}
+
+	c.rawBody = body
+	c.Request.Body = replayBody{bytes.NewReader(body)}
+
+	return body, nil
+}
+
+// rewindBody resets body buffered by RawBody, so it's read from the start by the next binding.
+func (c *Context) rewindBody() {
+	if body, ok := c.Request.Body.(replayBody); ok {
+		body.Seek(0, io.SeekStart)
+	}
+}
@@ -0,0 +1,108 @@
+package nano
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRawBody(t *testing.T) {
+	type Event struct {
+		Type string `json:"type" validate:"required"`
+	}
+
+	secret := []byte("secret")
+	payload := `{"type":"invoice.paid"}`
+
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	app := New()
+	app.Use(func(c *Context) {
+		body, err := c.RawBody()
+		if err != nil || !hmac.Equal([]byte(sign(body)), []byte(c.GetRequestHeader("X-Signature"))) {
+			c.String(http.StatusUnauthorized, "invalid signature")
+			return
+		}
+
+		c.Next()
+	})
+	app.POST("/webhooks", func(c *Context) {
+		var event, again Event
+		if !c.MustBind(&event) || !c.MustBind(&again) {
+			return
+		}
+
+		body, _ := c.RawBody()
+		c.String(http.StatusOK, "%s %s %s", event.Type, again.Type, body)
+	})
+
+	tt := []struct {
+		name      string
+		signature string
+		status    int
+		body      string
+	}{
+		{"valid signature", sign([]byte(payload)), http.StatusOK, "invoice.paid invoice.paid " + payload},
+		{"invalid signature", "forged", http.StatusUnauthorized, "invalid signature"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+			req.Header.Set("X-Signature", tc.signature)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("payload limit", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.ContentLength = -1
+
+		ctx := newContext(httptest.NewRecorder(), req)
+		ctx.handlers = []HandlerFunc{PayloadLimit(PayloadLimitConfig{MaxBodySize: 4}), func(c *Context) {
+			if _, err := c.RawBody(); !errors.Is(err, ErrPayloadTooLarge) {
+				st.Errorf("expected raw body error to be ErrPayloadTooLarge; got %v", err)
+			}
+		}}
+		ctx.Next()
+	})
+
+	t.Run("default buffer limit", func(st *testing.T) {
+		defaultMaxBufferedBody := DefaultMaxBufferedBody
+		DefaultMaxBufferedBody = 4
+		defer func() { DefaultMaxBufferedBody = defaultMaxBufferedBody }()
+
+		req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		ctx := newContext(httptest.NewRecorder(), req)
+		if _, err := ctx.RawBody(); !errors.Is(err, ErrPayloadTooLarge) {
+			st.Errorf("expected raw body error to be ErrPayloadTooLarge; got %v", err)
+		}
+	})
+}
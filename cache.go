@@ -0,0 +1,329 @@
+package nano
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderXCache tells whether response is served from cache.
+const HeaderXCache = "X-Cache"
+
+// CachedResponse is full response stored in cache store.
+type CachedResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// CacheStore defines response cache storage, implement it to share cache between instances, e.g. using Redis.
+type CacheStore interface {
+	// Get returns cached response of the key, it returns false when the key is missing or expired.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores response for ttl duration.
+	Set(key string, response *CachedResponse, ttl time.Duration)
+}
+
+// CacheConfig defines nano response cache middleware configuration.
+type CacheConfig struct {
+	// TTL is how long response is cached.
+	TTL time.Duration
+	// Store is cache storage, default is in-memory LRU store with 1000 capacity.
+	Store CacheStore
+	// VaryHeaders is list of request header which is part of the cache key, e.g. Accept-Language.
+	VaryHeaders []string
+	// Statuses is list of cacheable response status code, default is 200.
+	Statuses []int
+}
+
+// Cache returns response cache middleware using given ttl and store.
+// pass nil store to use in-memory LRU store.
+func Cache(ttl time.Duration, store CacheStore) HandlerFunc {
+	return CacheWithConfig(CacheConfig{TTL: ttl, Store: store})
+}
+
+// CacheWithConfig returns response cache middleware.
+// only GET & HEAD requests are cached, keyed by method, url, and vary headers.
+// Request with Cache-Control: no-cache skips the lookup, and no-store skips the cache entirely.
+// Response is not stored when handler calls c.NoCache, sets cookie, or sends Cache-Control: no-store, no-cache, or private.
+// Concurrent misses of the same key wait for the first one, so expensive handler isn't stampeded when entry expires.
+func CacheWithConfig(config CacheConfig) HandlerFunc {
+	if config.Store == nil {
+		config.Store = NewMemoryCacheStore(1000)
+	}
+
+	if len(config.Statuses) == 0 {
+		config.Statuses = []int{http.StatusOK}
+	}
+
+	flights := &cacheFlights{calls: make(map[string]chan struct{})}
+
+	return func(c *Context) {
+		if c.Method != http.MethodGet && c.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		directives := c.GetRequestHeader(HeaderCacheControl)
+		if hasCacheDirective(directives, "no-store") {
+			c.Next()
+			return
+		}
+
+		key := config.key(c)
+		lookup := !hasCacheDirective(directives, "no-cache")
+
+		for {
+			if lookup {
+				if cached, ok := config.Store.Get(key); ok {
+					writeCachedResponse(c, cached)
+					return
+				}
+			}
+
+			// only the leader runs handler, the others wait for its response.
+			done, leader := flights.join(key)
+			if leader {
+				defer flights.leave(key, done)
+				break
+			}
+
+			<-done
+			// leader response may not be cacheable, so the next round becomes leader or reads the cache.
+			lookup = true
+		}
+
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.SetHeader(HeaderXCache, "MISS")
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if !config.cacheable(c, writer) {
+			return
+		}
+
+		config.Store.Set(key, &CachedResponse{
+			Status: writer.status,
+			Header: writer.header,
+			Body:   writer.body.Bytes(),
+		}, config.TTL)
+	}
+}
+
+// key returns cache key of the request.
+func (config CacheConfig) key(c *Context) string {
+	var key strings.Builder
+	key.WriteString(c.Method)
+	key.WriteString(" ")
+	key.WriteString(c.Request.URL.RequestURI())
+
+	for _, header := range config.VaryHeaders {
+		key.WriteString("\n")
+		key.WriteString(header)
+		key.WriteString(": ")
+		key.WriteString(c.GetRequestHeader(header))
+	}
+
+	return key.String()
+}
+
+// cacheable returns true when captured response should be stored.
+func (config CacheConfig) cacheable(c *Context, writer *cacheWriter) bool {
+	if c.noCache || writer.flushed || writer.header == nil {
+		return false
+	}
+
+	if writer.header.Get("Set-Cookie") != "" {
+		return false
+	}
+
+	directives := writer.header.Get(HeaderCacheControl)
+	if hasCacheDirective(directives, "no-store") || hasCacheDirective(directives, "no-cache") || hasCacheDirective(directives, "private") {
+		return false
+	}
+
+	for _, status := range config.Statuses {
+		if status == writer.status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NoCache functions to opt out current response from Cache middleware.
+func (c *Context) NoCache() {
+	c.noCache = true
+}
+
+// writeCachedResponse writes cached response to client.
+func writeCachedResponse(c *Context, cached *CachedResponse) {
+	header := c.Writer.Header()
+	for name, values := range cached.Header {
+		header[name] = values
+	}
+
+	header.Set(HeaderXCache, "HIT")
+	c.Writer.WriteHeader(cached.Status)
+
+	if c.Method != http.MethodHead {
+		c.Writer.Write(cached.Body)
+	}
+}
+
+// hasCacheDirective returns true when Cache-Control header value contains the directive.
+func hasCacheDirective(header, directive string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name := strings.TrimSpace(strings.SplitN(part, "=", 2)[0])
+		if strings.EqualFold(name, directive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cacheFlights tracks in-flight requests of each cache key.
+type cacheFlights struct {
+	mu    sync.Mutex
+	calls map[string]chan struct{}
+}
+
+// join returns channel closed when the key leader is done, and whether caller becomes the leader.
+func (f *cacheFlights) join(key string) (chan struct{}, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if done, exists := f.calls[key]; exists {
+		return done, false
+	}
+
+	done := make(chan struct{})
+	f.calls[key] = done
+
+	return done, true
+}
+
+// leave releases waiting requests of the key.
+func (f *cacheFlights) leave(key string, done chan struct{}) {
+	f.mu.Lock()
+	delete(f.calls, key)
+	f.mu.Unlock()
+
+	close(done)
+}
+
+// cacheWriter writes response to client and captures it to be stored.
+type cacheWriter struct {
+	http.ResponseWriter
+	status  int
+	header  http.Header
+	body    bytes.Buffer
+	flushed bool
+}
+
+// WriteHeader captures status code & headers.
+func (w *cacheWriter) WriteHeader(code int) {
+	if w.header == nil {
+		w.status = code
+		w.header = w.ResponseWriter.Header().Clone()
+		w.header.Del(HeaderXCache)
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures response body.
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	if w.header == nil {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.body.Write(data)
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher. streamed response is never cached.
+func (w *cacheWriter) Flush() {
+	w.flushed = true
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// memoryCacheStore is in-memory LRU cache store.
+type memoryCacheStore struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// memoryCacheEntry is value of memoryCacheStore list element.
+type memoryCacheEntry struct {
+	key       string
+	response  *CachedResponse
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore creates in-memory LRU cache store, least recently used entry is evicted when capacity is reached.
+func NewMemoryCacheStore(capacity int) CacheStore {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+
+	return &memoryCacheStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns cached response of the key.
+func (s *memoryCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, exists := s.items[key]
+	if !exists {
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.order.Remove(element)
+		delete(s.items, key)
+		return nil, false
+	}
+
+	s.order.MoveToFront(element)
+
+	return entry.response, true
+}
+
+// Set stores response for ttl duration.
+func (s *memoryCacheStore) Set(key string, response *CachedResponse, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, response: response, expiresAt: time.Now().Add(ttl)}
+	if element, exists := s.items[key]; exists {
+		element.Value = entry
+		s.order.MoveToFront(element)
+		return
+	}
+
+	s.items[key] = s.order.PushFront(entry)
+
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
@@ -0,0 +1,165 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	tt := []struct {
+		name     string
+		handler  HandlerFunc
+		header   map[string]string
+		calls    int32
+		xcache   string
+		cacheHit bool
+	}{
+		{"cacheable response", func(c *Context) { c.String(http.StatusOK, "ok") }, nil, 1, "HIT", true},
+		{"handler opts out", func(c *Context) { c.NoCache(); c.String(http.StatusOK, "ok") }, nil, 2, "MISS", false},
+		{"error response", func(c *Context) { c.String(http.StatusInternalServerError, "error") }, nil, 2, "MISS", false},
+		{"private response", func(c *Context) {
+			c.SetHeader(HeaderCacheControl, "private, max-age=60")
+			c.String(http.StatusOK, "ok")
+		}, nil, 2, "MISS", false},
+		{"client bypass", func(c *Context) { c.String(http.StatusOK, "ok") }, map[string]string{HeaderCacheControl: "no-cache"}, 2, "MISS", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			var calls int32
+
+			app := New()
+			app.Use(Cache(time.Minute, nil))
+			app.GET("/", func(c *Context) {
+				atomic.AddInt32(&calls, 1)
+				tc.handler(c)
+			})
+
+			var rec *httptest.ResponseRecorder
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest(http.MethodGet, "/", nil)
+				if err != nil {
+					log.Fatalf("could not create http request: %v", err)
+				}
+
+				for key, value := range tc.header {
+					req.Header.Set(key, value)
+				}
+
+				rec = httptest.NewRecorder()
+				app.ServeHTTP(rec, req)
+			}
+
+			if calls != tc.calls {
+				st.Errorf("expected handler to be called %d times; got %d", tc.calls, calls)
+			}
+
+			if xcache := rec.Header().Get(HeaderXCache); xcache != tc.xcache {
+				st.Errorf("expected X-Cache header to be %s; got %s", tc.xcache, xcache)
+			}
+
+			if tc.cacheHit && rec.Body.String() != "ok" {
+				st.Errorf("expected cached body to be ok; got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCacheVaryHeaders(t *testing.T) {
+	app := New()
+	app.Use(CacheWithConfig(CacheConfig{TTL: time.Minute, VaryHeaders: []string{"Accept-Language"}}))
+	app.GET("/greeting", func(c *Context) {
+		if c.GetRequestHeader("Accept-Language") == "id" {
+			c.String(http.StatusOK, "halo")
+			return
+		}
+
+		c.String(http.StatusOK, "hello")
+	})
+
+	for _, lang := range []string{"id", "en", "id"} {
+		req, err := http.NewRequest(http.MethodGet, "/greeting", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set("Accept-Language", lang)
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		expected := map[string]string{"id": "halo", "en": "hello"}[lang]
+		if rec.Body.String() != expected {
+			t.Errorf("expected body of %s to be %s; got %s", lang, expected, rec.Body.String())
+		}
+	}
+}
+
+func TestCacheStampede(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+
+	app := New()
+	app.Use(Cache(time.Minute, nil))
+	app.GET("/report", func(c *Context) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		c.String(http.StatusOK, "report")
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, err := http.NewRequest(http.MethodGet, "/report", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Body.String() != "report" {
+				t.Errorf("expected body to be report; got %s", rec.Body.String())
+			}
+		}()
+	}
+
+	// give the requests time to queue behind the first one.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected handler to be called once; got %d", calls)
+	}
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore(2)
+	store.Set("a", &CachedResponse{Status: http.StatusOK}, time.Minute)
+	store.Set("b", &CachedResponse{Status: http.StatusOK}, time.Minute)
+
+	// a becomes the most recently used, so b is evicted.
+	store.Get("a")
+	store.Set("c", &CachedResponse{Status: http.StatusOK}, time.Minute)
+
+	if _, ok := store.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+
+	if _, ok := store.Get("a"); !ok {
+		t.Errorf("expected a to be cached")
+	}
+
+	store.Set("d", &CachedResponse{Status: http.StatusOK}, -time.Second)
+	if _, ok := store.Get("d"); ok {
+		t.Errorf("expected d to be expired")
+	}
+}
@@ -0,0 +1,356 @@
+package nano
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// api change kinds.
+const (
+	APIRouteAdded   = "route_added"
+	APIRouteRemoved = "route_removed"
+	APIFieldAdded   = "field_added"
+	APIFieldRemoved = "field_removed"
+	APIFieldChanged = "field_changed"
+)
+
+// APISnapshot describes api surface, that is route table and payload schemas.
+// Store it as json, then compare it with the current one using DiffAPISnapshot.
+type APISnapshot struct {
+	Routes []RouteSchema `json:"routes"`
+}
+
+// RouteSchema describes single route of api snapshot.
+// payload schemas are described using Route.WithRequest and Route.WithResponse.
+type RouteSchema struct {
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Request  []FieldSchema `json:"request,omitempty"`
+	Response []FieldSchema `json:"response,omitempty"`
+}
+
+// FieldSchema describes single payload field. nested field name is joined using dot, e.g. "address.city",
+// and slice element field is suffixed with [], e.g. "items[].sku".
+type FieldSchema struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	// Enum is list of allowed values from `enum` tag.
+	Enum []string `json:"enum,omitempty"`
+}
+
+// APIChange defines single change between two api snapshots.
+type APIChange struct {
+	Kind string `json:"kind"`
+	// Route is method and path of changed route, e.g. "GET /users/:id".
+	Route string `json:"route"`
+	// Field is changed field prefixed by payload, e.g. "request.name".
+	Field    string `json:"field,omitempty"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+// APIChangelog is list of changes between two api snapshots.
+// Breaking is true when at least one change could break existing clients, so it could be used as CI gate.
+type APIChangelog struct {
+	Changes  []APIChange `json:"changes"`
+	Breaking bool        `json:"breaking"`
+}
+
+// APISnapshot functions to snapshot registered routes and their payload schemas, sorted by path and method.
+func (ng *Engine) APISnapshot() APISnapshot {
+	snapshot := APISnapshot{Routes: make([]RouteSchema, 0, len(ng.router.routes))}
+
+	for _, route := range ng.router.routes {
+		snapshot.Routes = append(snapshot.Routes, RouteSchema{
+			Method:   route.info.Method,
+			Path:     route.info.Path,
+			Request:  schemaOf(route.request),
+			Response: schemaOf(route.response),
+		})
+	}
+
+	sort.Slice(snapshot.Routes, func(i, j int) bool {
+		a, b := snapshot.Routes[i], snapshot.Routes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		return a.Method < b.Method
+	})
+
+	return snapshot
+}
+
+// ReadAPISnapshot functions to read api snapshot stored as json, e.g. from testdata/api.json.
+func ReadAPISnapshot(r io.Reader) (APISnapshot, error) {
+	var snapshot APISnapshot
+	err := json.NewDecoder(r).Decode(&snapshot)
+
+	return snapshot, err
+}
+
+// DiffAPISnapshot returns changelog from previous to current api snapshot.
+// Removed route, removed response field, changed field type, and new required request field are breaking changes.
+func DiffAPISnapshot(previous, current APISnapshot) APIChangelog {
+	changelog := APIChangelog{Changes: make([]APIChange, 0)}
+
+	oldRoutes := make(map[string]RouteSchema, len(previous.Routes))
+	for _, route := range previous.Routes {
+		oldRoutes[route.Method+" "+route.Path] = route
+	}
+
+	for _, route := range current.Routes {
+		key := route.Method + " " + route.Path
+		before, exists := oldRoutes[key]
+		delete(oldRoutes, key)
+
+		if !exists {
+			changelog.add(APIChange{Kind: APIRouteAdded, Route: key})
+			continue
+		}
+
+		changelog.diffFields(key, "request", before.Request, route.Request)
+		changelog.diffFields(key, "response", before.Response, route.Response)
+	}
+
+	removed := make([]string, 0, len(oldRoutes))
+	for key := range oldRoutes {
+		removed = append(removed, key)
+	}
+
+	sort.Strings(removed)
+	for _, key := range removed {
+		changelog.add(APIChange{Kind: APIRouteRemoved, Route: key, Breaking: true})
+	}
+
+	return changelog
+}
+
+// add appends change into changelog.
+func (cl *APIChangelog) add(change APIChange) {
+	cl.Changes = append(cl.Changes, change)
+	cl.Breaking = cl.Breaking || change.Breaking
+}
+
+// diffFields compares fields of request or response payload.
+func (cl *APIChangelog) diffFields(route, payload string, previous, current []FieldSchema) {
+	isRequest := payload == "request"
+
+	oldFields := make(map[string]FieldSchema, len(previous))
+	for _, field := range previous {
+		oldFields[field.Name] = field
+	}
+
+	for _, field := range current {
+		before, exists := oldFields[field.Name]
+		delete(oldFields, field.Name)
+
+		name := payload + "." + field.Name
+		if !exists {
+			// client doesn't know the new field, so it breaks only when the field is required.
+			cl.add(APIChange{Kind: APIFieldAdded, Route: route, Field: name, New: field.String(), Breaking: isRequest && field.Required})
+			continue
+		}
+
+		if !reflect.DeepEqual(before, field) {
+			breaking := before.Type != field.Type || (isRequest && field.Required && !before.Required)
+			// client may send removed enum value, or may not understand new enum value of response.
+			if isRequest {
+				breaking = breaking || !enumSubset(before.Enum, field.Enum)
+			} else {
+				breaking = breaking || !enumSubset(field.Enum, before.Enum)
+			}
+
+			cl.add(APIChange{Kind: APIFieldChanged, Route: route, Field: name, Old: before.String(), New: field.String(), Breaking: breaking})
+		}
+	}
+
+	// keep declaration order of removed fields.
+	for _, field := range previous {
+		if _, removed := oldFields[field.Name]; removed {
+			cl.add(APIChange{Kind: APIFieldRemoved, Route: route, Field: payload + "." + field.Name, Old: field.String(), Breaking: !isRequest})
+		}
+	}
+}
+
+// String describes field type, e.g. "string,required,enum=asc|desc".
+func (f FieldSchema) String() string {
+	description := f.Type
+	if f.Required {
+		description += ",required"
+	}
+
+	if len(f.Enum) > 0 {
+		description += ",enum=" + strings.Join(f.Enum, "|")
+	}
+
+	return description
+}
+
+// enumSubset returns true when each value of enum is allowed by superset.
+// empty enum allows any value.
+func enumSubset(enum, superset []string) bool {
+	if len(superset) == 0 {
+		return true
+	}
+
+	if len(enum) == 0 {
+		return false
+	}
+
+	for _, value := range enum {
+		found := false
+		for _, allowed := range superset {
+			found = found || value == allowed
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// schemaOf returns fields of payload type. non-struct payload is described as single field named "body".
+func schemaOf(t reflect.Type) []FieldSchema {
+	if t == nil {
+		return nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make([]FieldSchema, 0)
+	switch {
+	case isSchemaStruct(t):
+		fields = appendSchemaFields(fields, t, "", map[reflect.Type]bool{})
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && isSchemaStruct(elemOf(t)):
+		fields = append(fields, FieldSchema{Name: "body", Type: schemaType(t)})
+		fields = appendSchemaFields(fields, elemOf(t), "body[].", map[reflect.Type]bool{})
+	default:
+		fields = append(fields, FieldSchema{Name: "body", Type: schemaType(t)})
+	}
+
+	return fields
+}
+
+// appendSchemaFields appends exported fields of struct recursively.
+// visited guards against self-referencing types.
+func appendSchemaFields(fields []FieldSchema, t reflect.Type, prefix string, visited map[reflect.Type]bool) []FieldSchema {
+	if visited[t] {
+		return fields
+	}
+
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := schemaFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		// embedded struct without tag is flattened like encoding/json does.
+		if field.Anonymous && field.Tag.Get("json") == "" && isSchemaStruct(elemOf(field.Type)) {
+			fields = appendSchemaFields(fields, elemOf(field.Type), prefix, visited)
+			continue
+		}
+
+		fields = append(fields, FieldSchema{
+			Name:     prefix + name,
+			Type:     schemaType(field.Type),
+			Required: hasValidationRule(field.Tag.Get("validate"), "required"),
+			Enum:     enumValues(field),
+		})
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case isSchemaStruct(fieldType):
+			fields = appendSchemaFields(fields, fieldType, prefix+name+".", visited)
+		case (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) && isSchemaStruct(elemOf(fieldType)):
+			fields = appendSchemaFields(fields, elemOf(fieldType), prefix+name+"[].", visited)
+		}
+	}
+
+	return fields
+}
+
+// schemaFieldName returns payload field name using json tag, then form tag.
+// it returns empty string when field is ignored.
+func schemaFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// schemaType returns language neutral type name, e.g. "[]int64" or "object".
+func schemaType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return "time"
+	case t.Kind() == reflect.Struct || t.Kind() == reflect.Interface:
+		return "object"
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return "[]" + schemaType(t.Elem())
+	case t.Kind() == reflect.Map:
+		return "map[" + schemaType(t.Key()) + "]" + schemaType(t.Elem())
+	}
+
+	return t.Kind().String()
+}
+
+// elemOf returns element type of pointer, slice, or array. other type is returned as is.
+func elemOf(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return elemOf(t.Elem())
+	}
+
+	return t
+}
+
+// isSchemaStruct returns true when t is struct described field by field.
+func isSchemaStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// hasValidationRule returns true when validate tag contains the rule.
+func hasValidationRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == rule {
+			return true
+		}
+	}
+
+	return false
+}
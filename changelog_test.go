@@ -0,0 +1,117 @@
+package nano
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type changelogAddress struct {
+	City string `json:"city"`
+}
+
+type changelogUserV1 struct {
+	ID      int              `json:"id"`
+	Name    string           `json:"name" validate:"required"`
+	Email   string           `json:"email"`
+	Address changelogAddress `json:"address"`
+}
+
+type changelogUserV2 struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name" validate:"required"`
+	Phone     string             `json:"phone" validate:"required"`
+	Addresses []changelogAddress `json:"addresses"`
+	CreatedAt time.Time          `json:"created_at"`
+	Secret    string             `json:"-"`
+}
+
+func TestAPISnapshot(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", func(c *Context) {}).WithResponse(&changelogUserV1{})
+	app.POST("/users", func(c *Context) {}).WithRequest(changelogUserV1{})
+	app.GET("/tags", func(c *Context) {}).WithResponse([]string{})
+
+	snapshot := app.APISnapshot()
+
+	expected := []RouteSchema{
+		{Method: "GET", Path: "/tags", Response: []FieldSchema{{Name: "body", Type: "[]string"}}},
+		{Method: "POST", Path: "/users", Request: []FieldSchema{
+			{Name: "id", Type: "int"},
+			{Name: "name", Type: "string", Required: true},
+			{Name: "email", Type: "string"},
+			{Name: "address", Type: "object"},
+			{Name: "address.city", Type: "string"},
+		}},
+		{Method: "GET", Path: "/users/:id", Response: []FieldSchema{
+			{Name: "id", Type: "int"},
+			{Name: "name", Type: "string", Required: true},
+			{Name: "email", Type: "string"},
+			{Name: "address", Type: "object"},
+			{Name: "address.city", Type: "string"},
+		}},
+	}
+
+	if !reflect.DeepEqual(snapshot.Routes, expected) {
+		t.Errorf("expected snapshot routes to be %+v; got %+v", expected, snapshot.Routes)
+	}
+
+	// snapshot should survive json round trip.
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("could not marshal snapshot: %v", err)
+	}
+
+	stored, err := ReadAPISnapshot(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("could not read snapshot: %v", err)
+	}
+
+	if changes := DiffAPISnapshot(stored, snapshot).Changes; len(changes) != 0 {
+		t.Errorf("expected no changes after round trip; got %+v", changes)
+	}
+}
+
+func TestDiffAPISnapshot(t *testing.T) {
+	v1 := New()
+	v1.GET("/users/:id", func(c *Context) {}).WithResponse(changelogUserV1{})
+	v1.POST("/users", func(c *Context) {}).WithRequest(changelogUserV1{})
+	v1.DELETE("/users/:id", func(c *Context) {})
+
+	v2 := New()
+	v2.GET("/users/:id", func(c *Context) {}).WithResponse(changelogUserV2{})
+	v2.POST("/users", func(c *Context) {}).WithRequest(changelogUserV2{})
+	v2.GET("/users", func(c *Context) {})
+
+	changelog := DiffAPISnapshot(v1.APISnapshot(), v2.APISnapshot())
+
+	expected := []APIChange{
+		{Kind: APIRouteAdded, Route: "GET /users"},
+		{Kind: APIFieldChanged, Route: "POST /users", Field: "request.id", Old: "int", New: "string", Breaking: true},
+		{Kind: APIFieldAdded, Route: "POST /users", Field: "request.phone", New: "string,required", Breaking: true},
+		{Kind: APIFieldAdded, Route: "POST /users", Field: "request.addresses", New: "[]object"},
+		{Kind: APIFieldAdded, Route: "POST /users", Field: "request.addresses[].city", New: "string"},
+		{Kind: APIFieldAdded, Route: "POST /users", Field: "request.created_at", New: "time"},
+		{Kind: APIFieldRemoved, Route: "POST /users", Field: "request.email", Old: "string"},
+		{Kind: APIFieldRemoved, Route: "POST /users", Field: "request.address", Old: "object"},
+		{Kind: APIFieldRemoved, Route: "POST /users", Field: "request.address.city", Old: "string"},
+		{Kind: APIFieldChanged, Route: "GET /users/:id", Field: "response.id", Old: "int", New: "string", Breaking: true},
+		{Kind: APIFieldAdded, Route: "GET /users/:id", Field: "response.phone", New: "string,required"},
+		{Kind: APIFieldAdded, Route: "GET /users/:id", Field: "response.addresses", New: "[]object"},
+		{Kind: APIFieldAdded, Route: "GET /users/:id", Field: "response.addresses[].city", New: "string"},
+		{Kind: APIFieldAdded, Route: "GET /users/:id", Field: "response.created_at", New: "time"},
+		{Kind: APIFieldRemoved, Route: "GET /users/:id", Field: "response.email", Old: "string", Breaking: true},
+		{Kind: APIFieldRemoved, Route: "GET /users/:id", Field: "response.address", Old: "object", Breaking: true},
+		{Kind: APIFieldRemoved, Route: "GET /users/:id", Field: "response.address.city", Old: "string", Breaking: true},
+		{Kind: APIRouteRemoved, Route: "DELETE /users/:id", Breaking: true},
+	}
+
+	if !reflect.DeepEqual(changelog.Changes, expected) {
+		t.Errorf("expected changes to be:\n%+v\ngot:\n%+v", expected, changelog.Changes)
+	}
+
+	if !changelog.Breaking {
+		t.Errorf("expected changelog to be breaking")
+	}
+}
@@ -0,0 +1,236 @@
+package nano
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CircuitState is state of circuit breaker of a route.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through while counting their failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fast-fails requests until OpenTimeout elapses.
+	CircuitOpen
+	// CircuitHalfOpen lets limited number of probe requests through, they decide whether the circuit closes or opens again.
+	CircuitHalfOpen
+)
+
+// String returns name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, so the state is listed by name in json, e.g. admin stats.
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CircuitBreakerConfig defines nano circuit breaker middleware configuration.
+type CircuitBreakerConfig struct {
+	// FailureRatio is ratio of failed requests within Window opening the circuit, default is 0.5.
+	FailureRatio float64
+	// MinRequests is minimum number of requests within Window before the ratio is checked, default is 10.
+	MinRequests int
+	// Window is duration of failure counting window of closed circuit, default is 10 seconds.
+	Window time.Duration
+	// SlowThreshold counts request taking longer than it as failure, zero disables latency check.
+	SlowThreshold time.Duration
+	// OpenTimeout is how long the circuit stays open before probing, default is 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is number of probe requests of half-open circuit, the circuit closes when all of them succeed.
+	// default is 1.
+	HalfOpenRequests int
+	// IsFailure returns true when the request failed, default checks 5xx status code. panic is always a failure.
+	IsFailure func(c *Context) bool
+	// Fallback responds fast-failed request, default responds 503 status code. Retry-After header is already set.
+	Fallback HandlerFunc
+}
+
+// circuit is circuit breaker state of single route.
+type circuit struct {
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+}
+
+// Breaker protects downstream of each route using circuit breaker, keyed by request method & route pattern.
+// Failing route is fast-failed for a while, so the downstream could recover instead of being hammered.
+type Breaker struct {
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewBreaker creates circuit breaker.
+func NewBreaker(config CircuitBreakerConfig) *Breaker {
+	if config.FailureRatio <= 0 {
+		config.FailureRatio = 0.5
+	}
+
+	if config.MinRequests <= 0 {
+		config.MinRequests = 10
+	}
+
+	if config.Window <= 0 {
+		config.Window = 10 * time.Second
+	}
+
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	if config.IsFailure == nil {
+		config.IsFailure = func(c *Context) bool {
+			return c.response.status >= http.StatusInternalServerError
+		}
+	}
+
+	if config.Fallback == nil {
+		config.Fallback = func(c *Context) {
+			c.String(http.StatusServiceUnavailable, "service unavailable")
+		}
+	}
+
+	return &Breaker{config: config, circuits: make(map[string]*circuit)}
+}
+
+// allow returns true when request of the circuit key could go through,
+// otherwise it returns how long the circuit stays open.
+func (b *Breaker) allow(key string, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cc, ok := b.circuits[key]
+	if !ok {
+		cc = &circuit{windowStart: now}
+		b.circuits[key] = cc
+	}
+
+	switch cc.state {
+	case CircuitOpen:
+		if remaining := b.config.OpenTimeout - now.Sub(cc.openedAt); remaining > 0 {
+			return false, remaining
+		}
+
+		cc.state, cc.probes, cc.successes = CircuitHalfOpen, 0, 0
+		fallthrough
+	case CircuitHalfOpen:
+		if cc.probes >= b.config.HalfOpenRequests {
+			return false, b.config.OpenTimeout
+		}
+
+		cc.probes++
+	default:
+		if now.Sub(cc.windowStart) >= b.config.Window {
+			cc.windowStart, cc.requests, cc.failures = now, 0, 0
+		}
+	}
+
+	return true, 0
+}
+
+// record counts result of request of the circuit key, and moves the circuit into the next state.
+func (b *Breaker) record(key string, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cc := b.circuits[key]
+
+	switch cc.state {
+	case CircuitHalfOpen:
+		if failed {
+			cc.state, cc.openedAt = CircuitOpen, now
+			return
+		}
+
+		cc.successes++
+		if cc.successes >= b.config.HalfOpenRequests {
+			cc.state, cc.windowStart, cc.requests, cc.failures = CircuitClosed, now, 0, 0
+		}
+	case CircuitClosed:
+		cc.requests++
+		if failed {
+			cc.failures++
+		}
+
+		if cc.requests >= b.config.MinRequests && float64(cc.failures)/float64(cc.requests) >= b.config.FailureRatio {
+			cc.state, cc.openedAt = CircuitOpen, now
+		}
+	}
+}
+
+// States returns circuit state of each route, keyed by request method & route pattern, e.g. "GET /users/:id".
+// Use it as admin stats provider to watch the circuits.
+func (b *Breaker) States() map[string]CircuitState {
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]CircuitState, len(b.circuits))
+	for key, cc := range b.circuits {
+		state := cc.state
+		if state == CircuitOpen && now.Sub(cc.openedAt) >= b.config.OpenTimeout {
+			state = CircuitHalfOpen
+		}
+
+		states[key] = state
+	}
+
+	return states
+}
+
+// Handle passes request through circuit of its route, it fast-fails request of open circuit using Fallback.
+// Request which doesn't match any route is not protected.
+func (b *Breaker) Handle(c *Context) {
+	if c.routePattern == "" {
+		c.Next()
+		return
+	}
+
+	key := c.Method + " " + c.routePattern
+	start := time.Now()
+
+	allowed, retryAfter := b.allow(key, start)
+	if !allowed {
+		c.SetHeader(HeaderRetryAfter, strconv.Itoa(int(retryAfter.Seconds()+1)))
+		b.config.Fallback(c)
+		return
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.record(key, true, time.Now())
+			panic(recovered)
+		}
+	}()
+
+	c.Next()
+
+	failed := b.config.IsFailure(c) || (b.config.SlowThreshold > 0 && time.Since(start) > b.config.SlowThreshold)
+	b.record(key, failed, time.Now())
+}
+
+// CircuitBreaker returns circuit breaker middleware, each route has its own circuit.
+func CircuitBreaker(config CircuitBreakerConfig) HandlerFunc {
+	return NewBreaker(config).Handle
+}
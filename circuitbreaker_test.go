@@ -0,0 +1,104 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	failing := true
+
+	breaker := NewBreaker(CircuitBreakerConfig{
+		MinRequests:   2,
+		OpenTimeout:   30 * time.Millisecond,
+		SlowThreshold: 20 * time.Millisecond,
+	})
+
+	app := New()
+	app.Use(breaker.Handle)
+	app.GET("/orders/:id", func(c *Context) {
+		if failing {
+			c.String(http.StatusBadGateway, "downstream error")
+			return
+		}
+
+		c.String(http.StatusOK, "order %s", c.Param("id"))
+	})
+	app.GET("/slow", func(c *Context) {
+		time.Sleep(30 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+	app.GET("/healthy", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	request := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	request("/orders/1")
+	request("/orders/2")
+
+	rec := request("/orders/3")
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get(HeaderRetryAfter) == "" {
+		t.Errorf("expected open circuit to fast-fail with retry after; got %d %q", rec.Code, rec.Header().Get(HeaderRetryAfter))
+	}
+
+	if rec := request("/healthy"); rec.Code != http.StatusOK {
+		t.Errorf("expected circuit of other route to stay closed; got %d", rec.Code)
+	}
+
+	if state := breaker.States()["GET /orders/:id"]; state != CircuitOpen {
+		t.Errorf("expected circuit state to be open; got %s", state)
+	}
+
+	t.Run("failed probe opens the circuit again", func(st *testing.T) {
+		time.Sleep(40 * time.Millisecond)
+
+		if state := breaker.States()["GET /orders/:id"]; state != CircuitHalfOpen {
+			st.Errorf("expected circuit state to be half-open; got %s", state)
+		}
+
+		if rec := request("/orders/4"); rec.Code != http.StatusBadGateway {
+			st.Errorf("expected probe request to go through; got %d", rec.Code)
+		}
+
+		if rec := request("/orders/5"); rec.Code != http.StatusServiceUnavailable {
+			st.Errorf("expected circuit to be open after failed probe; got %d", rec.Code)
+		}
+	})
+
+	t.Run("successful probe closes the circuit", func(st *testing.T) {
+		failing = false
+		time.Sleep(40 * time.Millisecond)
+
+		for _, path := range []string{"/orders/6", "/orders/7"} {
+			if rec := request(path); rec.Code != http.StatusOK {
+				st.Errorf("expected request of %s to succeed; got %d", path, rec.Code)
+			}
+		}
+
+		if state := breaker.States()["GET /orders/:id"]; state != CircuitClosed {
+			st.Errorf("expected circuit state to be closed; got %s", state)
+		}
+	})
+
+	t.Run("slow requests", func(st *testing.T) {
+		request("/slow")
+		request("/slow")
+
+		if rec := request("/slow"); rec.Code != http.StatusServiceUnavailable {
+			st.Errorf("expected slow route to be fast-failed; got %d", rec.Code)
+		}
+	})
+}
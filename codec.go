@@ -0,0 +1,120 @@
+package nano
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Codec defines marshaler & unmarshaler adapter of a content type.
+// nano doesn't ship msgpack or protobuf implementation, so you could pick the library you like,
+// e.g. wrap github.com/vmihailenco/msgpack or google.golang.org/protobuf/proto.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// RegisterCodec functions to register codec for given content type.
+// Registered codec is used by Context.Encode to write response, and by Bind to decode request body.
+func (ng *Engine) RegisterCodec(contentType string, codec Codec) {
+	if ng.codecs == nil {
+		ng.codecs = make(map[string]Codec)
+	}
+
+	ng.codecs[mediaType(contentType)] = codec
+}
+
+// mediaType returns content type without its parameters.
+func mediaType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+}
+
+// codecOf returns registered codec of content type, it may return nil.
+func (c *Context) codecOf(contentType string) Codec {
+	if c.engine == nil {
+		return nil
+	}
+
+	return c.engine.codecs[mediaType(contentType)]
+}
+
+// Encode writes object as response using codec registered for contentType.
+func (c *Context) Encode(statusCode int, contentType string, object interface{}) {
+	codec := c.codecOf(contentType)
+	if codec == nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	rs, err := codec.Marshal(object)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	c.SetContentType(contentType)
+	c.Status(statusCode)
+	c.Writer.Write(rs)
+}
+
+// MsgPack writes msgpack as response, msgpack codec must be registered first.
+func (c *Context) MsgPack(statusCode int, object interface{}) {
+	c.Encode(statusCode, MimeMsgPack, object)
+}
+
+// ProtoBuf writes protobuf message as response, protobuf codec must be registered first.
+func (c *Context) ProtoBuf(statusCode int, message interface{}) {
+	c.Encode(statusCode, MimeProtoBuf, message)
+}
+
+// BindMsgPack functions to bind request body (with content type application/x-msgpack) to targetStruct.
+// targetStruct must be pointer to user defined struct.
+func (c *Context) BindMsgPack(targetStruct interface{}) error {
+	return c.bindCodecAndValidate(MimeMsgPack, targetStruct)
+}
+
+// BindProtoBuf functions to bind request body (with content type application/x-protobuf) to target message.
+// target must be pointer to generated protobuf message.
+func (c *Context) BindProtoBuf(target interface{}) error {
+	return c.bindCodecAndValidate(MimeProtoBuf, target)
+}
+
+// bindCodecAndValidate binds request body using codec of content type, and then validates the target.
+func (c *Context) bindCodecAndValidate(contentType string, targetStruct interface{}) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		codec := c.codecOf(contentType)
+		if codec == nil {
+			return ErrBindContentType
+		}
+
+		return c.bindCodec(codec, targetStruct)
+	})
+}
+
+// bindCodec decodes request body using given codec without validation.
+func (c *Context) bindCodec(codec Codec, targetStruct interface{}) error {
+	if c.Request.Body == nil {
+		return nil
+	}
+	defer c.Request.Body.Close()
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return c.errPayload(err)
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := codec.Unmarshal(body, targetStruct); err != nil {
+		return ErrBinding{
+			Status: http.StatusBadRequest,
+			Text:   fmt.Sprintf("could not decode request body: %v", err),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package nano
+
+import (
+	"bytes"
+	"encoding/gob"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// gobCodec is used as stand-in for msgpack codec.
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func TestCodec(t *testing.T) {
+	type Person struct {
+		Name string `validate:"required"`
+	}
+
+	app := New()
+	app.RegisterCodec(MimeMsgPack, gobCodec{})
+
+	app.POST("/", func(c *Context) {
+		var person Person
+		if err := c.Bind(&person); err != nil {
+			c.String(err.(ErrBinding).Status, err.Error())
+			return
+		}
+
+		c.MsgPack(http.StatusOK, person)
+	})
+
+	app.POST("/proto", func(c *Context) {
+		c.ProtoBuf(http.StatusOK, Person{})
+	})
+
+	body, _ := gobCodec{}.Marshal(Person{Name: "foo"})
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeMsgPack)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status code to be 200; got %d (%s)", rec.Code, rec.Body.String())
+	}
+
+	if contentType := rec.Header().Get(HeaderContentType); contentType != MimeMsgPack {
+		t.Errorf("expected content type to be %s; got %s", MimeMsgPack, contentType)
+	}
+
+	var person Person
+	if err := (gobCodec{}).Unmarshal(rec.Body.Bytes(), &person); err != nil || person.Name != "foo" {
+		t.Errorf("expected response to be encoded using codec; got %v %v", person, err)
+	}
+
+	t.Run("unregistered codec", func(st *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/proto", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			st.Errorf("expected status code to be 500; got %d", rec.Code)
+		}
+	})
+}
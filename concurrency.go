@@ -0,0 +1,76 @@
+package nano
+
+import (
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// MaxConcurrent returns middleware limiting number of requests running the rest of handlers stack at the same time,
+// e.g. to protect memory heavy report generation. Each call creates its own limit, attach it per route to limit
+// the route only, or per group to limit all its routes together. n defaults to number of CPU.
+//
+// Request that comes when all n slots are taken waits up to timeout in a queue of queueDepth requests,
+// zero timeout waits until the client goes away. Request that doesn't get a slot is responded
+// with 503 status code and Retry-After header.
+//
+//	app.GET("/reports/:id", nano.MaxConcurrent(4, 16, 5*time.Second), generateReport)
+func MaxConcurrent(n, queueDepth int, timeout time.Duration) HandlerFunc {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+
+	retryAfter := strconv.Itoa(int(math.Max(1, math.Ceil(timeout.Seconds()))))
+	slots := make(chan struct{}, n)
+	var waiting int64
+
+	reject := func(c *Context) {
+		c.SetHeader(HeaderRetryAfter, retryAfter)
+		c.String(http.StatusServiceUnavailable, "service unavailable")
+	}
+
+	return func(c *Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			if atomic.AddInt64(&waiting, 1) > int64(queueDepth) {
+				atomic.AddInt64(&waiting, -1)
+				reject(c)
+				return
+			}
+
+			acquired := waitSlot(c, slots, timeout)
+			atomic.AddInt64(&waiting, -1)
+
+			if !acquired {
+				reject(c)
+				return
+			}
+		}
+
+		defer func() { <-slots }()
+		c.Next()
+	}
+}
+
+// waitSlot waits for free slot until timeout or the client goes away, it returns true when the slot is taken.
+func waitSlot(c *Context, slots chan struct{}, timeout time.Duration) bool {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-expired:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
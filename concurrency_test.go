@@ -0,0 +1,90 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrent(t *testing.T) {
+	release := make(chan struct{})
+
+	app := New()
+	app.GET("/reports", MaxConcurrent(1, 1, 20*time.Millisecond), func(c *Context) {
+		<-release
+		c.String(http.StatusOK, "report")
+	})
+	app.GET("/users", func(c *Context) {
+		c.String(http.StatusOK, "users")
+	})
+
+	request := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// take the only slot.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		request("/reports")
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	t.Run("queued request times out", func(st *testing.T) {
+		rec := request("/reports")
+		if rec.Code != http.StatusServiceUnavailable || rec.Header().Get(HeaderRetryAfter) != "1" {
+			st.Errorf("expected 503 with retry after 1; got %d %q", rec.Code, rec.Header().Get(HeaderRetryAfter))
+		}
+	})
+
+	t.Run("full queue rejects immediately", func(st *testing.T) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request("/reports")
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		start := time.Now()
+		if rec := request("/reports"); rec.Code != http.StatusServiceUnavailable {
+			st.Errorf("expected request over queue depth to be rejected; got %d", rec.Code)
+		}
+
+		if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+			st.Errorf("expected request over queue depth not to wait; waited %v", elapsed)
+		}
+	})
+
+	if rec := request("/users"); rec.Code != http.StatusOK {
+		t.Errorf("expected other route not to be limited; got %d", rec.Code)
+	}
+
+	close(release)
+	wg.Wait()
+
+	t.Run("queued request gets released slot", func(st *testing.T) {
+		done := make(chan *httptest.ResponseRecorder, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				done <- request("/reports")
+			}()
+		}
+
+		for i := 0; i < 2; i++ {
+			if rec := <-done; rec.Code != http.StatusOK {
+				st.Errorf("expected request to be served; got %d", rec.Code)
+			}
+		}
+	})
+}
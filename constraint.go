@@ -0,0 +1,166 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrRoutePattern is returned when url pattern of registered route is malformed.
+var ErrRoutePattern = errors.New("invalid route pattern")
+
+// routeParam describes parameter segment of url pattern, e.g. :id, :id(\d+), {id:int}, *path, or {path:*}.
+type routeParam struct {
+	name     string
+	catchAll bool
+	// constraint rejects parameter value, it's nil when any value is accepted.
+	constraint func(value string) bool
+}
+
+// paramTypes are named constraints of {name:type} segment.
+var paramTypes = map[string]func(value string) bool{
+	"int": func(value string) bool {
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	},
+	"uint": func(value string) bool {
+		_, err := strconv.ParseUint(value, 10, 64)
+		return err == nil
+	},
+	"float": func(value string) bool {
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	},
+	"bool": func(value string) bool {
+		_, err := strconv.ParseBool(value)
+		return err == nil
+	},
+	"alpha": func(value string) bool {
+		return value != "" && strings.IndexFunc(value, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
+	},
+	"alnum": func(value string) bool {
+		return value != "" && strings.IndexFunc(value, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) < 0
+	},
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+}
+
+// isParamPart returns true when url pattern segment is parameter.
+func isParamPart(part string) bool {
+	return part != "" && (part[0] == ':' || part[0] == '*' || (part[0] == '{' && strings.HasSuffix(part, "}")))
+}
+
+// isCatchAllPart returns true when url pattern segment is catch-all parameter, e.g. *path or {path:*}.
+func isCatchAllPart(part string) bool {
+	return part != "" && (part[0] == '*' || (part[0] == '{' && strings.HasSuffix(part, ":*}")))
+}
+
+// hasCatchAll returns true when url pattern has catch-all parameter.
+func hasCatchAll(urlPattern string) bool {
+	for _, part := range createURLParts(urlPattern) {
+		if isCatchAllPart(part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseParam parses parameter segment of url pattern, it returns false when the segment is static.
+// constraint is written as regular expression, e.g. :id(\d+) or {code:[a-z]{3}}, or as type name
+// of {name:type} segment, that is int, uint, float, bool, alpha, alnum, uuid, or * for catch-all.
+// the constraint is checked against the whole segment, and it can't contain slash.
+func parseParam(part string) (routeParam, bool, error) {
+	if !isParamPart(part) {
+		return routeParam{}, false, nil
+	}
+
+	var param routeParam
+	var pattern string
+
+	switch part[0] {
+	case '*':
+		param.name, param.catchAll = part[1:], true
+
+	case ':':
+		param.name = part[1:]
+		if i := strings.IndexByte(part, '('); i > 0 {
+			if !strings.HasSuffix(part, ")") {
+				return param, true, fmt.Errorf("%w: unclosed constraint of %s", ErrRoutePattern, part)
+			}
+
+			param.name, pattern = part[1:i], part[i+1:len(part)-1]
+		}
+
+	default:
+		inner := part[1 : len(part)-1]
+		param.name = inner
+
+		if i := strings.IndexByte(inner, ':'); i >= 0 {
+			param.name, pattern = inner[:i], inner[i+1:]
+
+			if pattern == "*" {
+				param.catchAll, pattern = true, ""
+			}
+
+			if match, ok := paramTypes[pattern]; ok {
+				param.constraint = match
+			}
+		}
+	}
+
+	if param.name == "" {
+		return param, true, fmt.Errorf("%w: parameter %s must be named", ErrRoutePattern, part)
+	}
+
+	if param.constraint == nil && pattern != "" {
+		expression, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return param, true, fmt.Errorf("%w: invalid constraint of %s: %v", ErrRoutePattern, part, err)
+		}
+
+		param.constraint = expression.MatchString
+	}
+
+	return param, true, nil
+}
+
+// parsePattern parses parameter segments of url pattern, static segment is described by nil.
+// it returns ErrRoutePattern when catch-all parameter isn't the last segment, e.g. /files/*path/raw,
+// or when parameter is malformed.
+func parsePattern(urlPattern string) ([]*routeParam, error) {
+	segments := strings.FieldsFunc(urlPattern, func(r rune) bool { return r == '/' })
+
+	for i, segment := range segments {
+		if isCatchAllPart(segment) && i < len(segments)-1 {
+			return nil, fmt.Errorf("%w: catch-all parameter %s must be the last segment", ErrRoutePattern, segment)
+		}
+	}
+
+	return parseParams(createURLParts(urlPattern))
+}
+
+// parseParams parses parameter segments of url parts, static segment is described by nil.
+func parseParams(urlParts []string) ([]*routeParam, error) {
+	params := make([]*routeParam, len(urlParts))
+
+	for i, part := range urlParts {
+		param, ok, err := parseParam(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			params[i] = &param
+		}
+	}
+
+	return params, nil
+}
+
+// accepts returns true when value satisfies constraint of the parameter.
+func (p *routeParam) accepts(value string) bool {
+	return p.constraint == nil || p.constraint(value)
+}
@@ -0,0 +1,183 @@
+package nano
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseParam(t *testing.T) {
+	tt := []struct {
+		name     string
+		part     string
+		isParam  bool
+		param    string
+		catchAll bool
+		accepted []string
+		rejected []string
+	}{
+		{"static part", "users", false, "", false, nil, nil},
+		{"named parameter", ":id", true, "id", false, []string{"1", "abc"}, nil},
+		{"regex parameter", `:id(\d+)`, true, "id", false, []string{"1", "42"}, []string{"abc", "1a", ""}},
+		{"regex alternation is anchored", ":kind(a|b)", true, "kind", false, []string{"a", "b"}, []string{"ab", "xa"}},
+		{"braced parameter", "{id}", true, "id", false, []string{"1", "abc"}, nil},
+		{"int parameter", "{id:int}", true, "id", false, []string{"1", "-2"}, []string{"1.5", "abc"}},
+		{"uint parameter", "{id:uint}", true, "id", false, []string{"1"}, []string{"-2"}},
+		{"float parameter", "{price:float}", true, "price", false, []string{"1.5", "2"}, []string{"abc"}},
+		{"bool parameter", "{active:bool}", true, "active", false, []string{"true", "0"}, []string{"yes"}},
+		{"alpha parameter", "{name:alpha}", true, "name", false, []string{"john"}, []string{"john1", ""}},
+		{"alnum parameter", "{name:alnum}", true, "name", false, []string{"john1"}, []string{"john-1"}},
+		{"uuid parameter", "{id:uuid}", true, "id", false, []string{"123e4567-e89b-12d3-a456-426614174000"}, []string{"123"}},
+		{"braced regex parameter", "{code:[a-z]{3}}", true, "code", false, []string{"abc"}, []string{"abcd", "ABC"}},
+		{"wildcard", "*path", true, "path", true, nil, nil},
+		{"braced wildcard", "{path:*}", true, "path", true, nil, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			param, isParam, err := parseParam(tc.part)
+			if err != nil {
+				st.Fatalf("expected %s to be parsed; got %v", tc.part, err)
+			}
+
+			if isParam != tc.isParam {
+				st.Fatalf("expected %s parameter to be %t; got %t", tc.part, tc.isParam, isParam)
+			}
+
+			if param.name != tc.param {
+				st.Errorf("expected parameter name to be %s; got %s", tc.param, param.name)
+			}
+
+			if param.catchAll != tc.catchAll {
+				st.Errorf("expected catch-all to be %t; got %t", tc.catchAll, param.catchAll)
+			}
+
+			for _, value := range tc.accepted {
+				if !param.accepts(value) {
+					st.Errorf("expected %s to accept %q", tc.part, value)
+				}
+			}
+
+			for _, value := range tc.rejected {
+				if param.accepts(value) {
+					st.Errorf("expected %s to reject %q", tc.part, value)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid parameter", func(st *testing.T) {
+		for _, part := range []string{`:id(\d+`, ":id([)", "{id:[}", ":", "*", "{}", `:(\d+)`, "{:int}", "{:*}"} {
+			if _, _, err := parseParam(part); !errors.Is(err, ErrRoutePattern) {
+				st.Errorf("expected %s error to be ErrRoutePattern; got %v", part, err)
+			}
+		}
+	})
+}
+
+func TestRouteConstraint(t *testing.T) {
+	app := New()
+	app.GET(`/users/:id(\d+)`, func(c *Context) {
+		c.String(http.StatusOK, "user %s", c.Param("id"))
+	})
+	app.GET("/users/me", func(c *Context) {
+		c.String(http.StatusOK, "me")
+	})
+	app.GET("/orders/{id:int}/files/{path:*}", func(c *Context) {
+		c.String(http.StatusOK, "order %s file %s", c.Param("id"), c.Param("path"))
+	})
+	app.GET("/accounts/{id:int}", func(c *Context) {}).Name("account")
+
+	if _, err := app.AddRoute(http.MethodGet, `/posts/:id(\d+`, func(c *Context) {}); !errors.Is(err, ErrRoutePattern) {
+		t.Errorf("expected error of invalid pattern to be ErrRoutePattern; got %v", err)
+	}
+
+	tt := []struct {
+		name   string
+		path   string
+		status int
+		body   string
+	}{
+		{"regex parameter", "/users/42", http.StatusOK, "user 42"},
+		{"static route", "/users/me", http.StatusOK, "me"},
+		{"rejected regex parameter", "/users/john", http.StatusNotFound, ""},
+		{"typed parameter & wildcard", "/orders/7/files/docs/invoice.pdf", http.StatusOK, "order 7 file docs/invoice.pdf"},
+		{"rejected typed parameter", "/orders/seven/files/invoice.pdf", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if tc.body != "" && rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %q; got %q", tc.body, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("url of constrained route", func(st *testing.T) {
+		if url, err := app.URL("account", map[string]string{"id": "5"}); err != nil || url != "/accounts/5" {
+			st.Errorf("expected url to be /accounts/5; got %s, %v", url, err)
+		}
+
+		if _, err := app.URL("account", map[string]string{"id": "five"}); err == nil {
+			st.Errorf("expected url of unsatisfied constraint to fail")
+		}
+	})
+}
+
+func TestRoutePatternValidation(t *testing.T) {
+	emptyHandler := func(c *Context) {}
+
+	tt := []struct {
+		name    string
+		pattern string
+		err     string
+	}{
+		{"mid-path catch-all", "/d/*path/extra", "GET /d/*path/extra: invalid route pattern: catch-all parameter *path must be the last segment"},
+		{"mid-path braced catch-all", "/d/{path:*}/extra", "GET /d/{path:*}/extra: invalid route pattern: catch-all parameter {path:*} must be the last segment"},
+		{"unnamed parameter", "/users/:", "GET /users/:: invalid route pattern: parameter : must be named"},
+		{"unnamed catch-all", "/files/*", "GET /files/*: invalid route pattern: parameter * must be named"},
+		{"catch-all with trailing slash", "/files/*path/", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+
+			_, err := app.AddRoute(http.MethodGet, tc.pattern, emptyHandler)
+			if tc.err == "" {
+				if err != nil {
+					st.Errorf("expected route to be registered; got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrRoutePattern) || err.Error() != tc.err {
+				st.Errorf("expected error to be %q; got %v", tc.err, err)
+			}
+
+			defer func() {
+				recovered, _ := recover().(error)
+				if !errors.Is(recovered, ErrRoutePattern) || recovered.Error() != tc.err {
+					st.Errorf("expected registration to panic with %q; got %v", tc.err, recovered)
+				}
+			}()
+
+			app.GET(tc.pattern, emptyHandler)
+		})
+	}
+}
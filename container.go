@@ -0,0 +1,184 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// ErrProvider is returned when the dependency can't be resolved, e.g. it isn't provided or its constructor fails.
+var ErrProvider = errors.New("could not resolve dependency")
+
+// provider constructs dependency registered by Provide or ProvideScoped.
+type provider struct {
+	// singleton is constructor of Provide, scoped is constructor of ProvideScoped.
+	singleton func() (interface{}, error)
+	scoped    func(c *Context) (interface{}, error)
+	// mu guards singleton construction, built is true once value is constructed.
+	mu    sync.Mutex
+	value interface{}
+	built bool
+}
+
+// container holds providers of the engine.
+type container struct {
+	providers map[string]*provider
+	// keys are provided keys in registration order.
+	keys []string
+}
+
+// Provide functions to register singleton dependency, e.g. database pool. The constructor is called once
+// on first resolve, failing constructor is called again on the next resolve. Singleton implementing io.Closer
+// is closed by Shutdown or CloseSingletons. It panics when the key is already provided.
+//
+//	app.Provide("db", func() (interface{}, error) {
+//		return sql.Open("postgres", dsn)
+//	})
+func (ng *Engine) Provide(key string, constructor func() (interface{}, error)) {
+	ng.provide(key, &provider{singleton: constructor})
+}
+
+// ProvideScoped functions to register request-scoped dependency, e.g. database transaction or per-request service.
+// The constructor is called once per request on first resolve, it could resolve other dependencies using c.
+// Dependency implementing io.Closer is closed after the request is served. It panics when the key is already provided.
+func (ng *Engine) ProvideScoped(key string, constructor func(c *Context) (interface{}, error)) {
+	ng.provide(key, &provider{scoped: constructor})
+}
+
+// provide registers provider of the key.
+func (ng *Engine) provide(key string, p *provider) {
+	ng.router.mustNotBeFrozen("provide " + key)
+
+	if ng.container.providers == nil {
+		ng.container.providers = make(map[string]*provider)
+	}
+
+	if _, exists := ng.container.providers[key]; exists {
+		panic(fmt.Sprintf("dependency %s already provided", key))
+	}
+
+	ng.container.providers[key] = p
+	ng.container.keys = append(ng.container.keys, key)
+}
+
+// Resolve returns singleton dependency of the key, request-scoped dependency can't be resolved outside request.
+func (ng *Engine) Resolve(key string) (interface{}, error) {
+	p, ok := ng.container.providers[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not provided", ErrProvider, key)
+	}
+
+	if p.scoped != nil {
+		return nil, fmt.Errorf("%w: %s is request-scoped", ErrProvider, key)
+	}
+
+	return p.resolveSingleton(key)
+}
+
+// resolveSingleton returns singleton value, it's constructed on first call.
+func (p *provider) resolveSingleton(key string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.built {
+		return p.value, nil
+	}
+
+	value, err := p.singleton()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrProvider, key, err)
+	}
+
+	p.value, p.built = value, true
+
+	return value, nil
+}
+
+// Resolve returns dependency of the key, request-scoped dependency is shared by the rest of the request.
+//
+//	db, err := c.Resolve("db")
+func (c *Context) Resolve(key string) (interface{}, error) {
+	if c.engine == nil {
+		return nil, fmt.Errorf("%w: %s is not provided", ErrProvider, key)
+	}
+
+	p, ok := c.engine.container.providers[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not provided", ErrProvider, key)
+	}
+
+	if p.scoped == nil {
+		return p.resolveSingleton(key)
+	}
+
+	if value, ok := c.scoped[key]; ok {
+		return value, nil
+	}
+
+	value, err := p.scoped(c)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrProvider, key, err)
+	}
+
+	if c.scoped == nil {
+		c.scoped = make(map[string]interface{})
+	}
+
+	c.scoped[key] = value
+
+	if closer, ok := value.(io.Closer); ok {
+		c.AfterResponse(func() {
+			closer.Close()
+		})
+	}
+
+	return value, nil
+}
+
+// Inject functions to resolve typed dependency, it returns error when the dependency isn't T.
+//
+//	db, err := nano.Inject[*sql.DB](c, "db")
+func Inject[T any](c *Context, key string) (T, error) {
+	var zero T
+
+	data, err := c.Resolve(key)
+	if err != nil {
+		return zero, err
+	}
+
+	value, ok := data.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, not %v", ErrProvider, key, data, reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	return value, nil
+}
+
+// CloseSingletons functions to close constructed singletons implementing io.Closer in reverse registration order,
+// so dependent is closed before its dependencies. errors are joined. It's called by Shutdown,
+// call it after shutting down your own http.Server.
+func (ng *Engine) CloseSingletons() error {
+	var errs []error
+
+	for i := len(ng.container.keys) - 1; i >= 0; i-- {
+		key := ng.container.keys[i]
+		p := ng.container.providers[key]
+		if p.scoped != nil {
+			continue
+		}
+
+		p.mu.Lock()
+		if closer, ok := p.value.(io.Closer); ok && p.built {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close %s: %w", key, err))
+			}
+
+			p.value, p.built = nil, false
+		}
+		p.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,183 @@
+package nano
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testPool struct {
+	closed bool
+}
+
+func (p *testPool) Close() error {
+	p.closed = true
+	return nil
+}
+
+type testTx struct {
+	pool   *testPool
+	closed bool
+}
+
+func (tx *testTx) Close() error {
+	tx.closed = true
+	return nil
+}
+
+func TestContainer(t *testing.T) {
+	pools, txs := 0, make([]*testTx, 0)
+
+	app := New()
+	app.Provide("db", func() (interface{}, error) {
+		pools++
+		return &testPool{}, nil
+	})
+	app.ProvideScoped("tx", func(c *Context) (interface{}, error) {
+		pool, err := Inject[*testPool](c, "db")
+		if err != nil {
+			return nil, err
+		}
+
+		tx := &testTx{pool: pool}
+		txs = append(txs, tx)
+
+		return tx, nil
+	})
+	app.Provide("broken", func() (interface{}, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	app.GET("/", func(c *Context) {
+		first, _ := Inject[*testTx](c, "tx")
+		second, _ := Inject[*testTx](c, "tx")
+		if first != second {
+			c.String(http.StatusInternalServerError, "request-scoped dependency is constructed twice")
+			return
+		}
+
+		if first.closed {
+			c.String(http.StatusInternalServerError, "request-scoped dependency is closed during the request")
+			return
+		}
+
+		c.String(http.StatusOK, "ok")
+	})
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status code to be 200; got %d %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	if pools != 1 {
+		t.Errorf("expected singleton to be constructed once; got %d", pools)
+	}
+
+	if len(txs) != 2 || txs[0] == txs[1] || !txs[0].closed || !txs[1].closed {
+		t.Errorf("expected request-scoped dependency to be constructed & closed per request")
+	}
+
+	if txs[0].pool != txs[1].pool {
+		t.Errorf("expected requests to share the singleton")
+	}
+
+	t.Run("resolve errors", func(st *testing.T) {
+		tt := []struct {
+			name    string
+			resolve func() error
+		}{
+			{"not provided", func() error { _, err := app.Resolve("cache"); return err }},
+			{"request-scoped outside request", func() error { _, err := app.Resolve("tx"); return err }},
+			{"failing constructor", func() error { _, err := app.Resolve("broken"); return err }},
+			{"wrong type", func() error {
+				_, err := Inject[*testTx](&Context{engine: app}, "db")
+				return err
+			}},
+		}
+
+		for _, tc := range tt {
+			if err := tc.resolve(); !errors.Is(err, ErrProvider) {
+				st.Errorf("expected %s to return ErrProvider; got %v", tc.name, err)
+			}
+		}
+	})
+
+	t.Run("duplicate key", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected duplicate key to panic")
+			}
+		}()
+
+		fresh := New()
+		fresh.ProvideScoped("db", nil)
+		fresh.Provide("db", nil)
+	})
+
+	t.Run("close singletons", func(st *testing.T) {
+		if err := app.Shutdown(context.Background()); err != nil {
+			st.Fatalf("expected shutdown not to fail; got %v", err)
+		}
+
+		if txs[0].pool.closed {
+			st.Errorf("expected singleton not to be closed while own http.Server may be serving requests")
+		}
+
+		if err := app.CloseSingletons(); err != nil {
+			st.Fatalf("expected closing singletons not to fail; got %v", err)
+		}
+
+		if !txs[0].pool.closed {
+			st.Errorf("expected singleton to be closed")
+		}
+	})
+}
+
+// orderedCloser records its name when it's closed.
+type orderedCloser struct {
+	name   string
+	closed *[]string
+}
+
+func (c orderedCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestCloseSingletonsOrder(t *testing.T) {
+	var closed []string
+
+	app := New()
+	for _, name := range []string{"db", "cache", "repository"} {
+		name := name
+		app.Provide(name, func() (interface{}, error) {
+			return orderedCloser{name: name, closed: &closed}, nil
+		})
+	}
+
+	for _, name := range []string{"cache", "db", "repository"} {
+		if _, err := app.Resolve(name); err != nil {
+			t.Fatalf("expected %s to be resolved; got %v", name, err)
+		}
+	}
+
+	if err := app.CloseSingletons(); err != nil {
+		t.Fatalf("expected closing singletons not to fail; got %v", err)
+	}
+
+	if strings.Join(closed, ",") != "repository,cache,db" {
+		t.Errorf("expected singletons to be closed in reverse registration order; got %v", closed)
+	}
+}
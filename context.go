@@ -1,76 +1,90 @@
 package nano
 
 import (
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
-
-	ut "github.com/go-playground/universal-translator"
-	"github.com/go-playground/validator/v10"
+	"time"
 )
 
-// Bag stores context key:value parameter.
-type Bag struct {
-	data map[string]interface{}
-}
-
-// NewBag creates new bag instance.
-func NewBag() *Bag {
-	return &Bag{
-		data: make(map[string]interface{}),
-	}
-}
-
-// Set bad data.
-func (b *Bag) Set(key string, data interface{}) {
-	b.data[key] = data
-}
-
-// Get data by given key.
-func (b *Bag) Get(key string) interface{} {
-	if data, ok := b.data[key]; ok {
-		return data
-	}
-
-	return nil
-}
-
 // Context defines nano request - response context.
 type Context struct {
-	Request    *http.Request
-	Writer     http.ResponseWriter
-	Method     string
-	Path       string
-	Origin     string
-	Params     map[string]string
-	handlers   []HandlerFunc
-	Bag        *Bag
-	cursor     int // used for handlers stack.
-	validator  *validator.Validate
-	translator ut.Translator
+	Request  *http.Request
+	Writer   http.ResponseWriter
+	Method   string
+	Path     string
+	Origin   string
+	Params   map[string]string
+	handlers []HandlerFunc
+	Bag      *Bag
+	cursor   int // used for handlers stack.
+	engine   *Engine
+	// payloadLimit is set by PayloadLimit middleware.
+	payloadLimit *PayloadLimitConfig
+	decodeStats  DecodeStats
+	// routePattern is url pattern of matching route.
+	routePattern string
+	startedAt    time.Time
+	// produces is response content types of matching route, it's set by Route.Produces.
+	produces []string
+	// trusted is set by TrustedCaller middleware when caller is verified internal client.
+	trusted bool
+	// noCache opts out response from Cache middleware.
+	noCache bool
+	// beforeWrite & afterResponse are hooks registered by BeforeWrite & AfterResponse.
+	beforeWrite   []func()
+	afterResponse []func()
+	// aborted is set by Abort.
+	aborted bool
+	// logger & requestID are created on first use, see Logger & RequestID.
+	logger    *slog.Logger
+	requestID string
+	// locale is set by SetLocale, fallbackLocale is default locale of I18n middleware.
+	locale         string
+	fallbackLocale string
+	// response is the innermost writer of Writer, see Written.
+	response *responseWriter
+	// timeFormat is default time layout of json, it's set by SetTimeFormat.
+	timeFormat string
+	// rawBody is request body buffered by RawBody.
+	rawBody []byte
+	// override is route-level options of matching route, see Route.WithTimeout.
+	override *routeOverride
+	// apiVersion is set by Version middleware.
+	apiVersion string
+	// scoped holds request-scoped dependencies resolved by Resolve.
+	scoped map[string]interface{}
 }
 
 // newContext is Context constructor.
 func newContext(w http.ResponseWriter, r *http.Request) *Context {
+	c := &Context{
+		Request:   r,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Origin:    r.Header.Get(HeaderOrigin),
+		cursor:    -1,
+		startedAt: time.Now(),
+		Bag:       NewBag(),
+	}
 
-	trans := newTranslator()
-	validator := newValidator(trans)
+	c.response = &responseWriter{ResponseWriter: w, c: c, status: http.StatusOK}
+	c.Writer = c.response
 
-	return &Context{
-		Request:    r,
-		Writer:     w,
-		Method:     r.Method,
-		Path:       r.URL.Path,
-		Origin:     r.Header.Get(HeaderOrigin),
-		cursor:     -1,
-		Bag:        NewBag(),
-		validator:  validator,
-		translator: trans,
-	}
+	return c
 }
 
 // Next moves cursor to the next handler stack.
 func (c *Context) Next() {
+	if c.aborted {
+		return
+	}
+
 	// moving cursor.
 	c.cursor++
 
@@ -79,6 +93,23 @@ func (c *Context) Next() {
 	}
 }
 
+// Abort functions to stop the handlers stack, the next handlers are not called even when c.Next is called.
+// it doesn't stop the current handler, so return after calling it.
+func (c *Context) Abort() {
+	c.aborted = true
+}
+
+// IsAborted returns true when the handlers stack is stopped by Abort.
+func (c *Context) IsAborted() bool {
+	return c.aborted
+}
+
+// isStrictBinding returns true when form conversion error should fail the binding.
+// strict binding is enabled by default, including context created outside engine.
+func (c *Context) isStrictBinding() bool {
+	return c.engine == nil || c.engine.strictBinding
+}
+
 // Status sets http status code response.
 func (c *Context) Status(statusCode int) {
 	c.Writer.WriteHeader(statusCode)
@@ -99,6 +130,12 @@ func (c *Context) SetContentType(contentType string) {
 	c.SetHeader(HeaderContentType, contentType)
 }
 
+// RoutePattern returns url pattern of matching route, e.g. /users/:id.
+// it's empty when no route matches the request.
+func (c *Context) RoutePattern() string {
+	return c.routePattern
+}
+
 // Param gets request parameter.
 func (c *Context) Param(key string) string {
 	value, _ := c.Params[key]
@@ -137,9 +174,72 @@ func (c *Context) QueryDefault(key string, defaultValue string) string {
 	return v
 }
 
+// ContentType returns request media type without its parameters, e.g. "application/json; charset=utf-8"
+// became "application/json".
+func (c *Context) ContentType() string {
+	contentType := c.GetRequestHeader(HeaderContentType)
+
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return mediaType(contentType)
+	}
+
+	return parsed
+}
+
 // IsJSON returns true when client send json body.
 func (c *Context) IsJSON() bool {
-	return c.GetRequestHeader(HeaderContentType) == MimeJSON
+	return c.ContentType() == MimeJSON
+}
+
+// IsSecure returns true when request is served over https,
+// including request forwarded by TLS terminating proxy.
+func (c *Context) IsSecure() bool {
+	if c.Request.TLS != nil {
+		return true
+	}
+
+	return strings.EqualFold(c.GetRequestHeader(HeaderXForwardedProto), "https")
+}
+
+// IsWebsocket returns true when client request websocket connection upgrade.
+func (c *Context) IsWebsocket() bool {
+	if !strings.EqualFold(c.GetRequestHeader(HeaderUpgrade), "websocket") {
+		return false
+	}
+
+	for _, token := range strings.Split(c.GetRequestHeader(HeaderConnection), ",") {
+		if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AcceptsEncoding returns true when client accept given content encoding, e.g. gzip.
+// encoding with zero quality value is treated as refused.
+func (c *Context) AcceptsEncoding(name string) bool {
+	name = strings.ToLower(name)
+	accepted := false
+
+	for _, encoding := range parseAccept(c.GetRequestHeader(HeaderAcceptEncoding)) {
+		// explicit encoding takes precedence over wildcard.
+		if encoding.mime == name {
+			return encoding.q > 0
+		}
+
+		if encoding.mime == "*" {
+			accepted = encoding.q > 0
+		}
+	}
+
+	return accepted
+}
+
+// UserAgent returns client user agent.
+func (c *Context) UserAgent() string {
+	return c.Request.UserAgent()
 }
 
 // ExpectJSON returns true when client request json response,
@@ -148,27 +248,58 @@ func (c *Context) ExpectJSON() bool {
 	return strings.Contains(c.GetRequestHeader(HeaderAccept), MimeJSON)
 }
 
-// JSON writes json as response.
-func (c *Context) JSON(statusCode int, object interface{}) {
-	rs, err := json.Marshal(object)
+// JSON writes json as response. It does nothing and returns Context.Err when the request is cancelled.
+// Marshalling error is responded with 500 status code and returned.
+func (c *Context) JSON(statusCode int, object interface{}) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	rs, err := c.jsonCodec().Marshal(object)
 	if err != nil {
 		c.String(http.StatusInternalServerError, "internal server error")
-		return
+		return err
 	}
 
 	c.SetContentType(MimeJSON)
 	c.Status(statusCode)
-	c.Writer.Write(rs)
+	_, err = c.Writer.Write(rs)
+
+	return err
+}
+
+// XML writes xml as response, it behaves like JSON.
+func (c *Context) XML(statusCode int, object interface{}) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	rs, err := xml.Marshal(object)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return err
+	}
+
+	c.SetContentType(MimeXML)
+	c.Status(statusCode)
+	_, err = c.Writer.Write(rs)
+
+	return err
 }
 
-// String writes plain text as response.
-func (c *Context) String(statusCode int, template string, value ...interface{}) {
+// String writes plain text as response. It does nothing and returns Context.Err when the request is cancelled.
+func (c *Context) String(statusCode int, template string, value ...interface{}) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
 	c.SetContentType(MimePlainText)
 	c.Status(statusCode)
 
 	text := fmt.Sprintf(template, value...)
+	_, err := c.Writer.Write([]byte(text))
 
-	c.Writer.Write([]byte(text))
+	return err
 }
 
 // File returns static file as response.
@@ -176,15 +307,88 @@ func (c *Context) File(statusCode int, filepath string) {
 	http.ServeFile(c.Writer, c.Request, filepath)
 }
 
-// HTML writes html as response.
-func (c *Context) HTML(statusCode int, html string) {
+// ServeContent writes content as response using http.ServeContent, so it handles Range, If-Range,
+// and conditional requests (If-Modified-Since, If-None-Match of ETag header set before), e.g. for video seeking
+// and resumable download. Content type is detected from extension of name, or from the content when it isn't set.
+// Pass zero modtime to skip Last-Modified header.
+func (c *Context) ServeContent(name string, modtime time.Time, content io.ReadSeeker) {
+	http.ServeContent(c.Writer, c.Request, name, modtime, content)
+}
+
+// HTML writes html as response. It does nothing and returns Context.Err when the request is cancelled.
+func (c *Context) HTML(statusCode int, html string) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
 	c.SetContentType(MimeHTML)
 	c.Status(statusCode)
-	c.Writer.Write([]byte(html))
+	_, err := c.Writer.Write([]byte(html))
+
+	return err
+}
+
+// Redirect redirects client to location using 3xx status code.
+// It panics when statusCode is not a redirection status code.
+func (c *Context) Redirect(statusCode int, location string) {
+	if statusCode < http.StatusMultipleChoices || statusCode > http.StatusPermanentRedirect {
+		panic(fmt.Sprintf("cannot redirect with status code %d", statusCode))
+	}
+
+	http.Redirect(c.Writer, c.Request, location, statusCode)
+}
+
+// Data writes binary as response. It does nothing and returns Context.Err when the request is cancelled.
+func (c *Context) Data(statusCode int, binary []byte) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	c.Status(statusCode)
+	_, err := c.Writer.Write(binary)
+
+	return err
 }
 
-// Data writes binary as response.
-func (c *Context) Data(statusCode int, binary []byte) {
+// Done returns channel which is closed when the request is cancelled, that is the client goes away
+// or Timeout middleware fires, so long running handler could stop its work.
+//
+//	select {
+//	case <-c.Done():
+//		return
+//	case row := <-rows:
+//		// ...
+//	}
+func (c *Context) Done() <-chan struct{} {
+	return c.Request.Context().Done()
+}
+
+// Err returns why the request is cancelled, e.g. context.Canceled when the client goes away
+// and context.DeadlineExceeded when Timeout middleware fires. It returns nil while the request is active.
+func (c *Context) Err() error {
+	return c.Request.Context().Err()
+}
+
+// DataFromReader writes content of reader as response without reading it into memory first,
+// e.g. to proxy large object from storage. Content-Length is set when contentLength isn't negative,
+// otherwise the response is chunked. extraHeaders is set before the status code is written.
+// Since response header is already sent while copying, the copy error is returned instead of writing error response.
+// The reader isn't closed, it's the caller responsibility.
+func (c *Context) DataFromReader(statusCode int, contentLength int64, contentType string, reader io.Reader, extraHeaders map[string]string) error {
+	for key, value := range extraHeaders {
+		c.SetHeader(key, value)
+	}
+
+	if contentType != "" {
+		c.SetContentType(contentType)
+	}
+
+	if contentLength >= 0 {
+		c.SetHeader(HeaderContentLength, strconv.FormatInt(contentLength, 10))
+	}
+
 	c.Status(statusCode)
-	c.Writer.Write(binary)
+
+	_, err := io.Copy(c.Writer, reader)
+	return err
 }
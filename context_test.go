@@ -2,12 +2,14 @@ package nano
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateNewContext(t *testing.T) {
@@ -269,6 +271,55 @@ func TestIsJSON(t *testing.T) {
 	if !ctx.IsJSON() {
 		t.Errorf("expected IsJSON to be true; got %v", ctx.IsJSON())
 	}
+
+	req.Header.Set(HeaderContentType, "application/json; charset=utf-8")
+	if !ctx.IsJSON() {
+		t.Errorf("expected IsJSON with charset parameter to be true; got %v", ctx.IsJSON())
+	}
+
+	if contentType := ctx.ContentType(); contentType != MimeJSON {
+		t.Errorf("expected content type to be %s; got %s", MimeJSON, contentType)
+	}
+}
+
+func TestRequestIntrospection(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not make http request: %v", err)
+	}
+	req.Header.Set(HeaderUserAgent, "nano-test")
+	req.Header.Set(HeaderXForwardedProto, "https")
+	req.Header.Set(HeaderUpgrade, "websocket")
+	req.Header.Set(HeaderConnection, "keep-alive, Upgrade")
+	req.Header.Set(HeaderAcceptEncoding, "br;q=0, *;q=0.5")
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	if ua := ctx.UserAgent(); ua != "nano-test" {
+		t.Errorf("expected user agent to be nano-test; got %s", ua)
+	}
+
+	if !ctx.IsSecure() {
+		t.Errorf("expected forwarded https request to be secure")
+	}
+
+	if !ctx.IsWebsocket() {
+		t.Errorf("expected websocket upgrade request to be detected")
+	}
+
+	testCases := []struct {
+		encoding string
+		accepted bool
+	}{
+		{"gzip", true},
+		{"br", false},
+	}
+
+	for _, tc := range testCases {
+		if accepted := ctx.AcceptsEncoding(tc.encoding); accepted != tc.accepted {
+			t.Errorf("expected encoding %s accepted to be %v; got %v", tc.encoding, tc.accepted, accepted)
+		}
+	}
 }
 
 func TestExpectJSON(t *testing.T) {
@@ -352,3 +403,128 @@ func TestBind(t *testing.T) {
 		t.Errorf("expected person gender to be male; got %s", person.Gender)
 	}
 }
+
+func TestRedirect(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/old", nil)
+	if err != nil {
+		log.Fatalf("could not make http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	ctx.Redirect(http.StatusMovedPermanently, "/new")
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Errorf("expected status code to be 301; got %d", rec.Code)
+	}
+
+	if location := rec.Header().Get("Location"); location != "/new" {
+		t.Errorf("expected location to be /new; got %s", location)
+	}
+
+	t.Run("non redirection status code", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected redirect with status code 200 to panic")
+			}
+		}()
+
+		ctx.Redirect(http.StatusOK, "/new")
+	})
+}
+
+func TestServeContent(t *testing.T) {
+	modtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tt := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		body    string
+	}{
+		{"full content", nil, http.StatusOK, "0123456789"},
+		{"range", map[string]string{"Range": "bytes=2-5"}, http.StatusPartialContent, "2345"},
+		{"not modified", map[string]string{"If-Modified-Since": modtime.Format(http.TimeFormat)}, http.StatusNotModified, ""},
+		{"outdated if-range", map[string]string{"Range": "bytes=2-5", "If-Range": modtime.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusOK, "0123456789"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/videos/intro.mp4", nil)
+			if err != nil {
+				log.Fatalf("could not make http request: %v", err)
+			}
+
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			newContext(rec, req).ServeContent("intro.mp4", modtime, strings.NewReader("0123456789"))
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %q; got %q", tc.body, rec.Body.String())
+			}
+
+			if tc.status == http.StatusOK && rec.Header().Get(HeaderContentType) != "video/mp4" {
+				st.Errorf("expected content type to be video/mp4; got %s", rec.Header().Get(HeaderContentType))
+			}
+		})
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	c := newContext(rec, req)
+
+	if err := c.Err(); err != nil {
+		t.Errorf("expected active request not to have error; got %v", err)
+	}
+
+	select {
+	case <-c.Done():
+		t.Errorf("expected done channel of active request not to be closed")
+	default:
+	}
+
+	cancel()
+	<-c.Done()
+
+	if err := c.Err(); err != context.Canceled {
+		t.Errorf("expected cancelled request to have context.Canceled error; got %v", err)
+	}
+
+	tt := []struct {
+		name   string
+		render func() error
+	}{
+		{"json", func() error { return c.JSON(http.StatusOK, H{"ok": true}) }},
+		{"xml", func() error { return c.XML(http.StatusOK, "ok") }},
+		{"string", func() error { return c.String(http.StatusOK, "ok") }},
+		{"html", func() error { return c.HTML(http.StatusOK, "<p>ok</p>") }},
+		{"data", func() error { return c.Data(http.StatusOK, []byte("ok")) }},
+		{"problem", func() error { return c.Problem(http.StatusNotFound, "", "", "", nil) }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if err := tc.render(); err != context.Canceled {
+				st.Errorf("expected render of cancelled request to return context.Canceled; got %v", err)
+			}
+
+			if rec.Body.Len() != 0 || c.Written() {
+				st.Errorf("expected render of cancelled request to write nothing; got %q", rec.Body.String())
+			}
+		})
+	}
+}
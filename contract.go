@@ -0,0 +1,56 @@
+package nano
+
+import (
+	"net/http"
+)
+
+// Consumes functions to restrict request content types accepted by the route, e.g. MimeJSON or "image/*".
+// request with body of other content type is rejected with 415 status code before any route middleware & handler is called.
+func (r *Route) Consumes(contentTypes ...string) *Route {
+	r.consumes = append(r.consumes, contentTypes...)
+	r.rebuild()
+
+	return r
+}
+
+// Produces functions to set response content types of the route.
+// they're offered by Negotiate and NegotiateFormat when offers is empty.
+func (r *Route) Produces(contentTypes ...string) *Route {
+	r.produces = append(r.produces, contentTypes...)
+	r.rebuild()
+
+	return r
+}
+
+// hasBody returns true when request has body or declares its content type.
+func (c *Context) hasBody() bool {
+	if c.GetRequestHeader(HeaderContentType) != "" {
+		return true
+	}
+
+	return c.Request.ContentLength != 0 && c.Request.Body != nil && c.Request.Body != http.NoBody
+}
+
+// routeContract returns handler enforcing request content types and storing response content types of the route.
+func routeContract(consumes, produces []string) HandlerFunc {
+	return func(c *Context) {
+		if len(consumes) > 0 && c.hasBody() && !consumable(consumes, c.ContentType()) {
+			c.String(http.StatusUnsupportedMediaType, "unsupported media type")
+			return
+		}
+
+		c.produces = produces
+		c.Next()
+	}
+}
+
+// consumable returns true when content type matches any of media ranges.
+func consumable(mediaRanges []string, contentType string) bool {
+	for _, mediaRange := range mediaRanges {
+		if matchSpecificity(mediaType(mediaRange), contentType) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type contractProduct struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func TestRouteContract(t *testing.T) {
+	app := New()
+
+	app.POST("/products", func(c *Context) {
+		c.Negotiate(http.StatusCreated, contractProduct{Name: "nano"})
+	}).Consumes(MimeJSON, "text/*").Produces(MimeXML, MimeJSON)
+
+	testCases := []struct {
+		name        string
+		body        string
+		contentType string
+		accept      string
+		code        int
+		produced    string
+	}{
+		{"json body", `{"name":"nano"}`, "application/json; charset=utf-8", "", http.StatusCreated, MimeXML},
+		{"text body", "nano", MimePlainText, MimeJSON, http.StatusCreated, MimeJSON},
+		{"form body", "name=nano", MimeFormURLEncoded, "", http.StatusUnsupportedMediaType, ""},
+		{"without body", "", "", MimeJSON, http.StatusCreated, MimeJSON},
+		{"not acceptable", `{}`, MimeJSON, MimeHTML, http.StatusNotAcceptable, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			if tc.contentType != "" {
+				req.Header.Set(HeaderContentType, tc.contentType)
+			}
+
+			if tc.accept != "" {
+				req.Header.Set(HeaderAccept, tc.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.code {
+				st.Errorf("expected status code to be %d; got %d", tc.code, rec.Code)
+			}
+
+			if contentType := mediaType(rec.Header().Get(HeaderContentType)); tc.produced != "" && contentType != tc.produced {
+				st.Errorf("expected response content type to be %s; got %s", tc.produced, contentType)
+			}
+		})
+	}
+}
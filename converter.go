@@ -0,0 +1,78 @@
+package nano
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// FormConverter converts form value into field type, e.g. uuid.Parse wrapper.
+type FormConverter func(value string) (interface{}, error)
+
+// textUnmarshalerType is reflect type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// RegisterFormConverter functions to register converter of form, query, header, and route parameter values
+// into fields of the same type as sample, e.g. decimal.Decimal{}. the converter must return value of that type.
+// Field type without registered converter falls back to encoding.TextUnmarshaler when it implements one.
+func (ng *Engine) RegisterFormConverter(sample interface{}, converter FormConverter) {
+	if ng.formConverters == nil {
+		ng.formConverters = make(map[reflect.Type]FormConverter)
+	}
+
+	ng.formConverters[reflect.TypeOf(sample)] = converter
+}
+
+// isText returns true when field type is converted by form converter or encoding.TextUnmarshaler.
+func (b *formBinder) isText(t reflect.Type) bool {
+	if _, ok := b.converters[t]; ok {
+		return true
+	}
+
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue sets string value into field, allocating pointer field when needed.
+// empty value sets field of converted type to its zero value.
+func (b *formBinder) setValue(value string, fieldValue reflect.Value) error {
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+
+		return b.setValue(value, fieldValue.Elem())
+	}
+
+	if !b.isText(fieldValue.Type()) {
+		return setFieldValue(fieldValue.Kind(), value, fieldValue)
+	}
+
+	fieldValue.Set(reflect.Zero(fieldValue.Type()))
+	if value == "" {
+		return nil
+	}
+
+	if converter, ok := b.converters[fieldValue.Type()]; ok {
+		converted, err := converter(value)
+		if err != nil {
+			return conversionError{kind: fieldValue.Kind(), typ: fieldValue.Type()}
+		}
+
+		convertedValue := reflect.ValueOf(converted)
+		if !convertedValue.IsValid() || !convertedValue.Type().AssignableTo(fieldValue.Type()) {
+			panic(fmt.Sprintf("form converter of %s returns %T", fieldValue.Type(), converted))
+		}
+
+		fieldValue.Set(convertedValue)
+
+		return nil
+	}
+
+	// field value is addressable since it's a field of struct pointer, slice element, or new map element.
+	if err := fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+		return conversionError{kind: fieldValue.Kind(), typ: fieldValue.Type()}
+	}
+
+	return nil
+}
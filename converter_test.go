@@ -0,0 +1,120 @@
+package nano
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// money is struct type converted by registered form converter.
+type money struct {
+	cents int64
+}
+
+// level is integer type converted by encoding.TextUnmarshaler.
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+
+	return nil
+}
+
+func TestFormConverter(t *testing.T) {
+	type Order struct {
+		Price    money      `form:"price"`
+		Level    level      `form:"level"`
+		Levels   []level    `form:"levels"`
+		IP       net.IP     `form:"ip"`
+		Deadline *time.Time `form:"deadline"`
+		Tags     []string   `form:"tags"`
+	}
+
+	tt := []struct {
+		name     string
+		query    string
+		expected Order
+		fields   []string
+	}{
+		{"converted values", "price=12.50&level=high&levels=low&levels=high&ip=10.0.0.1&deadline=2020-01-02T03:04:05Z&tags=a", Order{
+			Price:    money{cents: 1250},
+			Level:    2,
+			Levels:   []level{1, 2},
+			IP:       net.ParseIP("10.0.0.1"),
+			Deadline: func() *time.Time { d := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC); return &d }(),
+			Tags:     []string{"a"},
+		}, nil},
+		{"invalid values", "price=abc&level=medium&ip=nope", Order{}, []string{
+			"price must be a valid nano.money",
+			"level must be a valid nano.level",
+			"ip must be a valid net.IP",
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			c, app := CreateTestContext(httptest.NewRecorder())
+			app.RegisterFormConverter(money{}, func(value string) (interface{}, error) {
+				amount, err := strconv.ParseFloat(value, 64)
+				return money{cents: int64(amount * 100)}, err
+			})
+			c.SetRequest(httptest.NewRequest(http.MethodGet, "/?"+tc.query, nil))
+
+			var order Order
+			err := c.BindSimpleForm(&order)
+
+			if tc.fields == nil {
+				if err != nil {
+					st.Fatalf("expected error to be nil; got %v", err)
+				}
+
+				if !reflect.DeepEqual(order, tc.expected) {
+					st.Errorf("expected order to be %+v; got %+v", tc.expected, order)
+				}
+
+				return
+			}
+
+			errBinding, ok := err.(ErrBinding)
+			if !ok || errBinding.Status != http.StatusUnprocessableEntity {
+				st.Fatalf("expected 422 ErrBinding; got %v", err)
+			}
+
+			if !reflect.DeepEqual(errBinding.Fields, tc.fields) {
+				st.Errorf("expected error fields to be %v; got %v", tc.fields, errBinding.Fields)
+			}
+		})
+	}
+}
+
+func TestFormConverterType(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered == nil || !strings.Contains(recovered.(string), "returns string") {
+			t.Errorf("expected converter returning wrong type to panic; got %v", recovered)
+		}
+	}()
+
+	c, app := CreateTestContext(httptest.NewRecorder())
+	app.RegisterFormConverter(money{}, func(value string) (interface{}, error) {
+		return value, nil
+	})
+	c.SetRequest(httptest.NewRequest(http.MethodGet, "/?price=1", nil))
+
+	var order struct {
+		Price money `form:"price"`
+	}
+	c.BindSimpleForm(&order)
+}
@@ -9,6 +9,7 @@ package nano
 import (
 	"net/http"
 	"strings"
+	"sync"
 )
 
 // CORSConfig define nano cors middleware configuration.
@@ -16,6 +17,8 @@ type CORSConfig struct {
 	AllowedOrigins []string
 	AllowedMethods []string
 	AllowedHeaders []string
+	// Skipper returns true when the request should be served without cors headers.
+	Skipper func(c *Context) bool
 }
 
 // CORS struct.
@@ -23,6 +26,9 @@ type CORS struct {
 	allowedOrigins []string
 	allowedMethods []string
 	allowedHeaders []string
+	skipper        func(c *Context) bool
+	// mu guards the allowed lists, so they could be updated while serving requests.
+	mu sync.RWMutex
 }
 
 // parseRequestHeader splits header string to array of headers.
@@ -49,31 +55,49 @@ func parseRequestHeader(header string) []string {
 
 // SetAllowedOrigins functions to fill/replace all allowed origins.
 func (cors *CORS) SetAllowedOrigins(origins []string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedOrigins = origins
 }
 
 // SetAllowedMethods functions to fill/replace all allowed methods.
 func (cors *CORS) SetAllowedMethods(methods []string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedMethods = methods
 }
 
 // SetAllowedHeaders functions to fill/replace all allowed headers.
 func (cors *CORS) SetAllowedHeaders(headers []string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedHeaders = headers
 }
 
 // AddAllowedHeader functions to append method to allowed list.
 func (cors *CORS) AddAllowedHeader(header string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedHeaders = append(cors.allowedHeaders, header)
 }
 
 // AddAllowedMethod functions to append method to allowed list.
 func (cors *CORS) AddAllowedMethod(method string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedMethods = append(cors.allowedMethods, method)
 }
 
 // AddAllowedOrigin appends method to allowed list.
 func (cors *CORS) AddAllowedOrigin(origin string) {
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
+
 	cors.allowedOrigins = append(cors.allowedOrigins, origin)
 }
 
@@ -227,11 +251,19 @@ func (cors *CORS) handleSimpleRequest(c *Context) {
 // and then, upon "approval" from the server, sending the actual request with the actual HTTP request method.
 // Servers can also notify clients whether "credentials" (including Cookies and HTTP Authentication data) should be sent with requests.
 func (cors *CORS) Handle(c *Context) {
+	if cors.skipper != nil && cors.skipper(c) {
+		c.Next()
+		return
+	}
+
 	// preflighted requests first send an HTTP request by the OPTIONS method to the resource on the other domain,
 	// in order to determine whether the actual request is safe to send.
 	// Cross-site requests are preflighted like this since they may have implications to user data.
+	cors.mu.RLock()
+
 	if c.Method == http.MethodOptions && c.GetRequestHeader(HeaderAccessControlRequestMethod) != "" {
 		cors.handlePrefilghtRequest(c)
+		cors.mu.RUnlock()
 		return
 	}
 
@@ -239,15 +271,28 @@ func (cors *CORS) Handle(c *Context) {
 	// though the Fetch spec (which defines CORS) doesn’t use that term.
 	// A request that doesn’t trigger a CORS preflight—a so-called “simple request”
 	cors.handleSimpleRequest(c)
+	cors.mu.RUnlock()
 
 	c.Next()
 }
 
 // CORSWithConfig returns cors middleware.
 func CORSWithConfig(config CORSConfig) HandlerFunc {
+	return NewCORS(config).Handle
+}
+
+// NewCORS creates cors handler, keep it to update the allowed lists later using Update, e.g. on config reload.
+// Empty list of config allows all origins, headers, and common methods.
+func NewCORS(config CORSConfig) *CORS {
+	cors := &CORS{skipper: config.Skipper}
+	cors.Update(config)
 
-	cors := new(CORS)
+	return cors
+}
 
+// Update functions to replace all allowed origins, methods, and headers at once while serving requests,
+// empty list is replaced with its default like NewCORS. Skipper isn't changed.
+func (cors *CORS) Update(config CORSConfig) {
 	// create default value for all configuration field.
 	// default value is allowed for all origin, methods, and headers.
 	if len(config.AllowedMethods) == 0 {
@@ -262,9 +307,10 @@ func CORSWithConfig(config CORSConfig) HandlerFunc {
 		config.AllowedHeaders = []string{"*"}
 	}
 
-	cors.SetAllowedMethods(config.AllowedMethods)
-	cors.SetAllowedOrigins(config.AllowedOrigins)
-	cors.SetAllowedHeaders(config.AllowedHeaders)
+	cors.mu.Lock()
+	defer cors.mu.Unlock()
 
-	return cors.Handle
+	cors.allowedMethods = config.AllowedMethods
+	cors.allowedOrigins = config.AllowedOrigins
+	cors.allowedHeaders = config.AllowedHeaders
 }
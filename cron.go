@@ -0,0 +1,197 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCronSpec is returned when schedule spec is malformed.
+var ErrCronSpec = errors.New("invalid schedule spec")
+
+// cronDescriptors are shorthands of cron spec.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField defines range of cron field.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// cronFields are fields of cron spec in order.
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// schedule returns the next activation time after given time.
+type schedule interface {
+	next(after time.Time) time.Time
+}
+
+// intervalSchedule activates on fixed interval, it's parsed from @every spec.
+type intervalSchedule time.Duration
+
+// next returns given time plus the interval.
+func (s intervalSchedule) next(after time.Time) time.Time {
+	return after.Add(time.Duration(s))
+}
+
+// cronSchedule activates when all fields match, fields are bitsets of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar & dowStar are set when day of month or day of week is *,
+	// both days must match when either of them is *, otherwise any of them.
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+// parseSchedule parses standard 5 fields cron spec (minute hour day-of-month month day-of-week),
+// descriptor (e.g. @daily), or @every interval (e.g. @every 1h30m).
+// fields support *, number, range (1-5), step (*/15 or 1-30/2), and comma separated list.
+func parseSchedule(spec string, location *time.Location) (schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if interval := strings.TrimPrefix(spec, "@every "); interval != spec {
+		duration, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("%w: %s: interval must be positive duration", ErrCronSpec, spec)
+		}
+
+		return intervalSchedule(duration), nil
+	}
+
+	if expanded, ok := cronDescriptors[spec]; ok {
+		spec = expanded
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %s: expected %d fields; got %d", ErrCronSpec, spec, len(cronFields), len(parts))
+	}
+
+	bits := make([]uint64, len(parts))
+	for i, part := range parts {
+		var err error
+		if bits[i], err = parseCronField(part, cronFields[i]); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrCronSpec, spec, err)
+		}
+	}
+
+	// sunday could be written as 0 or 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+
+	return &cronSchedule{
+		minute:   bits[0],
+		hour:     bits[1],
+		dom:      bits[2],
+		month:    bits[3],
+		dow:      bits[4],
+		domStar:  parts[2] == "*" || strings.HasPrefix(parts[2], "*/"),
+		dowStar:  parts[4] == "*" || strings.HasPrefix(parts[4], "*/"),
+		location: location,
+	}, nil
+}
+
+// parseCronField parses comma separated list of cron field into bitset.
+func parseCronField(part string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+
+		if slash := strings.IndexByte(item, '/'); slash >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[slash+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step of %s %s", field.name, item)
+			}
+
+			rangePart = item[:slash]
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid %s %s", field.name, item)
+			}
+
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid %s %s", field.name, item)
+				}
+			} else if step > 1 {
+				// 5/15 means every 15 starting at 5.
+				end = field.max
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("%s %s is out of range %d-%d", field.name, item, field.min, field.max)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+// next returns the first matching minute after given time, or zero time when nothing matches within 5 years,
+// e.g. 30th of February.
+func (s *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay returns true when day of month and day of week of t match the schedule.
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
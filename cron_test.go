@@ -0,0 +1,53 @@
+package nano
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// 2021-03-15 is monday.
+	from := time.Date(2021, 3, 15, 10, 7, 30, 0, time.UTC)
+
+	tt := []struct {
+		name string
+		spec string
+		next time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2021, 3, 15, 10, 8, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2021, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"list & range", "0,30 9-17 * * *", time.Date(2021, 3, 15, 10, 30, 0, 0, time.UTC)},
+		{"range with step", "0 8-18/4 * * *", time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"start with step", "5/20 * * * *", time.Date(2021, 3, 15, 10, 25, 0, 0, time.UTC)},
+		{"next day", "30 3 * * *", time.Date(2021, 3, 16, 3, 30, 0, 0, time.UTC)},
+		{"day of week", "0 0 * * 5", time.Date(2021, 3, 19, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 1 * 3", time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"next year", "0 0 1 2 *", time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"descriptor", "@monthly", time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"interval", "@every 90s", from.Add(90 * time.Second)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			schedule, err := parseSchedule(tc.spec, time.UTC)
+			if err != nil {
+				st.Fatalf("expected %s to be parsed; got %v", tc.spec, err)
+			}
+
+			if next := schedule.next(from); !next.Equal(tc.next) {
+				st.Errorf("expected next run to be %v; got %v", tc.next, next)
+			}
+		})
+	}
+
+	t.Run("invalid spec", func(st *testing.T) {
+		for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every", "@every -1m", "@often"} {
+			if _, err := parseSchedule(spec, time.UTC); !errors.Is(err, ErrCronSpec) {
+				st.Errorf("expected %s error to be ErrCronSpec; got %v", spec, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package nano
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ansi colors of request trace.
+const (
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+	colorCyan   = "\033[36m"
+	colorReset  = "\033[0m"
+)
+
+// SetDebugOutput functions to set writer of debug output (route table, warnings, and request traces),
+// default is os.Stdout. output is colored only when it's a terminal.
+func (ng *Engine) SetDebugOutput(w io.Writer) {
+	ng.debugOutput = w
+}
+
+// debugWriter returns writer of debug output.
+func (ng *Engine) debugWriter() io.Writer {
+	if ng.debugOutput == nil {
+		return os.Stdout
+	}
+
+	return ng.debugOutput
+}
+
+// printWarnings prints route registration problems to w.
+func (ng *Engine) printWarnings(w io.Writer) {
+	for _, warning := range ng.router.warnings() {
+		fmt.Fprintf(w, "[nano] [WARNING] %s\n", warning)
+	}
+}
+
+// warnings returns route registration problems, that is overlapping routes, e.g. /users/new and /users/:id.
+func (r *router) warnings() []string {
+	warnings := make([]string, 0)
+
+	for i, a := range r.routes {
+		for _, b := range r.routes[i+1:] {
+			if a.info.Method != b.info.Method {
+				continue
+			}
+
+			if winner, ok := overlapWinner(a.info.Path, b.info.Path); ok {
+				warnings = append(warnings, fmt.Sprintf("%s %s overlaps %s, %s takes precedence", b.info.Method, b.info.Path, a.info.Path, winner))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// overlapWinner returns pattern which is matched when request path matches both patterns.
+// static segment takes precedence over parameter at the first position where the patterns differ.
+func overlapWinner(a, b string) (string, bool) {
+	aParts, bParts := createURLParts(a), createURLParts(b)
+	if len(aParts) != len(bParts) {
+		return "", false
+	}
+
+	winner := ""
+	for i := range aParts {
+		aParam, bParam := isParamPart(aParts[i]), isParamPart(bParts[i])
+
+		switch {
+		case aParam && bParam, aParts[i] == bParts[i]:
+			continue
+		case !aParam && !bParam:
+			// different static segments never match the same path.
+			return "", false
+		case winner == "" && aParam:
+			winner = b
+		case winner == "":
+			winner = a
+		}
+	}
+
+	return winner, winner != ""
+}
+
+// traceRequest prints request trace to debug output.
+func (ng *Engine) traceRequest(c *Context, status int) {
+	w := ng.debugWriter()
+	statusColor, methodColor, reset := "", "", ""
+
+	if isTerminal(w) {
+		methodColor, reset = colorCyan, colorReset
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			statusColor = colorRed
+		case status >= http.StatusBadRequest:
+			statusColor = colorYellow
+		default:
+			statusColor = colorGreen
+		}
+	}
+
+	fmt.Fprintf(w, "[nano] %s |%s %d %s| %12v | %s%-7s%s %s\n",
+		time.Now().Format("2006/01/02 15:04:05"),
+		statusColor, status, reset,
+		time.Since(c.startedAt),
+		methodColor, c.Method, reset,
+		c.Request.URL.RequestURI(),
+	)
+}
+
+// isTerminal returns true when w is character device, e.g. terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	stat, err := file.Stat()
+
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,91 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugRequestTrace(t *testing.T) {
+	var out bytes.Buffer
+
+	app := New()
+	app.SetDebug(true)
+	app.SetDebugOutput(&out)
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusAccepted, "ok")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/users/1?fields=name", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	trace := out.String()
+	for _, expected := range []string{"[nano]", " 202 ", "GET", "/users/1?fields=name"} {
+		if !strings.Contains(trace, expected) {
+			t.Errorf("expected request trace to contain %q; got %s", expected, trace)
+		}
+	}
+
+	if strings.Contains(trace, "\033[") {
+		t.Errorf("expected request trace written to buffer to be uncolored; got %q", trace)
+	}
+}
+
+func TestDebugRouteWarnings(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", func(c *Context) {})
+	app.GET("/users/new", func(c *Context) {})
+	app.GET("/users/:id/files/:file", func(c *Context) {})
+	app.GET("/users/:id/files/latest", func(c *Context) {})
+	app.POST("/users/new", func(c *Context) {})
+	app.GET("/posts/new", func(c *Context) {})
+
+	var out bytes.Buffer
+	app.printWarnings(&out)
+
+	expected := "[nano] [WARNING] GET /users/new overlaps /users/:id, /users/new takes precedence\n" +
+		"[nano] [WARNING] GET /users/:id/files/latest overlaps /users/:id/files/:file, /users/:id/files/latest takes precedence\n"
+
+	if out.String() != expected {
+		t.Errorf("expected warnings to be:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDebugRecovery(t *testing.T) {
+	tt := []struct {
+		debug bool
+		stack bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, tc := range tt {
+		app := New()
+		app.SetDebug(tc.debug)
+		app.SetDebugOutput(&bytes.Buffer{})
+		app.Use(RecoveryWithConfig(RecoveryConfig{LogFunc: func(RecoveryRecord) {}}))
+		app.GET("/", func(c *Context) {
+			panic("boom")
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if stack := strings.Contains(rec.Body.String(), "goroutine"); stack != tc.stack {
+			t.Errorf("expected stack trace in response to be %v in debug mode %v; got %s", tc.stack, tc.debug, rec.Body.String())
+		}
+	}
+}
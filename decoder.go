@@ -0,0 +1,68 @@
+package nano
+
+import (
+	"io"
+	"time"
+)
+
+// DecodeStats defines statistic of request body decoding.
+// it's useful for metrics middleware to measure payload size & decoding cost for capacity planning.
+type DecodeStats struct {
+	Bytes    int64
+	Duration time.Duration
+}
+
+// countingReader counts number of bytes read from underlying reader.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+// Read implements io.Reader.
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+
+	return n, err
+}
+
+// guardedDecoder wraps json codec to enforce payload limits and record decoding statistic.
+type guardedDecoder struct {
+	codec  JSONCodec
+	limits *PayloadLimitConfig
+	stats  DecodeStats
+}
+
+// newGuardedDecoder creates guarded decoder, limits may be nil.
+func newGuardedDecoder(codec JSONCodec, limits *PayloadLimitConfig) *guardedDecoder {
+	return &guardedDecoder{codec: codec, limits: limits}
+}
+
+// Decode decodes json from body into target.
+// depth & array length limits are checked before decoding, so the target is untouched when body exceeds the limit.
+func (d *guardedDecoder) Decode(body io.Reader, target interface{}) error {
+	start := time.Now()
+	counter := &countingReader{reader: body}
+
+	defer func() {
+		d.stats.Bytes = counter.count
+		d.stats.Duration = time.Since(start)
+	}()
+
+	var reader io.Reader = counter
+	if d.limits != nil && (d.limits.MaxJSONDepth > 0 || d.limits.MaxJSONArrayLength > 0) {
+		checked, err := checkJSONLimits(reader, d.limits)
+		if err != nil {
+			return err
+		}
+		reader = checked
+	}
+
+	return d.codec.NewDecoder(reader).Decode(target)
+}
+
+// DecodeStats returns statistic of the last request body decoding.
+// metrics middleware could read it after calling c.Next().
+func (c *Context) DecodeStats() DecodeStats {
+	return c.decodeStats
+}
@@ -0,0 +1,54 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStats(t *testing.T) {
+	body := `{"name":"foo"}`
+	app := New()
+
+	var stats DecodeStats
+	metrics := func(c *Context) {
+		c.Next()
+		stats = c.DecodeStats()
+	}
+
+	app.POST("/", metrics, func(c *Context) {
+		var person struct {
+			Name string `json:"name"`
+		}
+
+		if err := c.BindJSON(&person); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.String(http.StatusOK, person.Name)
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeJSON)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status code to be 200; got %d", rec.Code)
+	}
+
+	if stats.Bytes != int64(len(body)) {
+		t.Errorf("expected decoded bytes to be %d; got %d", len(body), stats.Bytes)
+	}
+
+	if stats.Duration <= 0 {
+		t.Errorf("expected decoding duration to be recorded; got %v", stats.Duration)
+	}
+}
@@ -0,0 +1,94 @@
+package nano
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultDecompressMaxSize is default maximum size of decompressed request body.
+const DefaultDecompressMaxSize = 10 << 20
+
+// DecompressDecoder creates decompressing reader of request body.
+type DecompressDecoder func(body io.Reader) (io.ReadCloser, error)
+
+// DecompressConfig defines nano decompress middleware configuration.
+type DecompressConfig struct {
+	// MaxSize is maximum size of decompressed body to protect server from zip bomb,
+	// binding returns 413 status code when it's exceeded. default is DefaultDecompressMaxSize.
+	MaxSize int64
+	// Decoders registers additional content encodings, e.g. br or zstd using third-party package.
+	// gzip and deflate are supported out of the box.
+	Decoders map[string]DecompressDecoder
+}
+
+// decompressedBody closes both decompressing reader and the original request body.
+type decompressedBody struct {
+	io.ReadCloser
+	original io.Closer
+}
+
+// Close closes decompressing reader and the original request body.
+func (d *decompressedBody) Close() error {
+	d.ReadCloser.Close()
+	return d.original.Close()
+}
+
+// Decompress middleware transparently decompresses gzip and deflate request body.
+func Decompress() HandlerFunc {
+	return DecompressWithConfig(DecompressConfig{})
+}
+
+// DecompressWithConfig returns decompress middleware with custom configuration.
+// request body of unsupported encoding is rejected with 415 status code,
+// and malformed compressed body is rejected with 400 status code.
+func DecompressWithConfig(config DecompressConfig) HandlerFunc {
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultDecompressMaxSize
+	}
+
+	decoders := map[string]DecompressDecoder{
+		"gzip": func(body io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(body)
+		},
+		"deflate": func(body io.Reader) (io.ReadCloser, error) {
+			return flate.NewReader(body), nil
+		},
+	}
+
+	for encoding, decoder := range config.Decoders {
+		decoders[strings.ToLower(encoding)] = decoder
+	}
+
+	return func(c *Context) {
+		encoding := strings.ToLower(strings.TrimSpace(c.GetRequestHeader(HeaderContentEncoding)))
+		if encoding == "" || encoding == "identity" || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		decoder, ok := decoders[encoding]
+		if !ok {
+			c.String(http.StatusUnsupportedMediaType, "unsupported content encoding")
+			return
+		}
+
+		reader, err := decoder(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, "malformed compressed body")
+			return
+		}
+
+		body := &decompressedBody{ReadCloser: reader, original: c.Request.Body}
+		c.Request.Body = &limitedBody{ReadCloser: body, remaining: config.MaxSize}
+
+		// body is no longer encoded and its size is unknown.
+		c.Request.Header.Del(HeaderContentEncoding)
+		c.Request.Header.Del(HeaderContentLength)
+		c.Request.ContentLength = -1
+
+		c.Next()
+	}
+}
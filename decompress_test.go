@@ -0,0 +1,71 @@
+package nano
+
+import (
+	"bytes"
+	"compress/gzip"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecompress(t *testing.T) {
+	type product struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	app := New()
+	app.Use(DecompressWithConfig(DecompressConfig{MaxSize: 64}))
+	app.POST("/products", func(c *Context) {
+		var p product
+		if err := c.Bind(&p); err != nil {
+			c.String(err.(ErrBinding).Status, err.Error())
+			return
+		}
+
+		c.String(http.StatusOK, p.Name)
+	})
+
+	compress := func(data string) []byte {
+		var buffer bytes.Buffer
+		gz := gzip.NewWriter(&buffer)
+		gz.Write([]byte(data))
+		gz.Close()
+
+		return buffer.Bytes()
+	}
+
+	testCases := []struct {
+		name     string
+		body     []byte
+		encoding string
+		code     int
+	}{
+		{"gzip", compress(`{"name":"nano"}`), "gzip", http.StatusOK},
+		{"plain", []byte(`{"name":"nano"}`), "", http.StatusOK},
+		{"zip bomb", compress(`{"name":"` + strings.Repeat("a", 1024) + `"}`), "gzip", http.StatusRequestEntityTooLarge},
+		{"malformed", []byte("not gzip"), "gzip", http.StatusBadRequest},
+		{"unsupported", []byte("data"), "br", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/products", bytes.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+			if tc.encoding != "" {
+				req.Header.Set(HeaderContentEncoding, tc.encoding)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.code {
+				st.Errorf("expected status code to be %d; got %d %s", tc.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package nano
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrDiffType is returned when compared values are not the same struct type.
+var ErrDiffType = errors.New("diff expects two values of the same struct type")
+
+// Change defines single field change between two struct values.
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// DiffConfig defines struct diff configuration.
+type DiffConfig struct {
+	// Tags is list of tag used as field name, the first found tag is used.
+	// default is json, then form.
+	Tags []string
+	// Redact is list of field name (using dot for nested field, e.g. "user.password")
+	// whose old and new values are masked. slice, map, or interface value containing redacted field is masked whole.
+	Redact []string
+	// RedactedValue replaces redacted values, default is "[REDACTED]".
+	RedactedValue interface{}
+}
+
+// Diff returns field-level changes between before and after, e.g. the value before update and after binding.
+// It's useful to create changeset for audit trails.
+func Diff(before, after interface{}) ([]Change, error) {
+	return DiffWithConfig(before, after, DiffConfig{})
+}
+
+// DiffWithConfig returns field-level changes between before and after using given configuration.
+func DiffWithConfig(before, after interface{}, config DiffConfig) ([]Change, error) {
+	if len(config.Tags) == 0 {
+		config.Tags = []string{"json", "form"}
+	}
+
+	if config.RedactedValue == nil {
+		config.RedactedValue = "[REDACTED]"
+	}
+
+	beforeValue := reflect.Indirect(reflect.ValueOf(before))
+	afterValue := reflect.Indirect(reflect.ValueOf(after))
+
+	// nil value or nil pointer isn't valid, it has no type to compare.
+	if !beforeValue.IsValid() || !afterValue.IsValid() {
+		return nil, ErrDiffType
+	}
+
+	if beforeValue.Kind() != reflect.Struct || beforeValue.Type() != afterValue.Type() {
+		return nil, ErrDiffType
+	}
+
+	differ := &structDiffer{config: config, changes: make([]Change, 0)}
+	differ.diffStruct(beforeValue, afterValue, "")
+
+	return differ.changes, nil
+}
+
+// structDiffer holds state of single diff process.
+type structDiffer struct {
+	config  DiffConfig
+	changes []Change
+}
+
+// fieldName returns field name based on configured tags.
+// it returns empty string when field is ignored by tag.
+func (d *structDiffer) fieldName(field reflect.StructField) string {
+	for _, tag := range d.config.Tags {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// isRedacted returns true when field path is listed in redaction config.
+func (d *structDiffer) isRedacted(path string) bool {
+	for _, redacted := range d.config.Redact {
+		if redacted == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasRedactedChild returns true when a field nested under path is listed in redaction config.
+func (d *structDiffer) hasRedactedChild(path string) bool {
+	for _, redacted := range d.config.Redact {
+		if strings.HasPrefix(redacted, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffStruct compares each exported field recursively.
+func (d *structDiffer) diffStruct(before, after reflect.Value, namespace string) {
+	structType := before.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		// skip unexported field.
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := d.fieldName(field)
+		if name == "" {
+			continue
+		}
+
+		d.diffValue(before.Field(i), after.Field(i), namespace+name)
+	}
+}
+
+// diffValue compares two values, nested struct is compared field by field.
+func (d *structDiffer) diffValue(before, after reflect.Value, path string) {
+	// value of a redacted path, or value containing redacted fields that can't be compared field by field, is masked.
+	if d.isRedacted(path) || (d.hasRedactedChild(path) && !isDiffableStruct(before.Type()) && !isDiffableStructPtr(before.Type())) {
+		if !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			d.changes = append(d.changes, Change{Field: path, Old: d.config.RedactedValue, New: d.config.RedactedValue})
+		}
+
+		return
+	}
+
+	if isDiffableStruct(before.Type()) {
+		d.diffStruct(before, after, path+".")
+		return
+	}
+
+	// compare pointer to struct field by field, nil pointer is compared as zero value of the struct.
+	if isDiffableStructPtr(before.Type()) && (!before.IsNil() || !after.IsNil()) {
+		d.diffStruct(indirectOrZero(before), indirectOrZero(after), path+".")
+		return
+	}
+
+	if !valueEqual(before, after) {
+		d.changes = append(d.changes, Change{Field: path, Old: before.Interface(), New: after.Interface()})
+	}
+}
+
+// isDiffableStruct returns true when struct should be compared field by field.
+func isDiffableStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// isDiffableStructPtr returns true when t is pointer to struct which should be compared field by field.
+func isDiffableStructPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && isDiffableStruct(t.Elem())
+}
+
+// indirectOrZero returns value pointed by pointer v, or zero value of its element type when v is nil.
+func indirectOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+
+	return v.Elem()
+}
+
+// valueEqual compares two values, time is compared using time.Equal.
+func valueEqual(before, after reflect.Value) bool {
+	if beforeTime, ok := before.Interface().(time.Time); ok {
+		return beforeTime.Equal(after.Interface().(time.Time))
+	}
+
+	return reflect.DeepEqual(before.Interface(), after.Interface())
+}
@@ -0,0 +1,111 @@
+package nano
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+
+	type User struct {
+		Name     string    `json:"name"`
+		Email    string    `form:"email"`
+		Password string    `json:"password"`
+		Tags     []string  `json:"tags"`
+		Address  Address   `json:"address"`
+		Birth    time.Time `json:"birth"`
+		Internal string    `json:"-"`
+		private  string
+	}
+
+	birth := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := User{Name: "foo", Email: "foo@mail.com", Password: "secret", Tags: []string{"a"}, Address: Address{City: "Jakarta"}, Birth: birth, private: "x"}
+	after := before
+	after.Name = "bar"
+	after.Password = "changed"
+	after.Address.City = "Bandung"
+	after.Birth = birth.In(time.FixedZone("WIB", 7*3600))
+	after.Internal = "ignored"
+	after.private = "y"
+
+	changes, err := DiffWithConfig(before, &after, DiffConfig{Redact: []string{"password"}})
+	if err != nil {
+		t.Fatalf("expected error to be nil; got %v", err)
+	}
+
+	expected := []Change{
+		{Field: "name", Old: "foo", New: "bar"},
+		{Field: "password", Old: "[REDACTED]", New: "[REDACTED]"},
+		{Field: "address.city", Old: "Jakarta", New: "Bandung"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected num of changes to be %d; got %v", len(expected), changes)
+	}
+
+	for i, change := range changes {
+		if change != expected[i] {
+			t.Errorf("expected change %d to be %v; got %v", i, expected[i], change)
+		}
+	}
+
+	t.Run("different types", func(st *testing.T) {
+		if _, err := Diff(before, Address{}); err != ErrDiffType {
+			st.Errorf("expected ErrDiffType; got %v", err)
+		}
+	})
+
+	t.Run("redacted nested field", func(st *testing.T) {
+		type Credential struct {
+			Login    string
+			Password string
+		}
+
+		type Account struct {
+			Credential  *Credential
+			Credentials []Credential
+		}
+
+		changes, err := DiffWithConfig(Account{}, Account{
+			Credential:  &Credential{Login: "foo", Password: "hunter2"},
+			Credentials: []Credential{{Password: "hunter2"}},
+		}, DiffConfig{Redact: []string{"Credential.Password", "Credentials.Password"}})
+		if err != nil {
+			st.Fatalf("expected error to be nil; got %v", err)
+		}
+
+		expected := []Change{
+			{Field: "Credential.Login", Old: "", New: "foo"},
+			{Field: "Credential.Password", Old: "[REDACTED]", New: "[REDACTED]"},
+			{Field: "Credentials", Old: "[REDACTED]", New: "[REDACTED]"},
+		}
+
+		if !reflect.DeepEqual(changes, expected) {
+			st.Errorf("expected changes to be %v; got %v", expected, changes)
+		}
+	})
+
+	t.Run("nil values", func(st *testing.T) {
+		var nilUser *User
+
+		tt := []struct {
+			name          string
+			before, after interface{}
+		}{
+			{"nil after", before, nil},
+			{"nil before", nil, after},
+			{"nil pointer after", &before, nilUser},
+			{"nil pointer before", nilUser, &after},
+		}
+
+		for _, tc := range tt {
+			if _, err := Diff(tc.before, tc.after); err != ErrDiffType {
+				st.Errorf("expected ErrDiffType for %s; got %v", tc.name, err)
+			}
+		}
+	})
+}
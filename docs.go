@@ -0,0 +1,109 @@
+package nano
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// RouteDoc describes route for api documentation, it's attached using Route.Doc.
+type RouteDoc struct {
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+}
+
+// Doc functions to describe the route, the description is listed by Engine.Routes, GenerateOpenAPI, and ServeDocs.
+func (r *Route) Doc(doc RouteDoc) *Route {
+	r.router.mustNotBeFrozen("document " + r.info.Path)
+	r.info.Doc = &doc
+
+	return r
+}
+
+// docsSection is routes of a tag listed by docs page.
+type docsSection struct {
+	Tag    string
+	Routes []RouteInfo
+}
+
+// ServeDocs functions to serve html page listing registered routes with their description, grouped by tag.
+// Routes without tag are listed under "default". The page is rendered on first request,
+// so routes registered later are included.
+func (rg *RouterGroup) ServeDocs(urlPath string) *Route {
+	var once sync.Once
+	var page string
+
+	return rg.GET(urlPath, func(c *Context) {
+		once.Do(func() {
+			page = renderDocs(rg.engine.Routes())
+		})
+
+		c.HTML(http.StatusOK, page)
+	})
+}
+
+// renderDocs renders docs page of routes, sections & routes are sorted so the page is stable.
+func renderDocs(routes []RouteInfo) string {
+	tagged := make(map[string][]RouteInfo)
+
+	for _, route := range routes {
+		if route.Doc == nil || len(route.Doc.Tags) == 0 {
+			tagged["default"] = append(tagged["default"], route)
+			continue
+		}
+
+		for _, tag := range route.Doc.Tags {
+			tagged[tag] = append(tagged[tag], route)
+		}
+	}
+
+	sections := make([]docsSection, 0, len(tagged))
+	for tag, routes := range tagged {
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+
+			return routes[i].Method < routes[j].Method
+		})
+
+		sections = append(sections, docsSection{Tag: tag, Routes: routes})
+	}
+
+	sort.Slice(sections, func(i, j int) bool {
+		return sections[i].Tag < sections[j].Tag
+	})
+
+	page := new(bytes.Buffer)
+	docsTemplate.Execute(page, sections)
+
+	return page.String()
+}
+
+// docsTemplate is html template of docs page, it's executed with sorted sections.
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>API Documentation</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 0 auto; padding: 1em; }
+.route { border-bottom: 1px solid #ddd; padding: .5em 0; }
+.method { display: inline-block; min-width: 5em; font-weight: bold; }
+.deprecated code { text-decoration: line-through; }
+</style>
+</head>
+<body>
+<h1>API Documentation</h1>
+{{range .}}<h2>{{.Tag}}</h2>
+{{range .Routes}}<div class="route{{if and .Doc .Doc.Deprecated}} deprecated{{end}}">
+<span class="method">{{.Method}}</span> <code>{{.Path}}</code>{{if and .Doc .Doc.Deprecated}} <em>deprecated</em>{{end}}
+{{with .Doc}}{{if .Summary}}<p><strong>{{.Summary}}</strong></p>{{end}}{{if .Description}}<p>{{.Description}}</p>{{end}}{{end}}
+</div>
+{{end}}{{end}}</body>
+</html>
+`))
@@ -0,0 +1,64 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouteDoc(t *testing.T) {
+	emptyHandler := func(c *Context) {}
+
+	app := New()
+	app.GET("/users", emptyHandler).Doc(RouteDoc{Summary: "List users", Description: "Users are <paginated>.", Tags: []string{"users"}})
+	app.GET("/v1/users", emptyHandler).Doc(RouteDoc{Summary: "List users (v1)", Tags: []string{"users"}, Deprecated: true})
+	ping := app.GET("/ping", emptyHandler)
+	app.ServeDocs("/_docs")
+
+	var doc *RouteDoc
+	for _, route := range app.Routes() {
+		if route.Path == "/users" {
+			doc = route.Doc
+		}
+	}
+
+	if doc == nil || doc.Summary != "List users" {
+		t.Fatalf("expected route doc to be listed by Routes; got %+v", doc)
+	}
+
+	operation := app.GenerateOpenAPI(OpenAPIInfo{}).Paths["/v1/users"].Get
+	if operation == nil || operation.Summary != "List users (v1)" || !operation.Deprecated || len(operation.Tags) != 1 {
+		t.Errorf("expected route doc to describe the openapi operation; got %+v", operation)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/_docs", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	page := rec.Body.String()
+	for _, expected := range []string{"<h2>default</h2>", "<h2>users</h2>", "<code>/ping</code>", "<strong>List users</strong>", "Users are &lt;paginated&gt;.", `class="route deprecated"`} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("expected docs page to contain %s; got %s", expected, page)
+		}
+	}
+
+	if strings.Index(page, "<h2>default</h2>") > strings.Index(page, "<h2>users</h2>") {
+		t.Errorf("expected sections to be sorted by tag")
+	}
+
+	t.Run("frozen router", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected documenting route of frozen router to panic")
+			}
+		}()
+
+		ping.Doc(RouteDoc{Summary: "Health check"})
+	})
+}
@@ -0,0 +1,221 @@
+package nano
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DumpRecord is request & response captured by Dump middleware.
+type DumpRecord struct {
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	Status         int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	Duration       time.Duration
+	// RequestTruncated & ResponseTruncated are true when the body exceeds DumpConfig.MaxBodySize.
+	RequestTruncated  bool
+	ResponseTruncated bool
+}
+
+// DumpConfig defines nano dump middleware configuration.
+type DumpConfig struct {
+	// Handler receives record of each request after the response is written,
+	// default prints the record to debug output, see Engine.SetDebugOutput.
+	Handler func(c *Context, record DumpRecord)
+	// MaxBodySize is maximum number of captured bytes of request & response body, default is 64KB.
+	// Body beyond it is still served but not captured.
+	MaxBodySize int
+	// RedactHeaders are headers whose values are replaced with [REDACTED] in the record,
+	// default is Authorization, Proxy-Authorization, Cookie, and Set-Cookie.
+	RedactHeaders []string
+	// Redact is called before the record is handed to Handler, e.g. to remove password from the body. it's optional.
+	Redact func(record *DumpRecord)
+}
+
+// Dump returns dump middleware with default configuration.
+func Dump() HandlerFunc {
+	return DumpWithConfig(DumpConfig{})
+}
+
+// DumpWithConfig returns middleware capturing request & response of the rest of handlers stack, including their bodies,
+// for troubleshooting integrations. It only works in debug mode, so it's harmless when left registered in production.
+// Only request body read by the handler is captured.
+func DumpWithConfig(config DumpConfig) HandlerFunc {
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = 64 << 10
+	}
+
+	if config.RedactHeaders == nil {
+		config.RedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	}
+
+	if config.Handler == nil {
+		config.Handler = printDump
+	}
+
+	return func(c *Context) {
+		if c.engine == nil || !c.engine.debug {
+			c.Next()
+			return
+		}
+
+		record := DumpRecord{
+			Method:        c.Method,
+			URL:           c.Request.URL.String(),
+			RequestHeader: c.Request.Header.Clone(),
+		}
+
+		body := &dumpBody{limit: config.MaxBodySize}
+		if c.Request.Body != nil {
+			body.ReadCloser = c.Request.Body
+			c.Request.Body = body
+		}
+
+		writer := &dumpWriter{ResponseWriter: c.Writer, limit: config.MaxBodySize, status: http.StatusOK}
+		c.Writer = writer
+		defer func() {
+			c.Writer = writer.ResponseWriter
+		}()
+
+		start := time.Now()
+		c.Next()
+
+		record.Duration = time.Since(start)
+		record.RequestBody, record.RequestTruncated = body.captured.Bytes(), body.truncated
+		record.Status = writer.status
+		record.ResponseHeader = writer.Header().Clone()
+		record.ResponseBody, record.ResponseTruncated = writer.captured.Bytes(), writer.truncated
+
+		for _, name := range config.RedactHeaders {
+			redactHeader(record.RequestHeader, name)
+			redactHeader(record.ResponseHeader, name)
+		}
+
+		if config.Redact != nil {
+			config.Redact(&record)
+		}
+
+		config.Handler(c, record)
+	}
+}
+
+// redactHeader replaces values of header name.
+func redactHeader(header http.Header, name string) {
+	values := header.Values(name)
+	if len(values) == 0 {
+		return
+	}
+
+	redacted := make([]string, len(values))
+	for i := range redacted {
+		redacted[i] = "[REDACTED]"
+	}
+
+	header[http.CanonicalHeaderKey(name)] = redacted
+}
+
+// printDump is the default dump handler, it prints the record to debug output.
+func printDump(c *Context, record DumpRecord) {
+	dump := new(strings.Builder)
+	fmt.Fprintf(dump, "[nano] [DUMP] %s %s | %d | %v\n", record.Method, record.URL, record.Status, record.Duration)
+	writeDumpPart(dump, "> ", record.RequestHeader, record.RequestBody, record.RequestTruncated)
+	writeDumpPart(dump, "< ", record.ResponseHeader, record.ResponseBody, record.ResponseTruncated)
+
+	io.WriteString(c.engine.debugWriter(), dump.String())
+}
+
+// writeDumpPart writes sorted headers and body of request or response, each line is prefixed by marker.
+func writeDumpPart(w io.Writer, marker string, header http.Header, body []byte, truncated bool) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			fmt.Fprintf(w, "%s%s: %s\n", marker, name, value)
+		}
+	}
+
+	if len(body) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, strings.TrimSpace(marker))
+	for _, line := range strings.Split(strings.TrimRight(string(body), "\n"), "\n") {
+		fmt.Fprintf(w, "%s%s\n", marker, line)
+	}
+
+	if truncated {
+		fmt.Fprintf(w, "%s... (truncated)\n", marker)
+	}
+}
+
+// dumpBody copies request body into buffer while it's read by the handler, up to the limit.
+type dumpBody struct {
+	io.ReadCloser
+	captured  bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Read reads request body and captures the data.
+func (b *dumpBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.truncated = capture(&b.captured, p[:n], b.limit) || b.truncated
+
+	return n, err
+}
+
+// dumpWriter copies response body into buffer while it's written to the client, up to the limit.
+type dumpWriter struct {
+	http.ResponseWriter
+	status    int
+	captured  bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// WriteHeader records status code.
+func (w *dumpWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures data and writes it to the client.
+func (w *dumpWriter) Write(data []byte) (int, error) {
+	w.truncated = capture(&w.captured, data, w.limit) || w.truncated
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher.
+func (w *dumpWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// capture writes data into buffer up to limit bytes, it returns true when the data doesn't fit.
+func capture(buffer *bytes.Buffer, data []byte, limit int) bool {
+	remaining := limit - buffer.Len()
+	if len(data) <= remaining {
+		buffer.Write(data)
+		return false
+	}
+
+	if remaining > 0 {
+		buffer.Write(data[:remaining])
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package nano
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	var records []DumpRecord
+
+	app := New()
+	app.SetDebug(true)
+	app.SetDebugOutput(io.Discard)
+	app.Use(DumpWithConfig(DumpConfig{
+		MaxBodySize: 16,
+		Handler: func(c *Context, record DumpRecord) {
+			records = append(records, record)
+		},
+		Redact: func(record *DumpRecord) {
+			record.RequestBody = bytes.ReplaceAll(record.RequestBody, []byte("secret"), []byte("******"))
+		},
+	}))
+
+	app.POST("/login", func(c *Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.SetHeader("Set-Cookie", "session=abc")
+		c.String(http.StatusCreated, "welcome %s", body)
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/login?next=/", strings.NewReader("user:secret"))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "welcome user:secret" {
+		t.Fatalf("expected response not to be changed by dump; got %s", body)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("expected one record to be dumped; got %d", len(records))
+	}
+
+	record := records[0]
+	if record.Method != http.MethodPost || record.URL != "/login?next=/" || record.Status != http.StatusCreated {
+		t.Errorf("expected record of POST /login?next=/ 201; got %s %s %d", record.Method, record.URL, record.Status)
+	}
+
+	if body := string(record.RequestBody); body != "user:******" || record.RequestTruncated {
+		t.Errorf("expected request body to be redacted; got %s (truncated %v)", body, record.RequestTruncated)
+	}
+
+	if body := string(record.ResponseBody); body != "welcome user:sec" || !record.ResponseTruncated {
+		t.Errorf("expected response body to be truncated at 16 bytes; got %s (truncated %v)", body, record.ResponseTruncated)
+	}
+
+	if value := record.RequestHeader.Get("Authorization"); value != "[REDACTED]" {
+		t.Errorf("expected authorization header to be redacted; got %s", value)
+	}
+
+	if value := record.ResponseHeader.Get("Set-Cookie"); value != "[REDACTED]" {
+		t.Errorf("expected set-cookie header to be redacted; got %s", value)
+	}
+
+	if value := rec.Header().Get("Set-Cookie"); value != "session=abc" {
+		t.Errorf("expected response header not to be redacted; got %s", value)
+	}
+
+	t.Run("default handler", func(st *testing.T) {
+		output := new(bytes.Buffer)
+
+		app := New()
+		app.SetDebug(true)
+		app.SetDebugOutput(output)
+		app.Use(Dump())
+		app.GET("/", func(c *Context) {
+			c.String(http.StatusOK, "hello")
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if dump := output.String(); !strings.Contains(dump, "[nano] [DUMP] GET / | 200") || !strings.Contains(dump, "<\n< hello\n") {
+			st.Errorf("expected record to be printed to debug output; got %s", dump)
+		}
+	})
+
+	t.Run("disabled outside debug mode", func(st *testing.T) {
+		app.SetDebug(false)
+		defer app.SetDebug(true)
+
+		req, err := http.NewRequest(http.MethodPost, "/login", strings.NewReader("user"))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if len(records) != 1 {
+			st.Errorf("expected request not to be dumped outside debug mode; got %d records", len(records))
+		}
+	})
+}
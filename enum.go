@@ -0,0 +1,120 @@
+package nano
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// enumValues returns allowed values of `enum` tag, e.g. `enum:"asc|desc"`.
+func enumValues(field reflect.StructField) []string {
+	tag := field.Tag.Get("enum")
+	if tag == "" {
+		return nil
+	}
+
+	return strings.Split(tag, "|")
+}
+
+// checkEnums returns error message of each field whose value isn't listed in its `enum` tag.
+// zero value is skipped, so use "required" rule to make sure the field is present.
+func checkEnums(value reflect.Value) []string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errFields []string
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		allowed := enumValues(field)
+		if allowed == nil {
+			// enum of nested struct is checked recursively.
+			if isSchemaStruct(elemOf(field.Type)) {
+				errFields = append(errFields, checkEnumsOf(value.Field(i))...)
+			}
+
+			continue
+		}
+
+		if !enumAllowed(value.Field(i), allowed) {
+			errFields = append(errFields, fmt.Sprintf("%s must be one of [%s]", enumFieldName(field), strings.Join(allowed, " ")))
+		}
+	}
+
+	return errFields
+}
+
+// checkEnumsOf checks nested struct, or each struct element of slice.
+func checkEnumsOf(value reflect.Value) []string {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return checkEnums(value)
+	}
+
+	var errFields []string
+	for i := 0; i < value.Len(); i++ {
+		errFields = append(errFields, checkEnumsOf(value.Index(i))...)
+	}
+
+	return errFields
+}
+
+// enumAllowed returns true when value (or each element of slice value) is listed in allowed values.
+func enumAllowed(value reflect.Value, allowed []string) bool {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		for i := 0; i < value.Len(); i++ {
+			if !enumAllowed(value.Index(i), allowed) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if value.IsZero() {
+		return true
+	}
+
+	text := fmt.Sprint(value.Interface())
+	for _, option := range allowed {
+		if text == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enumFieldName returns field name used in error message, using the first binding tag found.
+func enumFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "query", "uri", "header", "json"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
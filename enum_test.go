@@ -0,0 +1,110 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnumBinding(t *testing.T) {
+	type Filter struct {
+		Field string `json:"field" enum:"name|created_at"`
+	}
+
+	type Query struct {
+		Sort    string   `json:"sort" form:"sort" enum:"asc|desc"`
+		Limit   int      `json:"limit" form:"limit" enum:"10|50|100"`
+		Status  []string `json:"status" form:"status" enum:"active|inactive"`
+		Filters []Filter `json:"filters"`
+		Order   *string  `json:"order" enum:"asc|desc" validate:"required"`
+	}
+
+	tt := []struct {
+		name      string
+		body      string
+		validated bool
+		fields    []string
+	}{
+		{"valid values", `{"sort":"asc","limit":50,"status":["active"],"filters":[{"field":"name"}],"order":"desc"}`, false, nil},
+		{"zero values are skipped", `{"order":"asc"}`, false, nil},
+		{"invalid values", `{"sort":"up","limit":20,"status":["active","deleted"],"filters":[{"field":"age"}],"order":"asc"}`, false, []string{
+			"sort must be one of [asc desc]",
+			"limit must be one of [10 50 100]",
+			"status must be one of [active inactive]",
+			"field must be one of [name created_at]",
+		}},
+		{"enum is checked before validator", `{"sort":"up"}`, true, []string{
+			"sort must be one of [asc desc]",
+			"Order is a required field",
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if tc.validated {
+				skipWithoutValidator(st)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+
+			var query Query
+			err = newContext(httptest.NewRecorder(), req).BindJSON(&query)
+
+			if tc.fields == nil {
+				if err != nil {
+					st.Errorf("expected error to be nil; got %v", err)
+				}
+
+				return
+			}
+
+			errBinding, ok := err.(ErrBinding)
+			if !ok {
+				st.Fatalf("expected ErrBinding; got %T", err)
+			}
+
+			if errBinding.Status != http.StatusUnprocessableEntity {
+				st.Errorf("expected status code to be %d; got %d", http.StatusUnprocessableEntity, errBinding.Status)
+			}
+
+			if !reflect.DeepEqual(errBinding.Fields, tc.fields) {
+				st.Errorf("expected error fields to be %v; got %v", tc.fields, errBinding.Fields)
+			}
+		})
+	}
+}
+
+func TestEnumSchema(t *testing.T) {
+	type ListRequestV1 struct {
+		Sort string `json:"sort" enum:"asc|desc"`
+	}
+
+	type ListRequestV2 struct {
+		Sort string `json:"sort" enum:"asc"`
+	}
+
+	v1 := New()
+	v1.GET("/users", func(c *Context) {}).WithRequest(ListRequestV1{})
+
+	v2 := New()
+	v2.GET("/users", func(c *Context) {}).WithRequest(ListRequestV2{})
+
+	snapshot := v1.APISnapshot()
+	expected := []FieldSchema{{Name: "sort", Type: "string", Enum: []string{"asc", "desc"}}}
+	if !reflect.DeepEqual(snapshot.Routes[0].Request, expected) {
+		t.Errorf("expected request schema to be %+v; got %+v", expected, snapshot.Routes[0].Request)
+	}
+
+	changelog := DiffAPISnapshot(snapshot, v2.APISnapshot())
+	change := APIChange{Kind: APIFieldChanged, Route: "GET /users", Field: "request.sort", Old: "string,enum=asc|desc", New: "string,enum=asc", Breaking: true}
+	if !reflect.DeepEqual(changelog.Changes, []APIChange{change}) {
+		t.Errorf("expected changes to be %+v; got %+v", change, changelog.Changes)
+	}
+}
@@ -0,0 +1,74 @@
+package nano
+
+import "time"
+
+// EventType defines engine lifecycle event.
+type EventType int
+
+const (
+	// EventRouteMatched is emitted when request matches registered route.
+	EventRouteMatched EventType = iota
+	// EventHandlerPanicked is emitted when middleware or handler panics, Event.Panic holds the recovered value.
+	EventHandlerPanicked
+	// EventResponseCommitted is emitted when response status code & headers are written, Event.Status holds the status code.
+	EventResponseCommitted
+	// EventSlowRequest is emitted when request takes longer than slow request threshold.
+	EventSlowRequest
+	// EventRequestTimeout is emitted when Timeout middleware fires, Event.Status holds the fallback status code.
+	EventRequestTimeout
+)
+
+// Event defines engine lifecycle event.
+type Event struct {
+	Type    EventType
+	Context *Context
+	// Status is response status code, it's set for EventResponseCommitted, EventSlowRequest & EventRequestTimeout.
+	Status int
+	// Duration is elapsed time since the request is received.
+	Duration time.Duration
+	// Panic is recovered value of EventHandlerPanicked.
+	Panic interface{}
+}
+
+// EventHandler handles engine lifecycle event, it's called synchronously in request goroutine so keep it fast.
+type EventHandler func(event Event)
+
+// On functions to subscribe engine lifecycle event, e.g. for metrics, logging, or audit.
+func (ng *Engine) On(eventType EventType, handler EventHandler) {
+	if ng.listeners == nil {
+		ng.listeners = make(map[EventType][]EventHandler)
+	}
+
+	ng.listeners[eventType] = append(ng.listeners[eventType], handler)
+}
+
+// SetSlowRequestThreshold functions to set minimum duration of request to emit EventSlowRequest.
+// zero threshold (default) disables the event.
+func (ng *Engine) SetSlowRequestThreshold(threshold time.Duration) {
+	ng.slowRequestThreshold = threshold
+}
+
+// hasListener returns true when there is subscriber of the event.
+func (ng *Engine) hasListener(eventType EventType) bool {
+	return len(ng.listeners[eventType]) > 0
+}
+
+// emit calls subscribers of the event.
+func (ng *Engine) emit(event Event) {
+	if event.Context != nil {
+		event.Duration = time.Since(event.Context.startedAt)
+	}
+
+	for _, handler := range ng.listeners[event.Type] {
+		handler(event)
+	}
+}
+
+// emit emits engine lifecycle event of the context, it does nothing for context created outside engine.
+func (c *Context) emit(eventType EventType, status int, recovered interface{}) {
+	if c.engine == nil || !c.engine.hasListener(eventType) {
+		return
+	}
+
+	c.engine.emit(Event{Type: eventType, Context: c, Status: status, Panic: recovered})
+}
@@ -0,0 +1,64 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	app := New()
+	app.Use(Recovery())
+	app.SetSlowRequestThreshold(50 * time.Millisecond)
+
+	events := make([]string, 0)
+	app.On(EventRouteMatched, func(e Event) {
+		events = append(events, "matched "+e.Context.RoutePattern())
+	})
+	app.On(EventResponseCommitted, func(e Event) {
+		events = append(events, "committed "+http.StatusText(e.Status))
+	})
+	app.On(EventHandlerPanicked, func(e Event) {
+		events = append(events, "panicked "+e.Panic.(string))
+	})
+	app.On(EventSlowRequest, func(e Event) {
+		if e.Duration < 50*time.Millisecond {
+			t.Errorf("expected slow request duration to exceed threshold; got %v", e.Duration)
+		}
+		events = append(events, "slow "+http.StatusText(e.Status))
+	})
+
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusCreated, "ok")
+	})
+
+	app.GET("/slow", func(c *Context) {
+		time.Sleep(60 * time.Millisecond)
+		c.Status(http.StatusAccepted)
+	})
+
+	app.GET("/panic", func(c *Context) {
+		panic("boom")
+	})
+
+	for _, url := range []string{"/users/1", "/slow", "/panic"} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	expected := []string{
+		"matched /users/:id", "committed Created",
+		"matched /slow", "committed Accepted", "slow Accepted",
+		"matched /panic", "panicked boom", "committed Internal Server Error",
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events to be %v; got %v", expected, events)
+	}
+}
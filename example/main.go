@@ -23,7 +23,7 @@ func main() {
 	// below is logic to gracefully shutdown the web server.
 	// done channel is used to notify when the shutting down process is complete.
 	done := make(chan struct{})
-	shutdown := make(chan os.Signal)
+	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 
 	// create server from http std package
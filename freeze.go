@@ -0,0 +1,39 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrRouterFrozen is returned when route is registered after the engine is frozen.
+var ErrRouterFrozen = errors.New("router is frozen")
+
+// Freeze functions to stop accepting route registration, the engine is frozen implicitly on the first request.
+// routes are read without locking while requests are served, so registering route, router group, middleware,
+// or route name afterwards panics with ErrRouterFrozen (AddRoute returns it), instead of corrupting the routing tree.
+// Register everything before calling Run, or before serving the engine using http.Server.
+func (ng *Engine) Freeze() {
+	atomic.StoreInt32(&ng.router.frozen, 1)
+}
+
+// IsFrozen returns true when the engine doesn't accept route registration anymore.
+func (ng *Engine) IsFrozen() bool {
+	return atomic.LoadInt32(&ng.router.frozen) == 1
+}
+
+// checkFrozen returns ErrRouterFrozen describing rejected change when the router is frozen.
+func (r *router) checkFrozen(change string) error {
+	if atomic.LoadInt32(&r.frozen) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot %s after the engine started serving requests", ErrRouterFrozen, change)
+}
+
+// mustNotBeFrozen panics when the router is frozen.
+func (r *router) mustNotBeFrozen(change string) {
+	if err := r.checkFrozen(change); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+package nano
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	emptyHandler := func(c *Context) {}
+
+	t.Run("frozen on first request", func(st *testing.T) {
+		app := New()
+		app.GET("/", emptyHandler)
+
+		if app.IsFrozen() {
+			st.Fatalf("expected engine not to be frozen before serving requests")
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		if !app.IsFrozen() {
+			st.Errorf("expected engine to be frozen after serving request")
+		}
+
+		if _, err := app.AddRoute(http.MethodGet, "/late", emptyHandler); !errors.Is(err, ErrRouterFrozen) {
+			st.Errorf("expected late registration error to be ErrRouterFrozen; got %v", err)
+		}
+
+		if err := app.Default(emptyHandler); !errors.Is(err, ErrRouterFrozen) {
+			st.Errorf("expected late default handler error to be ErrRouterFrozen; got %v", err)
+		}
+	})
+
+	tt := []struct {
+		name     string
+		register func(app *Engine, route *Route)
+		err      string
+	}{
+		{"route", func(app *Engine, route *Route) { app.POST("/users", emptyHandler) }, "router is frozen: cannot register POST /users after the engine started serving requests"},
+		{"router group", func(app *Engine, route *Route) { app.Group("/api") }, "router is frozen: cannot create router group /api after the engine started serving requests"},
+		{"middleware", func(app *Engine, route *Route) { app.Use(emptyHandler) }, "router is frozen: cannot add middleware after the engine started serving requests"},
+		{"route middleware", func(app *Engine, route *Route) { route.Use(emptyHandler) }, "router is frozen: cannot change handlers of /users after the engine started serving requests"},
+		{"route name", func(app *Engine, route *Route) { route.Name("users") }, "router is frozen: cannot name /users after the engine started serving requests"},
+		{"custom router", func(app *Engine, route *Route) { app.SetRouter(nil) }, "router is frozen: cannot set router after the engine started serving requests"},
+		{"base path", func(app *Engine, route *Route) { app.SetBasePath("/api") }, "router is frozen: cannot set base path after the engine started serving requests"},
+		{"timeout fallback", func(app *Engine, route *Route) { app.OnTimeout(emptyHandler) }, "router is frozen: cannot set timeout fallback after the engine started serving requests"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			route := app.GET("/users", emptyHandler)
+			app.Freeze()
+
+			defer func() {
+				recovered, _ := recover().(error)
+				if !errors.Is(recovered, ErrRouterFrozen) || recovered.Error() != tc.err {
+					st.Errorf("expected registration to panic with %q; got %v", tc.err, recovered)
+				}
+			}()
+
+			tc.register(app, route)
+		})
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	app := New()
+	app.Use(func(c *Context) { c.Next() })
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "user %s", c.Param("id"))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for _, path := range []string{"/users/1", "/unknown"} {
+				req, err := http.NewRequest(http.MethodGet, path, nil)
+				if err != nil {
+					log.Fatalf("could not create http request: %v", err)
+				}
+
+				app.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -1,35 +1,151 @@
 package nano
 
 import (
+	"fmt"
+	"hash/fnv"
+	"io"
 	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
 )
 
-// fileServerHandler handles static file server.
-func fileServerHandler(routerPrefix, baseURL string, rootDir http.FileSystem) HandlerFunc {
-	return func(c *Context) {
-		prefix := baseURL + "/"
-		// if current file server not in root group, append router group prefix to baseurl.
-		if routerPrefix != "" {
-			prefix = routerPrefix + baseURL + "/"
+// HeaderETag is response entity tag.
+const HeaderETag = "ETag"
+
+// fileStamp identifies version of static file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// etagEntry is cached entity tag of static file.
+type etagEntry struct {
+	stamp fileStamp
+	etag  string
+}
+
+// staticFiles is static file server root directory with its entity tag cache.
+type staticFiles struct {
+	root  http.FileSystem
+	mu    sync.Mutex
+	etags map[string]etagEntry
+}
+
+// newStaticFiles creates static files of root directory.
+func newStaticFiles(root http.FileSystem) *staticFiles {
+	return &staticFiles{
+		root:  root,
+		etags: make(map[string]etagEntry),
+	}
+}
+
+// maxStaticETags is maximum number of cached entity tags of single static directory,
+// the cache is cleared once it's full.
+const maxStaticETags = 1024
+
+// stampETag returns entity tag of file version, it doesn't read the file.
+func stampETag(stamp fileStamp) string {
+	return fmt.Sprintf(`"%x-%x"`, stamp.modTime.UnixNano(), stamp.size)
+}
+
+// etag returns entity tag of file content, it's used in debug mode since edited file may keep its modification time.
+// the tag is cached until file modification time or size changes, or until the file is invalidated by static watcher.
+// file is read from the start and rewound on cache miss.
+func (sf *staticFiles) etag(name string, file io.ReadSeeker, stat os.FileInfo) (string, error) {
+	stamp := fileStamp{modTime: stat.ModTime(), size: stat.Size()}
+
+	sf.mu.Lock()
+	entry, ok := sf.etags[name]
+	sf.mu.Unlock()
+
+	if ok && entry.stamp == stamp {
+		return entry.etag, nil
+	}
+
+	hash := fnv.New64a()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := fmt.Sprintf(`"%x"`, hash.Sum64())
+
+	sf.mu.Lock()
+	if len(sf.etags) >= maxStaticETags {
+		sf.etags = make(map[string]etagEntry)
+	}
+	sf.etags[name] = etagEntry{stamp: stamp, etag: etag}
+	sf.mu.Unlock()
+
+	return etag, nil
+}
+
+// invalidate drops cached entity tags of given files.
+func (sf *staticFiles) invalidate(names []string) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	for _, name := range names {
+		delete(sf.etags, name)
+	}
+}
+
+// snapshot returns version of all files inside root directory.
+func (sf *staticFiles) snapshot() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	sf.walk("/", stamps)
+
+	return stamps
+}
+
+// walk collects version of files inside dir recursively, unreadable entry is skipped.
+func (sf *staticFiles) walk(dir string, stamps map[string]fileStamp) {
+	file, err := sf.root.Open(dir)
+	if err != nil {
+		return
+	}
+
+	entries, err := file.Readdir(-1)
+	file.Close()
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		name := path.Join(dir, entry.Name())
+		if entry.IsDir() {
+			sf.walk(name, stamps)
+			continue
 		}
 
-		fs := http.FileServer(rootDir)
-		// remove static prefix of url.
-		fileServer := http.StripPrefix(prefix, fs)
+		stamps[name] = fileStamp{modTime: entry.ModTime(), size: entry.Size()}
+	}
+}
 
+// fileServerHandler handles static file server.
+// requested file is opened once, then served using http.ServeContent,
+// so conditional & range requests are supported.
+func fileServerHandler(files *staticFiles) HandlerFunc {
+	return func(c *Context) {
 		// we will check existence of file,
 		// if current requested file doesn't exists, we will send not found as response.
-		file, err := rootDir.Open(c.Param("filepath"))
+		name := path.Clean("/" + c.Param("filepath"))
+		file, err := files.root.Open(name)
 		if err != nil {
 			c.String(http.StatusNotFound, "file not found")
 			return
 		}
+		defer file.Close()
 
 		stat, err := file.Stat()
 		if err != nil {
 			panic(err)
 		}
-		file.Close()
 
 		// disable directory listing.
 		if stat.IsDir() {
@@ -37,6 +153,19 @@ func fileServerHandler(routerPrefix, baseURL string, rootDir http.FileSystem) Ha
 			return
 		}
 
-		fileServer.ServeHTTP(c.Writer, c.Request)
+		// http.ServeContent responds 304 Not Modified when the tag matches If-None-Match header.
+		// in debug mode, browser should revalidate files on every request since they're being edited,
+		// so the tag is hash of file content. otherwise the tag is derived from file version without reading it.
+		if c.engine != nil && c.engine.debug {
+			if etag, err := files.etag(name, file, stat); err == nil {
+				c.SetHeader(HeaderETag, etag)
+			}
+
+			c.SetHeader(HeaderCacheControl, "no-cache")
+		} else {
+			c.SetHeader(HeaderETag, stampETag(fileStamp{modTime: stat.ModTime(), size: stat.Size()}))
+		}
+
+		http.ServeContent(c.Writer, c.Request, stat.Name(), stat.ModTime(), file)
 	}
 }
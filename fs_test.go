@@ -0,0 +1,149 @@
+package nano
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStaticETag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nano-static")
+	if err != nil {
+		log.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "app.js")
+	if err := ioutil.WriteFile(filename, []byte("console.log(1)"), 0644); err != nil {
+		log.Fatalf("could not write file: %v", err)
+	}
+
+	app := New()
+	app.SetDebug(true)
+	app.Static("/assets", http.Dir(dir))
+
+	changes := make([][]string, 0)
+	app.OnStaticChange(func(changed []string) {
+		changes = append(changes, changed)
+	})
+
+	request := func(etag string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, "/assets/app.js", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := request("")
+	etag := rec.Header().Get(HeaderETag)
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected file to be served with etag; got %d %q", rec.Code, etag)
+	}
+
+	if cache := rec.Header().Get(HeaderCacheControl); cache != "no-cache" {
+		t.Errorf("expected cache control in debug mode to be no-cache; got %s", cache)
+	}
+
+	if rec := request(etag); rec.Code != http.StatusNotModified {
+		t.Errorf("expected matching etag to be 304; got %d", rec.Code)
+	}
+
+	snapshot := app.staticFiles[0].snapshot()
+
+	// keep modification time, so only static watcher could invalidate the cached tag.
+	stat, _ := os.Stat(filename)
+	if err := ioutil.WriteFile(filename, []byte("console.log(2)"), 0644); err != nil {
+		log.Fatalf("could not write file: %v", err)
+	}
+	os.Chtimes(filename, time.Now(), stat.ModTime())
+
+	app.pollStatic(app.staticFiles[0], map[string]fileStamp{})
+	if !reflect.DeepEqual(changes, [][]string{{"/app.js"}}) {
+		t.Errorf("expected static change hook to receive /app.js; got %v", changes)
+	}
+
+	if rec := request(etag); rec.Code != http.StatusOK || rec.Header().Get(HeaderETag) == etag {
+		t.Errorf("expected changed file to be served with new etag; got %d %s", rec.Code, rec.Header().Get(HeaderETag))
+	}
+
+	if changed := changedFiles(snapshot, map[string]fileStamp{}); !reflect.DeepEqual(changed, []string{"/app.js"}) {
+		t.Errorf("expected deleted file to be reported; got %v", changed)
+	}
+}
+
+func TestStaticETagProduction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nano-static")
+	if err != nil {
+		log.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log(1)"), 0644); err != nil {
+		log.Fatalf("could not write file: %v", err)
+	}
+
+	app := New()
+	app.Static("/assets", http.Dir(dir))
+
+	req, err := http.NewRequest(http.MethodGet, "/assets/app.js", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	etag := rec.Header().Get(HeaderETag)
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected file to be served with etag; got %d %q", rec.Code, etag)
+	}
+
+	if len(app.staticFiles[0].etags) != 0 {
+		t.Errorf("expected file content not to be hashed outside debug mode")
+	}
+
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected matching etag to be 304; got %d", rec.Code)
+	}
+}
+
+func BenchmarkStatic(b *testing.B) {
+	dir, err := ioutil.TempDir("", "nano-static")
+	if err != nil {
+		log.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log(1)"), 0644); err != nil {
+		log.Fatalf("could not write file: %v", err)
+	}
+
+	app := New()
+	app.Static("/assets", http.Dir(dir))
+
+	req, err := http.NewRequest(http.MethodGet, "/assets/app.js", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
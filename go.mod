@@ -1,6 +1,6 @@
 module github.com/hariadivicky/nano
 
-go 1.13
+go 1.21
 
 require (
 	github.com/go-playground/locales v0.13.0
@@ -8,5 +8,10 @@ require (
 	github.com/go-playground/validator v9.31.0+incompatible
 	github.com/go-playground/validator/v10 v10.3.0
 	github.com/json-iterator/go v1.1.9
-	github.com/liamylian/jsontime/v2 v2.0.0
+)
+
+require (
+	github.com/leodido/go-urn v1.2.0 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 )
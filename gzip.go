@@ -2,35 +2,184 @@ package nano
 
 import (
 	"compress/gzip"
+	"io/ioutil"
 	"net/http"
+	"path"
 	"strings"
+	"sync"
 )
 
+// GzipConfig defines nano gzip middleware configuration.
+type GzipConfig struct {
+	// Level is default compression level, e.g. gzip.DefaultCompression.
+	Level int
+	// ContentTypeLevels overrides compression level by response content type, e.g. "image/png" or "image/*".
+	// use gzip.NoCompression to serve the content type without compression.
+	ContentTypeLevels map[string]int
+	// MinLength is minimum response size to be compressed, smaller response is served as is.
+	// response is buffered up to MinLength bytes until the size is known. default is 0, compress all responses.
+	MinLength int
+	// ExcludedPaths are request path prefixes served without compression.
+	ExcludedPaths []string
+	// ExcludedExtensions are requested file extensions served without compression, e.g. ".png".
+	ExcludedExtensions []string
+	// ExcludedContentTypes are response content types served without compression, e.g. "video/*".
+	// default is DefaultGzipExcludedContentTypes, set empty slice to compress all content types.
+	ExcludedContentTypes []string
+	// Skipper returns true when the request should be served without compression.
+	Skipper func(c *Context) bool
+}
+
+// DefaultGzipExcludedContentTypes are already compressed content types, compressing them only wastes CPU.
+var DefaultGzipExcludedContentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"video/*",
+	"audio/*",
+	"font/woff2",
+	"application/zip",
+	"application/gzip",
+}
+
+// gzipCompressor holds gzip writer pools of each configured compression level.
+type gzipCompressor struct {
+	config GzipConfig
+	pools  map[int]*sync.Pool
+}
+
+// newGzipCompressor creates writer pool for each compression level, it returns error when any level is invalid.
+func newGzipCompressor(config GzipConfig) (*gzipCompressor, error) {
+	if config.ExcludedContentTypes == nil {
+		config.ExcludedContentTypes = DefaultGzipExcludedContentTypes
+	}
+
+	// excluded content type is served with no compression level, unless its level is configured explicitly.
+	contentTypeLevels := make(map[string]int, len(config.ContentTypeLevels)+len(config.ExcludedContentTypes))
+	for _, contentType := range config.ExcludedContentTypes {
+		contentTypeLevels[contentType] = gzip.NoCompression
+	}
+
+	for contentType, level := range config.ContentTypeLevels {
+		contentTypeLevels[contentType] = level
+	}
+
+	config.ContentTypeLevels = contentTypeLevels
+
+	gc := &gzipCompressor{
+		config: config,
+		pools:  make(map[int]*sync.Pool),
+	}
+
+	levels := []int{config.Level}
+	for _, level := range config.ContentTypeLevels {
+		levels = append(levels, level)
+	}
+
+	for _, level := range levels {
+		if _, exists := gc.pools[level]; exists || level == gzip.NoCompression {
+			continue
+		}
+
+		// make sure compression level is valid, so pool never fails creating writer.
+		if _, err := gzip.NewWriterLevel(ioutil.Discard, level); err != nil {
+			return nil, err
+		}
+
+		level := level
+		gc.pools[level] = &sync.Pool{
+			New: func() interface{} {
+				gz, _ := gzip.NewWriterLevel(ioutil.Discard, level)
+				return gz
+			},
+		}
+	}
+
+	return gc, nil
+}
+
+// excluded returns true when request path or its extension is excluded from compression.
+func (gc *gzipCompressor) excluded(requestPath string) bool {
+	for _, prefix := range gc.config.ExcludedPaths {
+		if strings.HasPrefix(requestPath, prefix) {
+			return true
+		}
+	}
+
+	extension := strings.ToLower(path.Ext(requestPath))
+	for _, excluded := range gc.config.ExcludedExtensions {
+		if extension != "" && extension == strings.ToLower(excluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// levelOf returns compression level of content type.
+func (gc *gzipCompressor) levelOf(contentType string) int {
+	contentType = mediaType(contentType)
+
+	if level, ok := gc.config.ContentTypeLevels[contentType]; ok {
+		return level
+	}
+
+	if index := strings.Index(contentType, "/"); index >= 0 {
+		if level, ok := gc.config.ContentTypeLevels[contentType[:index]+"/*"]; ok {
+			return level
+		}
+	}
+
+	return gc.config.Level
+}
+
 type gzipWriter struct {
 	http.ResponseWriter
-	writer *gzip.Writer
+	compressor *gzipCompressor
+	// writer is nil until the response starts, and stays nil when compression is skipped.
+	writer  *gzip.Writer
+	level   int
+	started bool
+	// statusCode & buffer are pending response until it reaches compressor min length.
+	statusCode int
+	buffer     []byte
 }
 
 // Gzip compression for http response.
 // this compression works when client accept gzip in their request.
 func Gzip(compressionLevel int) HandlerFunc {
+	return GzipWithConfig(GzipConfig{Level: compressionLevel})
+}
+
+// GzipWithConfig returns gzip middleware with custom configuration.
+// gzip writers are pooled per compression level and reused across requests.
+func GzipWithConfig(config GzipConfig) HandlerFunc {
+	compressor, err := newGzipCompressor(config)
+
 	return func(c *Context) {
+		if (config.Skipper != nil && config.Skipper(c)) || (compressor != nil && compressor.excluded(c.Path)) {
+			c.Next()
+			return
+		}
+
+		// response depends on Accept-Encoding, so shared caches should store compressed & plain responses separately.
+		addVary(c.Writer.Header(), HeaderAcceptEncoding)
+
 		// make sure if client request has gzip in accept-encoding header.
-		if !strings.Contains(c.GetRequestHeader(HeaderAcceptEncoding), "gzip") {
+		if !c.AcceptsEncoding("gzip") {
 			c.Next()
 			return
 		}
 
-		gz, err := gzip.NewWriterLevel(c.Writer, compressionLevel)
 		// this error may caused incorrect compression level value.
 		if err != nil {
 			c.String(http.StatusInternalServerError, "internal server error")
 			return
 		}
-		c.SetHeader(HeaderContentEncoding, "gzip")
-		defer gz.Close()
 
-		gzWriter := &gzipWriter{c.Writer, gz}
+		gzWriter := &gzipWriter{ResponseWriter: c.Writer, compressor: compressor}
+		defer gzWriter.close()
 
 		// replace default writter with Gzip Writer.
 		c.Writer = gzWriter
@@ -38,16 +187,136 @@ func Gzip(compressionLevel int) HandlerFunc {
 	}
 }
 
+// start decides compression level by response content type, then writes pending status code & body.
+// it's called once before response is written.
+func (g *gzipWriter) start(compress bool) error {
+	if g.started {
+		return nil
+	}
+	g.started = true
+
+	// response without body & already encoded response must not be compressed.
+	noBody := g.statusCode == http.StatusNoContent || g.statusCode == http.StatusNotModified
+	if noBody || g.Header().Get(HeaderContentEncoding) != "" {
+		compress = false
+	}
+
+	level := g.compressor.levelOf(g.Header().Get(HeaderContentType))
+	if compress && level != gzip.NoCompression {
+		g.level = level
+		g.writer = g.compressor.pools[level].Get().(*gzip.Writer)
+		g.writer.Reset(g.ResponseWriter)
+
+		g.Header().Set(HeaderContentEncoding, "gzip")
+		// reference: https://github.com/labstack/echo/issues/444
+		// If Content-Length header is set, gzip probably writes the wrong number of bytes.
+		// We should delete the Content-Length header prior to writing the headers on a gzipped response.
+		g.Header().Del(HeaderContentLength)
+	}
+
+	if g.statusCode != 0 {
+		g.ResponseWriter.WriteHeader(g.statusCode)
+	}
+
+	buffer := g.buffer
+	g.buffer = nil
+
+	if len(buffer) > 0 {
+		if _, err := g.write(buffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// close flushes compressed response and returns the writer to its pool.
+// pending response is shorter than min length, so it's written without compression.
+func (g *gzipWriter) close() {
+	g.start(false)
+
+	if g.writer == nil {
+		return
+	}
+
+	g.writer.Close()
+	g.writer.Reset(ioutil.Discard)
+	g.compressor.pools[g.level].Put(g.writer)
+	g.writer = nil
+}
+
+// write writes data to gzip writer, or to response writer when compression is skipped.
+func (g *gzipWriter) write(data []byte) (int, error) {
+	if g.writer == nil {
+		return g.ResponseWriter.Write(data)
+	}
+
+	return g.writer.Write(data)
+}
+
 // Write overrides default http response writer with gzip writter.
 func (g *gzipWriter) Write(data []byte) (int, error) {
-	return g.writer.Write(data)
+	if g.started {
+		return g.write(data)
+	}
+
+	// net/http would detect content type from compressed data, so detect it from the plain one.
+	if g.Header().Get(HeaderContentType) == "" {
+		g.Header().Set(HeaderContentType, http.DetectContentType(data))
+	}
+
+	g.buffer = append(g.buffer, data...)
+	if len(g.buffer) >= g.compressor.config.MinLength {
+		if err := g.start(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
 }
 
-// WriteHeader overrides response writer to delete content length.
-// reference: https://github.com/labstack/echo/issues/444
-// If Content-Length header is set, gzip probably writes the wrong number of bytes.
-// We should delete the Content-Length header prior to writing the headers on a gzipped response.
+// WriteHeader overrides response writer to start compression before headers are written.
+// when min length is set, status code is written along with the body.
 func (g *gzipWriter) WriteHeader(code int) {
-	g.Header().Del(HeaderContentLength)
-	g.ResponseWriter.WriteHeader(code)
+	if g.started {
+		g.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	g.statusCode = code
+	if g.compressor.config.MinLength <= 0 {
+		g.start(true)
+	}
+}
+
+// Flush implements http.Flusher, it starts compression and flushes compressed data to the client,
+// so streaming responses (e.g. JSONStream & server-sent events) keep working behind Gzip.
+func (g *gzipWriter) Flush() {
+	g.start(true)
+
+	if g.writer != nil {
+		g.writer.Flush()
+	}
+
+	if flusher, ok := g.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying response writer, it's used by http.ResponseController.
+func (g *gzipWriter) Unwrap() http.ResponseWriter {
+	return g.ResponseWriter
+}
+
+// addVary adds value to Vary header when it isn't listed yet.
+func addVary(header http.Header, value string) {
+	for _, vary := range header.Values(HeaderVary) {
+		for _, field := range strings.Split(vary, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return
+			}
+		}
+	}
+
+	header.Add(HeaderVary, value)
 }
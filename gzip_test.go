@@ -2,9 +2,11 @@ package nano
 
 import (
 	"compress/gzip"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -78,3 +80,239 @@ func TestGzipWithWrongCompressionLevel(t *testing.T) {
 		t.Errorf("expected encoding not to be gzip; got %s", encoding)
 	}
 }
+
+func TestGzipContentTypeLevels(t *testing.T) {
+	app := New()
+	app.Use(GzipWithConfig(GzipConfig{
+		Level: gzip.BestSpeed,
+		ContentTypeLevels: map[string]int{
+			"image/*": gzip.NoCompression,
+		},
+	}))
+
+	app.GET("/text", func(c *Context) {
+		c.String(http.StatusOK, "hello world")
+	})
+
+	app.GET("/image", func(c *Context) {
+		c.SetContentType("image/png")
+		c.Data(http.StatusOK, []byte("png"))
+	})
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Add(HeaderAcceptEncoding, "gzip")
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// pooled writers must be reset between requests.
+	for i := 0; i < 2; i++ {
+		rec := request("/text")
+		if encoding := rec.Header().Get(HeaderContentEncoding); encoding != "gzip" {
+			t.Fatalf("expected encoding to be gzip; got %s", encoding)
+		}
+
+		gz, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("expected valid gzip body; got %v", err)
+		}
+
+		if body, _ := ioutil.ReadAll(gz); string(body) != "hello world" {
+			t.Errorf("expected decompressed body to be hello world; got %s", body)
+		}
+	}
+
+	rec := request("/image")
+	if encoding := rec.Header().Get(HeaderContentEncoding); encoding != "" {
+		t.Errorf("expected image not to be compressed; got %s", encoding)
+	}
+
+	if body := rec.Body.String(); body != "png" {
+		t.Errorf("expected image body to be png; got %s", body)
+	}
+}
+
+func BenchmarkGzip(b *testing.B) {
+	app := New()
+	app.Use(Gzip(gzip.DefaultCompression))
+
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "hello world")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Add(HeaderAcceptEncoding, "gzip")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+func TestGzipMinLength(t *testing.T) {
+	app := New()
+	app.Use(GzipWithConfig(GzipConfig{Level: gzip.DefaultCompression, MinLength: 32}))
+
+	large := strings.Repeat("hello world ", 10)
+
+	app.GET("/small", func(c *Context) {
+		c.JSON(http.StatusCreated, H{"ok": true})
+	})
+
+	app.GET("/large", func(c *Context) {
+		c.String(http.StatusOK, large)
+	})
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Add(HeaderAcceptEncoding, "gzip")
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := request("/small")
+	if encoding := rec.Header().Get(HeaderContentEncoding); encoding != "" {
+		t.Errorf("expected small response not to be compressed; got %s", encoding)
+	}
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"ok":true}` {
+		t.Errorf("expected small response to be written as is; got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request("/large")
+	if encoding := rec.Header().Get(HeaderContentEncoding); encoding != "gzip" {
+		t.Fatalf("expected large response to be compressed; got %s", encoding)
+	}
+
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("expected valid gzip body; got %v", err)
+	}
+
+	if body, _ := ioutil.ReadAll(gz); string(body) != large {
+		t.Errorf("expected decompressed body to be %s; got %s", large, body)
+	}
+}
+
+func TestGzipExclusion(t *testing.T) {
+	app := New()
+	app.Use(GzipWithConfig(GzipConfig{
+		Level:              gzip.DefaultCompression,
+		ExcludedPaths:      []string{"/stream"},
+		ExcludedExtensions: []string{".PNG"},
+	}))
+
+	text := func(c *Context) {
+		c.String(http.StatusOK, "hello world")
+	}
+
+	app.GET("/stream/events", text)
+	app.GET("/assets/logo.png", text)
+	app.GET("/photo", func(c *Context) {
+		c.SetContentType("image/jpeg")
+		c.Data(http.StatusOK, []byte("jpeg"))
+	})
+	app.GET("/encoded", func(c *Context) {
+		c.SetHeader(HeaderContentEncoding, "br")
+		c.Data(http.StatusOK, []byte("brotli"))
+	})
+	app.GET("/empty", func(c *Context) {
+		c.Status(http.StatusNoContent)
+	})
+	app.GET("/text", text)
+
+	testCases := []struct {
+		url      string
+		encoding string
+		vary     string
+	}{
+		{"/stream/events", "", ""},
+		{"/assets/logo.png", "", ""},
+		{"/photo", "", HeaderAcceptEncoding},
+		{"/encoded", "br", HeaderAcceptEncoding},
+		{"/empty", "", HeaderAcceptEncoding},
+		{"/text", "gzip", HeaderAcceptEncoding},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Add(HeaderAcceptEncoding, "gzip")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if encoding := rec.Header().Get(HeaderContentEncoding); encoding != tc.encoding {
+				st.Errorf("expected encoding to be %q; got %q", tc.encoding, encoding)
+			}
+
+			if vary := rec.Header().Get(HeaderVary); vary != tc.vary {
+				st.Errorf("expected vary to be %q; got %q", tc.vary, vary)
+			}
+		})
+	}
+}
+
+func TestGzipStreaming(t *testing.T) {
+	app := New()
+	app.Use(GzipWithConfig(GzipConfig{Level: gzip.DefaultCompression, MinLength: 1024}))
+
+	rec := httptest.NewRecorder()
+	flushed := 0
+
+	app.GET("/events", func(c *Context) {
+		c.SetContentType("text/event-stream")
+		c.Writer.Write([]byte("data: first\n\n"))
+
+		flusher, ok := c.Writer.(http.Flusher)
+		if !ok {
+			t.Errorf("expected gzip writer to implement http.Flusher")
+			return
+		}
+		flusher.Flush()
+		flushed = rec.Body.Len()
+
+		c.Writer.Write([]byte("data: second\n\n"))
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/events", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Add(HeaderAcceptEncoding, "gzip")
+	app.ServeHTTP(rec, req)
+
+	if !rec.Flushed || flushed == 0 {
+		t.Errorf("expected first event to be flushed before the response is complete")
+	}
+
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("expected valid gzip body; got %v", err)
+	}
+
+	if body, _ := ioutil.ReadAll(gz); string(body) != "data: first\n\ndata: second\n\n" {
+		t.Errorf("expected decompressed events; got %q", body)
+	}
+}
@@ -0,0 +1,55 @@
+package nano
+
+import (
+	"log/slog"
+)
+
+// HandlerE defines request handler returning error, so handler could return error of c.JSON, c.Bind, etc.
+// instead of writing error response itself. Returned error is responded like Context.AbortWithError,
+// that is using error handler set by Engine.SetErrorHandler.
+//
+//	func showUser(c *nano.Context) error {
+//		user, err := users.Find(c.Param("id"))
+//		if err != nil {
+//			return nano.NewHTTPError(http.StatusNotFound, "user not found").WithInternal(err)
+//		}
+//
+//		return c.JSON(http.StatusOK, user)
+//	}
+type HandlerE func(c *Context) error
+
+// E functions to convert HandlerE into HandlerFunc, so it's accepted by route registration & Use,
+// e.g. app.GET("/users/:id", nano.E(showUser)). Error of cancelled request is ignored since nobody reads
+// the response, and error returned after the response is written is only logged.
+func E(handler HandlerE) HandlerFunc {
+	return func(c *Context) {
+		err := handler(c)
+		if err == nil || c.Err() != nil {
+			return
+		}
+
+		if c.Written() {
+			c.Abort()
+			c.Logger().Error("request failed after response is written", slog.Any("error", err))
+			return
+		}
+
+		c.AbortWithError(err)
+	}
+}
+
+// HandleE functions to register route of handlers returning error, see HandlerE.
+func (rg *RouterGroup) HandleE(requestMethod, urlPattern string, handler ...HandlerE) *Route {
+	handlers := make([]HandlerFunc, len(handler))
+	for i, h := range handler {
+		handlers[i] = E(h)
+	}
+
+	route := rg.addRoute(requestMethod, urlPattern, handlers...)
+	// name the route after the handler instead of its wrapper.
+	if len(handler) > 0 {
+		route.info.HandlerName = nameOfFunction(handler[len(handler)-1])
+	}
+
+	return route
+}
@@ -0,0 +1,63 @@
+package nano
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerE(t *testing.T) {
+	errDatabase := errors.New("database is down")
+
+	app := New()
+	app.GET("/users/:id", E(func(c *Context) error {
+		if c.Param("id") != "1" {
+			return NewHTTPError(http.StatusNotFound, "user not found")
+		}
+
+		return c.JSON(http.StatusOK, H{"id": 1})
+	}))
+
+	app.HandleE(http.MethodGet, "/orders", func(c *Context) error {
+		return errDatabase
+	})
+
+	app.GET("/written", E(func(c *Context) error {
+		c.String(http.StatusAccepted, "accepted")
+		return errDatabase
+	}))
+
+	tt := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/users/1", http.StatusOK, `{"id":1}`},
+		{"/users/2", http.StatusNotFound, `{"error":"user not found"}`},
+		{"/orders", http.StatusInternalServerError, `{"error":"Internal Server Error"}`},
+		{"/written", http.StatusAccepted, "accepted"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderAccept, MimeJSON)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if name := app.Routes()[1].HandlerName; !strings.HasPrefix(name, "github.com/hariadivicky/nano.TestHandlerE.func") {
+		t.Errorf("expected handler name of HandleE route to be the handler; got %s", name)
+	}
+}
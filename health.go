@@ -0,0 +1,205 @@
+package nano
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// health check statuses.
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthProbe checks a dependency, e.g. database ping. it returns error when the dependency is unhealthy.
+type HealthProbe func(ctx context.Context) error
+
+// HealthCheckConfig defines health check endpoints configuration.
+type HealthCheckConfig struct {
+	// LivenessPath is path of liveness endpoint, default is /healthz.
+	LivenessPath string
+	// ReadinessPath is path of readiness endpoint, default is /readyz.
+	ReadinessPath string
+	// Liveness are probes of liveness endpoint, keep them cheap since failing liveness usually restarts the process.
+	Liveness map[string]HealthProbe
+	// Readiness are probes of readiness endpoint, e.g. database & downstream service checks.
+	Readiness map[string]HealthProbe
+	// Timeout is maximum duration of each probe, default is 5 seconds.
+	Timeout time.Duration
+	// ShutdownDelay is duration Shutdown waits after readiness reports not ready before it stops the server,
+	// so load balancer has time to stop sending new requests.
+	ShutdownDelay time.Duration
+}
+
+// HealthReport is response body of health check endpoints.
+type HealthReport struct {
+	Status string `json:"status"`
+	// Reason tells why the engine isn't ready while all probes pass, i.e. "warming up" or "shutting down".
+	Reason string                       `json:"reason,omitempty"`
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthCheckResult is result of single probe.
+type HealthCheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// EnableHealthChecks functions to register liveness & readiness endpoints responding json HealthReport,
+// 200 status code when healthy and 503 otherwise. readiness also fails until warm-up tasks are completed
+// and after Shutdown is called. both endpoints are served in maintenance mode.
+func (ng *Engine) EnableHealthChecks(config HealthCheckConfig) {
+	if config.LivenessPath == "" {
+		config.LivenessPath = "/healthz"
+	}
+
+	if config.ReadinessPath == "" {
+		config.ReadinessPath = "/readyz"
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	ng.healthConfig = &config
+
+	ng.GET(config.LivenessPath, func(c *Context) {
+		respondHealth(c, runProbes(c.Request.Context(), config.Liveness, config.Timeout))
+	})
+
+	ng.GET(config.ReadinessPath, func(c *Context) {
+		report := runProbes(c.Request.Context(), config.Readiness, config.Timeout)
+
+		switch {
+		case atomic.LoadInt32(&ng.shuttingDown) == 1:
+			report.Status, report.Reason = HealthStatusFail, "shutting down"
+		case !ng.IsReady():
+			report.Status, report.Reason = HealthStatusFail, "warming up"
+		}
+
+		respondHealth(c, report)
+	})
+}
+
+// isHealthCheck returns true when url path is health check endpoint.
+func (ng *Engine) isHealthCheck(urlPath string) bool {
+	return ng.healthConfig != nil && (urlPath == ng.healthConfig.LivenessPath || urlPath == ng.healthConfig.ReadinessPath)
+}
+
+// Shutdown functions to gracefully stop server started by Run, Run returns http.ErrServerClosed afterwards.
+// readiness endpoint reports not ready and scheduled jobs stop first, then the server is stopped after HealthCheckConfig.ShutdownDelay,
+// and background tasks (including running scheduled jobs) are waited until ctx is done. Singletons registered by Provide
+// are closed last.
+// Call it before shutting down your own http.Server to flip the readiness only, then call WaitTasks
+// and CloseSingletons afterwards.
+func (ng *Engine) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&ng.shuttingDown, 1)
+	ng.stopScheduler()
+
+	if ng.healthConfig != nil && ng.healthConfig.ShutdownDelay > 0 {
+		select {
+		case <-time.After(ng.healthConfig.ShutdownDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// own http.Server is still serving requests, so its singletons are still in use.
+	if ng.server == nil {
+		return nil
+	}
+
+	if err := ng.server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	if err := ng.WaitTasks(ctx); err != nil {
+		return err
+	}
+
+	return ng.CloseSingletons()
+}
+
+// runProbes runs probes concurrently, each probe is limited by the timeout.
+func runProbes(ctx context.Context, probes map[string]HealthProbe, timeout time.Duration) HealthReport {
+	report := HealthReport{Status: HealthStatusOK}
+	if len(probes) == 0 {
+		return report
+	}
+
+	report.Checks = make(map[string]HealthCheckResult, len(probes))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, probe := range probes {
+		wg.Add(1)
+
+		go func(name string, probe HealthProbe) {
+			defer wg.Done()
+
+			result := runProbe(ctx, probe, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[name] = result
+			if result.Status != HealthStatusOK {
+				report.Status = HealthStatusFail
+			}
+		}(name, probe)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+// runProbe runs single probe, panic and timeout are reported as failure.
+func runProbe(ctx context.Context, probe HealthProbe, timeout time.Duration) (result HealthCheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	errs := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				errs <- fmt.Errorf("probe panic: %v", recovered)
+			}
+		}()
+
+		errs <- probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result.Status, result.Duration = HealthStatusOK, time.Since(startedAt).String()
+	if err != nil {
+		result.Status, result.Error = HealthStatusFail, err.Error()
+	}
+
+	return result
+}
+
+// respondHealth responds health report, 503 status code is used when it fails.
+func respondHealth(c *Context, report HealthReport) {
+	status := http.StatusOK
+	if report.Status != HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.SetHeader(HeaderCacheControl, "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,113 @@
+package nano
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthChecks(t *testing.T) {
+	dbErr := errors.New("connection refused")
+
+	tt := []struct {
+		name   string
+		path   string
+		db     error
+		setup  func(app *Engine)
+		status int
+		report HealthReport
+	}{
+		{"liveness", "/healthz", nil, nil, http.StatusOK, HealthReport{Status: HealthStatusOK}},
+		{"readiness", "/readyz", nil, nil, http.StatusOK, HealthReport{Status: HealthStatusOK, Checks: map[string]HealthCheckResult{"db": {Status: HealthStatusOK}}}},
+		{"failing probe", "/readyz", dbErr, nil, http.StatusServiceUnavailable, HealthReport{
+			Status: HealthStatusFail,
+			Checks: map[string]HealthCheckResult{"db": {Status: HealthStatusFail, Error: "connection refused"}},
+		}},
+		{"warming up", "/readyz", nil, func(app *Engine) {
+			app.Warmup(func(ctx context.Context) error { return nil })
+		}, http.StatusServiceUnavailable, HealthReport{Status: HealthStatusFail, Reason: "warming up", Checks: map[string]HealthCheckResult{"db": {Status: HealthStatusOK}}}},
+		{"shutting down", "/readyz", nil, func(app *Engine) {
+			app.Shutdown(context.Background())
+		}, http.StatusServiceUnavailable, HealthReport{Status: HealthStatusFail, Reason: "shutting down", Checks: map[string]HealthCheckResult{"db": {Status: HealthStatusOK}}}},
+		{"served in maintenance mode", "/healthz", nil, func(app *Engine) {
+			app.SetMaintenance(true)
+		}, http.StatusOK, HealthReport{Status: HealthStatusOK}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.EnableHealthChecks(HealthCheckConfig{
+				Readiness: map[string]HealthProbe{
+					"db": func(ctx context.Context) error { return tc.db },
+				},
+			})
+
+			if tc.setup != nil {
+				tc.setup(app)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			var report HealthReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				st.Fatalf("could not decode health report: %v", err)
+			}
+
+			// duration varies, so it's not compared.
+			for name, check := range report.Checks {
+				check.Duration = ""
+				report.Checks[name] = check
+			}
+
+			if report.Status != tc.report.Status || report.Reason != tc.report.Reason || len(report.Checks) != len(tc.report.Checks) {
+				st.Fatalf("expected report to be %+v; got %+v", tc.report, report)
+			}
+
+			for name, check := range tc.report.Checks {
+				if report.Checks[name] != check {
+					st.Errorf("expected %s check to be %+v; got %+v", name, check, report.Checks[name])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthProbeTimeout(t *testing.T) {
+	report := runProbes(context.Background(), map[string]HealthProbe{
+		"slow": func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(time.Second)
+			return nil
+		},
+		"panic": func(ctx context.Context) error {
+			panic("boom")
+		},
+	}, 10*time.Millisecond)
+
+	if report.Status != HealthStatusFail {
+		t.Errorf("expected report status to be fail; got %s", report.Status)
+	}
+
+	if err := report.Checks["slow"].Error; err != context.DeadlineExceeded.Error() {
+		t.Errorf("expected slow probe error to be %v; got %s", context.DeadlineExceeded, err)
+	}
+
+	if err := report.Checks["panic"].Error; err != "probe panic: boom" {
+		t.Errorf("expected panic probe error to be probe panic: boom; got %s", err)
+	}
+}
@@ -0,0 +1,90 @@
+package nano
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// BeforeWrite functions to register hook called once right before status code is written,
+// e.g. to set cookies or security headers. hooks are called in registration order,
+// hook registered after response is written is never called.
+func (c *Context) BeforeWrite(fn func()) {
+	if c.beforeWrite == nil {
+		c.Writer = &hookWriter{ResponseWriter: c.Writer, c: c}
+	}
+
+	c.beforeWrite = append(c.beforeWrite, fn)
+}
+
+// AfterResponse functions to register hook called after the request is served, e.g. to release resources.
+// hooks are called in reverse registration order like deferred functions, even when handler panics.
+func (c *Context) AfterResponse(fn func()) {
+	c.afterResponse = append(c.afterResponse, fn)
+}
+
+// runAfter calls trailing middlewares registered by UseAfter, the handlers stack is exhausted first,
+// so Next inside them doesn't resume the stack.
+func (c *Context) runAfter(after []HandlerFunc) {
+	c.cursor = len(c.handlers)
+
+	for _, middleware := range after {
+		middleware(c)
+	}
+}
+
+// runAfterResponse calls after-response hooks.
+func (c *Context) runAfterResponse() {
+	for i := len(c.afterResponse) - 1; i >= 0; i-- {
+		c.afterResponse[i]()
+	}
+}
+
+// hookWriter calls before-write hooks of context once before status code is written.
+type hookWriter struct {
+	http.ResponseWriter
+	c       *Context
+	written bool
+}
+
+// WriteHeader calls before-write hooks before the status code is written.
+func (w *hookWriter) WriteHeader(code int) {
+	if !w.written {
+		w.written = true
+
+		for _, fn := range w.c.beforeWrite {
+			fn()
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes 200 status code first when status code isn't written yet.
+func (w *hookWriter) Write(data []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher.
+func (w *hookWriter) Flush() {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *hookWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, ErrHijackNotSupported
+}
@@ -0,0 +1,81 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestResponseHooks(t *testing.T) {
+	calls := make([]string, 0)
+
+	app := New()
+	app.Use(func(c *Context) {
+		c.BeforeWrite(func() {
+			calls = append(calls, "before write")
+			c.SetHeader("X-Frame-Options", "DENY")
+		})
+		c.AfterResponse(func() {
+			calls = append(calls, "after response 1")
+		})
+		c.AfterResponse(func() {
+			calls = append(calls, "after response 2")
+		})
+
+		c.Next()
+	})
+	app.GET("/", func(c *Context) {
+		calls = append(calls, "handler")
+		c.String(http.StatusCreated, "ok")
+		calls = append(calls, "written")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated || rec.Header().Get("X-Frame-Options") != "DENY" {
+		t.Errorf("expected response to be 201 with header set by hook; got %d %v", rec.Code, rec.Header())
+	}
+
+	expected := []string{"handler", "before write", "written", "after response 2", "after response 1"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected hook calls to be %v; got %v", expected, calls)
+	}
+}
+
+func TestAfterResponsePanic(t *testing.T) {
+	called := false
+
+	app := New()
+	app.GET("/", func(c *Context) {
+		c.AfterResponse(func() {
+			called = true
+		})
+
+		panic("boom")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	func() {
+		defer func() {
+			recover()
+		}()
+
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	if !called {
+		t.Errorf("expected after response hook to be called when handler panics")
+	}
+}
@@ -0,0 +1,115 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+)
+
+// HTTPError is error responded to the client with its status code. Message is shown to the client,
+// while Internal is the cause which is only logged, e.g. database error behind 500 status code.
+type HTTPError struct {
+	Code     int
+	Message  string
+	Internal error
+}
+
+// NewHTTPError creates http error, message defaults to status text of the code.
+func NewHTTPError(code int, message string) *HTTPError {
+	return &HTTPError{Code: code, Message: message}
+}
+
+// WithInternal returns copy of the error having internal cause.
+func (e *HTTPError) WithInternal(err error) *HTTPError {
+	return &HTTPError{Code: e.Code, Message: e.Message, Internal: err}
+}
+
+// Error implements error interface.
+func (e *HTTPError) Error() string {
+	if e.Internal != nil {
+		return fmt.Sprintf("%d %s: %v", e.Code, e.message(), e.Internal)
+	}
+
+	return fmt.Sprintf("%d %s", e.Code, e.message())
+}
+
+// Unwrap returns the internal cause.
+func (e *HTTPError) Unwrap() error {
+	return e.Internal
+}
+
+// message returns message of the error, or status text when it's empty.
+func (e *HTTPError) message() string {
+	if e.Message == "" {
+		return http.StatusText(e.Code)
+	}
+
+	return e.Message
+}
+
+// toHTTPError converts err into http error. ErrBinding keeps its status code,
+// other errors are responded as 500 status code having err as internal cause.
+func toHTTPError(err error) *HTTPError {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	var errBinding ErrBinding
+	if errors.As(err, &errBinding) {
+		return &HTTPError{Code: errBinding.Status, Message: errBinding.Error(), Internal: errBinding.Err}
+	}
+
+	return &HTTPError{Code: http.StatusInternalServerError, Internal: err}
+}
+
+// SetErrorHandler functions to replace renderer of errors passed to Context.AbortWithError or panicked as HTTPError.
+// The internal cause is already logged before the handler is called.
+func (ng *Engine) SetErrorHandler(handler func(c *Context, err *HTTPError)) {
+	ng.errorHandler = handler
+}
+
+// AbortWithError functions to stop the handlers stack and respond err, so handler could just return after calling it.
+// HTTPError is responded using its code & message, ErrBinding using its status code & fields, and other errors
+// using 500 status code without exposing the error. The internal cause is logged using Context.Logger.
+//
+//	if err != nil {
+//		c.AbortWithError(nano.NewHTTPError(http.StatusNotFound, "user not found").WithInternal(err))
+//		return
+//	}
+func (c *Context) AbortWithError(err error) {
+	c.Abort()
+	c.respondError(toHTTPError(err))
+}
+
+// respondError logs internal cause of the error and renders it using error handler of the engine.
+func (c *Context) respondError(err *HTTPError) {
+	if err.Internal != nil {
+		c.Logger().Error("request failed", slog.Int("status", err.Code), slog.String("message", err.message()), slog.Any("error", err.Internal))
+	}
+
+	if c.engine != nil && c.engine.errorHandler != nil {
+		c.engine.errorHandler(c, err)
+		return
+	}
+
+	respondHTTPError(c, err)
+}
+
+// respondHTTPError is the default error handler, it responds json (or problem details) when client expects json
+// and html otherwise.
+func respondHTTPError(c *Context, err *HTTPError) {
+	if c.useProblemDetails() && c.expectProblem() {
+		c.Problem(err.Code, "", "", err.Message, nil)
+		return
+	}
+
+	if c.ExpectJSON() {
+		c.JSON(err.Code, H{"error": err.message()})
+		return
+	}
+
+	c.HTML(err.Code, fmt.Sprintf("<h1>%d %s</h1><p>%s</p>", err.Code, http.StatusText(err.Code), html.EscapeString(err.message())))
+}
@@ -0,0 +1,108 @@
+package nano
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAbortWithError(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+
+	tt := []struct {
+		name    string
+		err     error
+		accept  string
+		status  int
+		body    string
+		logged  bool
+		handler func(c *Context, err error)
+	}{
+		{"http error as json", NewHTTPError(http.StatusNotFound, "user not found"), MimeJSON, http.StatusNotFound, `{"error":"user not found"}`, false, nil},
+		{"http error as html", NewHTTPError(http.StatusForbidden, "<admin> only"), MimeHTML, http.StatusForbidden, "<h1>403 Forbidden</h1><p>&lt;admin&gt; only</p>", false, nil},
+		{"default message", NewHTTPError(http.StatusConflict, ""), MimeJSON, http.StatusConflict, `{"error":"Conflict"}`, false, nil},
+		{"internal cause", NewHTTPError(http.StatusServiceUnavailable, "try again later").WithInternal(errDatabase), MimeJSON, http.StatusServiceUnavailable, `{"error":"try again later"}`, true, nil},
+		{"plain error", errDatabase, MimeJSON, http.StatusInternalServerError, `{"error":"Internal Server Error"}`, true, nil},
+		{"binding error", ErrBinding{Status: http.StatusUnprocessableEntity, Text: "validation error", Fields: []string{"name is required"}}, MimeJSON, http.StatusUnprocessableEntity, `{"error":"validation error name is required"}`, false, nil},
+		{"panicked http error", NewHTTPError(http.StatusUnauthorized, "login required"), MimeJSON, http.StatusUnauthorized, `{"error":"login required"}`, false, func(c *Context, err error) { panic(err) }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			var output bytes.Buffer
+			nextCalled := false
+
+			handler := tc.handler
+			if handler == nil {
+				handler = func(c *Context, err error) { c.AbortWithError(err) }
+			}
+
+			app := New()
+			app.SetLogger(slog.New(slog.NewTextHandler(&output, nil)))
+			app.Use(Recovery(), func(c *Context) {
+				handler(c, tc.err)
+				c.Next()
+			})
+			app.GET("/", func(c *Context) { nextCalled = true })
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderAccept, tc.accept)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+
+			if nextCalled {
+				st.Errorf("expected next handler not to be called")
+			}
+
+			if logged := strings.Contains(output.String(), errDatabase.Error()); logged != tc.logged {
+				st.Errorf("expected internal cause to be logged %t; got %s", tc.logged, output.String())
+			}
+
+			if strings.Contains(output.String(), "panic recovered") {
+				st.Errorf("expected http error not to be logged as panic; got %s", output.String())
+			}
+		})
+	}
+
+	t.Run("custom error handler", func(st *testing.T) {
+		app := New()
+		app.SetErrorHandler(func(c *Context, err *HTTPError) {
+			c.String(err.Code, "oops: %s", err.Message)
+		})
+		app.GET("/", func(c *Context) {
+			c.AbortWithError(NewHTTPError(http.StatusTeapot, "no coffee"))
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusTeapot || rec.Body.String() != "oops: no coffee" {
+			st.Errorf("expected custom error response; got %d %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("error message", func(st *testing.T) {
+		err := NewHTTPError(http.StatusNotFound, "").WithInternal(errDatabase)
+		if err.Error() != "404 Not Found: connection refused" || !errors.Is(err, errDatabase) {
+			st.Errorf("expected error to describe & wrap internal cause; got %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package nano
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HeaderContentLanguage is language of response content, it's set by I18n middleware.
+const HeaderContentLanguage = "Content-Language"
+
+// defaultLocale is locale used when I18n middleware isn't used.
+const defaultLocale = "en"
+
+// I18nConfig defines i18n middleware configuration.
+type I18nConfig struct {
+	// DefaultLocale is used when none of requested locales is supported, default is "en".
+	DefaultLocale string
+	// Locales are supported locales, default is locales registered by Engine.AddMessages.
+	Locales []string
+	// QueryParam is url query carrying requested locale, default is "lang". set it to "-" to disable.
+	QueryParam string
+	// CookieName is cookie carrying requested locale, default is "lang". set it to "-" to disable.
+	CookieName string
+}
+
+// AddMessages functions to register translated messages of a locale, e.g. "en" or "pt-BR".
+// messages are merged with previously registered messages of the same locale.
+func (ng *Engine) AddMessages(locale string, messages map[string]string) {
+	if ng.messages == nil {
+		ng.messages = make(map[string]map[string]string)
+	}
+
+	locale = normalizeLocale(locale)
+	if ng.messages[locale] == nil {
+		ng.messages[locale] = make(map[string]string, len(messages))
+	}
+
+	for key, message := range messages {
+		ng.messages[locale][key] = message
+	}
+}
+
+// I18n returns middleware negotiating request locale using default configuration.
+func I18n() HandlerFunc {
+	return I18nWithConfig(I18nConfig{})
+}
+
+// I18nWithConfig returns middleware negotiating request locale from url query, cookie, and Accept-Language
+// header respectively. the locale is used by Context.T and validation error messages, and sent back
+// in Content-Language header.
+func I18nWithConfig(config I18nConfig) HandlerFunc {
+	if config.DefaultLocale == "" {
+		config.DefaultLocale = defaultLocale
+	}
+
+	if config.QueryParam == "" {
+		config.QueryParam = "lang"
+	}
+
+	if config.CookieName == "" {
+		config.CookieName = "lang"
+	}
+
+	return func(c *Context) {
+		supported := config.Locales
+		if supported == nil && c.engine != nil {
+			for locale := range c.engine.messages {
+				supported = append(supported, locale)
+			}
+		}
+
+		locale := negotiateLocale(c, config, supported)
+
+		c.fallbackLocale = normalizeLocale(config.DefaultLocale)
+		c.SetLocale(locale)
+		c.SetHeader(HeaderContentLanguage, locale)
+		c.Next()
+	}
+}
+
+// negotiateLocale returns the first supported locale requested by client, or default locale.
+func negotiateLocale(c *Context, config I18nConfig, supported []string) string {
+	requested := make([]string, 0)
+
+	if config.QueryParam != "-" {
+		requested = append(requested, c.Query(config.QueryParam))
+	}
+
+	if config.CookieName != "-" {
+		if cookie, err := c.Request.Cookie(config.CookieName); err == nil {
+			requested = append(requested, cookie.Value)
+		}
+	}
+
+	for _, language := range parseAccept(c.GetRequestHeader("Accept-Language")) {
+		if language.q > 0 {
+			requested = append(requested, language.mime)
+		}
+	}
+
+	for _, locale := range requested {
+		if matched := matchLocale(locale, supported); matched != "" {
+			return matched
+		}
+	}
+
+	return config.DefaultLocale
+}
+
+// matchLocale returns supported locale matching requested locale exactly, or by its base language,
+// e.g. en-US matches en. it returns empty string when none matches.
+func matchLocale(requested string, supported []string) string {
+	requested = normalizeLocale(requested)
+	if requested == "" || requested == "*" {
+		return ""
+	}
+
+	for _, candidate := range []string{requested, baseLocale(requested)} {
+		for _, locale := range supported {
+			if normalizeLocale(locale) == candidate {
+				return locale
+			}
+		}
+	}
+
+	return ""
+}
+
+// normalizeLocale converts locale into lower case using hyphen separator, e.g. pt_BR into pt-br.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// baseLocale returns language of the locale, e.g. en of en-us.
+func baseLocale(locale string) string {
+	return strings.SplitN(locale, "-", 2)[0]
+}
+
+// Locale returns locale of current request negotiated by I18n middleware, default is "en".
+func (c *Context) Locale() string {
+	if c.locale == "" {
+		return defaultLocale
+	}
+
+	return c.locale
+}
+
+// SetLocale functions to set locale of current request, validation error messages are translated
+// into the locale when it's supported by the validator.
+func (c *Context) SetLocale(locale string) {
+	c.locale = locale
+}
+
+// T functions to translate message key into locale of current request, args are applied to the message
+// using fmt.Sprintf. message of base language (e.g. en for en-US) is used when the locale doesn't have it,
+// then message of default locale, then the key itself.
+func (c *Context) T(key string, args ...interface{}) string {
+	message, ok := c.message(key)
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+// message looks up message key in locale of current request and its fallbacks.
+func (c *Context) message(key string) (string, bool) {
+	if c.engine == nil {
+		return "", false
+	}
+
+	locale := normalizeLocale(c.Locale())
+
+	for _, candidate := range []string{locale, baseLocale(locale), c.fallbackLocale} {
+		if message, ok := c.engine.messages[candidate][key]; ok {
+			return message, true
+		}
+	}
+
+	return "", false
+}
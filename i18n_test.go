@@ -0,0 +1,92 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestI18n(t *testing.T) {
+	app := New()
+	app.AddMessages("en", map[string]string{"greeting": "Hello, %s!", "farewell": "Goodbye"})
+	app.AddMessages("id", map[string]string{"greeting": "Halo, %s!"})
+	app.AddMessages("pt_BR", map[string]string{"greeting": "Olá, %s!"})
+
+	app.Use(I18n())
+	app.GET("/greet", func(c *Context) {
+		c.String(http.StatusOK, "%s|%s|%s", c.Locale(), c.T("greeting", "foo"), c.T("farewell"))
+	})
+
+	type Person struct {
+		Name string `form:"name" validate:"required"`
+	}
+
+	app.POST("/people", func(c *Context) {
+		var person Person
+		if err := c.Bind(&person); err != nil {
+			c.String(http.StatusUnprocessableEntity, "%s", strings.Join(err.(ErrBinding).Fields, ","))
+		}
+	})
+
+	tt := []struct {
+		name     string
+		target   string
+		cookie   string
+		accept   string
+		expected string
+	}{
+		{"default locale", "/greet", "", "", "en|Hello, foo!|Goodbye"},
+		{"accept language quality", "/greet", "", "fr;q=1, id;q=0.8, en;q=0.5", "id|Halo, foo!|Goodbye"},
+		{"base language", "/greet", "", "id-ID", "id|Halo, foo!|Goodbye"},
+		{"region locale", "/greet", "", "pt-BR", "pt-br|Olá, foo!|Goodbye"},
+		{"cookie over header", "/greet", "id", "en", "id|Halo, foo!|Goodbye"},
+		{"query over cookie", "/greet?lang=en", "id", "", "en|Hello, foo!|Goodbye"},
+		{"unsupported locale", "/greet?lang=de", "", "de", "en|Hello, foo!|Goodbye"},
+		{"validation message", "/people", "", "id", "name wajib diisi"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			requestMethod := http.MethodGet
+			if tc.target == "/people" {
+				skipWithoutValidator(st)
+				requestMethod = http.MethodPost
+			}
+
+			req, err := http.NewRequest(requestMethod, tc.target, strings.NewReader(""))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tc.cookie})
+			}
+			req.Header.Set("Accept-Language", tc.accept)
+			if requestMethod == http.MethodPost {
+				req.Header.Set(HeaderContentType, MimeFormURLEncoded)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tc.expected {
+				st.Errorf("expected response to be %s; got %s", tc.expected, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTranslateWithoutMiddleware(t *testing.T) {
+	c, app := CreateTestContext(httptest.NewRecorder())
+	app.AddMessages("en", map[string]string{"greeting": "Hello"})
+
+	if c.T("greeting") != "Hello" {
+		t.Errorf("expected greeting to be Hello; got %s", c.T("greeting"))
+	}
+
+	if c.T("missing.key") != "missing.key" {
+		t.Errorf("expected missing key to be returned as is; got %s", c.T("missing.key"))
+	}
+}
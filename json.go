@@ -0,0 +1,100 @@
+package nano
+
+import (
+	"io"
+	"sync"
+)
+
+// JSONCodec encodes & decodes json, it's used by json responses, json binding, and patch documents, see Engine.SetJSONCodec.
+// The default codec formats time field using time_format & time_location tags, see Engine.AddTimeFormatAlias.
+// build with nano_nojsoniter tag (or its former name nano_nojsontime) to use encoding/json instead of jsoniter.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	NewEncoder(w io.Writer) JSONEncoder
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONEncoder writes json values into a stream.
+type JSONEncoder interface {
+	Encode(v interface{}) error
+}
+
+// JSONDecoder reads json values from a stream.
+type JSONDecoder interface {
+	Decode(v interface{}) error
+}
+
+// json is the default json codec, it's used when there is no time format alias & override.
+var json JSONCodec = newJSONCodec(nil, "")
+
+// SetJSONCodec functions to replace json codec used by Context.JSON, JSONStream, BindJSON, and BindMergePatch,
+// e.g. sonic or encoding/json/v2 for throughput. pass nil to restore the default codec.
+func (ng *Engine) SetJSONCodec(codec JSONCodec) {
+	ng.jsonCodec = codec
+}
+
+// AddTimeFormatAlias functions to register time layout alias used by time_format tag & SetTimeFormat,
+// e.g. AddTimeFormatAlias("month", "2006-01"). aliases are scoped to the engine, so apps in one process
+// could use different layouts. sql_date, sql_datetime, and names of time package layouts (e.g. RFC1123) are built-in.
+func (ng *Engine) AddTimeFormatAlias(alias, layout string) {
+	ng.router.mustNotBeFrozen("add time format alias " + alias)
+
+	if ng.timeFormats == nil {
+		ng.timeFormats = make(map[string]string)
+	}
+
+	ng.timeFormats[alias] = layout
+}
+
+// TimeFormat returns middleware setting default time layout of json response & binding, see Context.SetTimeFormat.
+//
+//	app.GET("/reports", listReports).Use(nano.TimeFormat("sql_date"))
+func TimeFormat(layout string) HandlerFunc {
+	return func(c *Context) {
+		c.SetTimeFormat(layout)
+		c.Next()
+	}
+}
+
+// SetTimeFormat functions to override default time layout of json response & binding of current request,
+// it applies to time fields without time_format tag. layout could be alias, e.g. sql_date. default is RFC3339.
+func (c *Context) SetTimeFormat(layout string) {
+	c.timeFormat = layout
+}
+
+// jsonCodec returns json codec set by SetJSONCodec, or the default codec using time formats of the engine.
+func (c *Context) jsonCodec() JSONCodec {
+	if c.engine == nil {
+		return timeFormatCodec(nil, nil, c.timeFormat)
+	}
+
+	if c.engine.jsonCodec != nil {
+		return c.engine.jsonCodec
+	}
+
+	return timeFormatCodec(&c.engine.timeCodecs, c.engine.timeFormats, c.timeFormat)
+}
+
+// sharedTimeCodecs caches codecs of context created without engine.
+var sharedTimeCodecs sync.Map
+
+// timeFormatCodec returns default json codec using time format aliases & default layout,
+// codecs are cached by layout because jsoniter caches encoders per codec.
+func timeFormatCodec(codecs *sync.Map, aliases map[string]string, layout string) JSONCodec {
+	if len(aliases) == 0 && layout == "" {
+		return json
+	}
+
+	if codecs == nil {
+		codecs = &sharedTimeCodecs
+	}
+
+	if codec, ok := codecs.Load(layout); ok {
+		return codec.(JSONCodec)
+	}
+
+	codec, _ := codecs.LoadOrStore(layout, newJSONCodec(aliases, layout))
+
+	return codec.(JSONCodec)
+}
@@ -0,0 +1,184 @@
+//go:build !nano_nojsoniter && !nano_nojsontime
+
+package nano
+
+import (
+	"io"
+	"time"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// builtinTimeFormats are time_format aliases available in every engine.
+var builtinTimeFormats = map[string]string{
+	"ANSIC":        time.ANSIC,
+	"UnixDate":     time.UnixDate,
+	"RubyDate":     time.RubyDate,
+	"RFC822":       time.RFC822,
+	"RFC822Z":      time.RFC822Z,
+	"RFC850":       time.RFC850,
+	"RFC1123":      time.RFC1123,
+	"RFC1123Z":     time.RFC1123Z,
+	"RFC3339":      time.RFC3339,
+	"RFC3339Nano":  time.RFC3339Nano,
+	"Kitchen":      time.Kitchen,
+	"Stamp":        time.Stamp,
+	"StampMilli":   time.StampMilli,
+	"StampMicro":   time.StampMicro,
+	"StampNano":    time.StampNano,
+	"sql_date":     "2006-01-02",
+	"sql_datetime": "2006-01-02 15:04:05",
+}
+
+// jsoniterCodec is json codec of jsoniter configuration.
+type jsoniterCodec struct {
+	api jsoniter.API
+}
+
+// newJSONCodec returns jsoniter codec supporting time_format & time_location tags.
+// aliases are added to builtinTimeFormats, layout is used by time field without time_format tag, default is RFC3339.
+func newJSONCodec(aliases map[string]string, layout string) JSONCodec {
+	extension := &timeFormatExtension{aliases: aliases}
+	extension.layout = extension.resolve(layout)
+	if extension.layout == "" {
+		extension.layout = time.RFC3339
+	}
+
+	api := jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true}.Froze()
+	api.RegisterExtension(extension)
+
+	return jsoniterCodec{api: api}
+}
+
+func (j jsoniterCodec) Marshal(v interface{}) ([]byte, error) {
+	return j.api.Marshal(v)
+}
+
+func (j jsoniterCodec) Unmarshal(data []byte, v interface{}) error {
+	return j.api.Unmarshal(data, v)
+}
+
+func (j jsoniterCodec) NewEncoder(w io.Writer) JSONEncoder {
+	return j.api.NewEncoder(w)
+}
+
+func (j jsoniterCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return j.api.NewDecoder(r)
+}
+
+// timeFormatExtension encodes & decodes time.Time and *time.Time struct fields using time_format & time_location tags.
+// time is written in local time zone unless time_location tag says otherwise, e.g. UTC or Asia/Jakarta.
+type timeFormatExtension struct {
+	jsoniter.DummyExtension
+	aliases map[string]string
+	layout  string
+}
+
+// resolve returns layout of time format alias, or the format itself when it isn't an alias.
+func (e *timeFormatExtension) resolve(format string) string {
+	if layout, ok := e.aliases[format]; ok {
+		return layout
+	}
+
+	if layout, ok := builtinTimeFormats[format]; ok {
+		return layout
+	}
+
+	return format
+}
+
+// UpdateStructDescriptor replaces codec of time fields.
+func (e *timeFormatExtension) UpdateStructDescriptor(descriptor *jsoniter.StructDescriptor) {
+	for _, binding := range descriptor.Fields {
+		typeName := binding.Field.Type().String()
+		if typeName != "time.Time" && typeName != "*time.Time" {
+			continue
+		}
+
+		codec := &timeCodec{layout: e.layout, location: time.Local, isPtr: typeName == "*time.Time"}
+		if format := binding.Field.Tag().Get("time_format"); format != "" {
+			codec.layout = e.resolve(format)
+		}
+
+		switch name := binding.Field.Tag().Get("time_location"); name {
+		case "", "Local":
+		case "UTC":
+			codec.location = time.UTC
+		default:
+			codec.location, codec.err = time.LoadLocation(name)
+		}
+
+		binding.Encoder, binding.Decoder = codec, codec
+	}
+}
+
+// timeCodec encodes & decodes time field using its layout & location.
+type timeCodec struct {
+	layout   string
+	location *time.Location
+	isPtr    bool
+	// err is error of loading time_location, it's reported on encoding & decoding.
+	err error
+}
+
+// timeOf returns time stored at ptr, it returns nil when the field is nil pointer.
+func (t *timeCodec) timeOf(ptr unsafe.Pointer) *time.Time {
+	if t.isPtr {
+		return *(**time.Time)(ptr)
+	}
+
+	return (*time.Time)(ptr)
+}
+
+func (t *timeCodec) IsEmpty(ptr unsafe.Pointer) bool {
+	return t.isPtr && t.timeOf(ptr) == nil
+}
+
+func (t *timeCodec) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if t.err != nil {
+		stream.Error = t.err
+		return
+	}
+
+	value := t.timeOf(ptr)
+	if value == nil {
+		stream.WriteNil()
+		return
+	}
+
+	stream.WriteString(value.In(t.location).Format(t.layout))
+}
+
+func (t *timeCodec) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if t.err != nil {
+		iter.Error = t.err
+		return
+	}
+
+	if iter.ReadNil() {
+		if t.isPtr {
+			*(**time.Time)(ptr) = nil
+		}
+
+		return
+	}
+
+	text := iter.ReadString()
+	if text == "" {
+		return
+	}
+
+	value, err := time.ParseInLocation(t.layout, text, t.location)
+	if err != nil {
+		iter.ReportError("decode time", err.Error())
+		return
+	}
+
+	if t.isPtr {
+		*(**time.Time)(ptr) = &value
+		return
+	}
+
+	*(*time.Time)(ptr) = value
+}
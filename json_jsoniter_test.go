@@ -0,0 +1,85 @@
+//go:build !nano_nojsoniter && !nano_nojsontime
+
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeFormat(t *testing.T) {
+	type Report struct {
+		Day      time.Time  `json:"day" time_format:"sql_date" time_location:"UTC"`
+		Month    *time.Time `json:"month,omitempty" time_format:"month" time_location:"UTC"`
+		Created  time.Time  `json:"created" time_location:"UTC"`
+		Archived *time.Time `json:"archived" time_location:"UTC"`
+	}
+
+	moment := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	report := Report{Day: moment, Month: &moment, Created: moment}
+
+	tt := []struct {
+		name   string
+		alias  string
+		layout string
+		body   string
+	}{
+		{"engine alias", "2006-01", "", `{"day":"2021-03-04","month":"2021-03","created":"2021-03-04T05:06:07Z","archived":null}`},
+		{"another engine alias", "01/2006", "", `{"day":"2021-03-04","month":"03/2021","created":"2021-03-04T05:06:07Z","archived":null}`},
+		{"response override", "2006-01", "sql_datetime", `{"day":"2021-03-04","month":"2021-03","created":"2021-03-04 05:06:07","archived":null}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.AddTimeFormatAlias("month", tc.alias)
+			app.GET("/report", func(c *Context) {
+				c.JSON(http.StatusOK, report)
+			})
+
+			if tc.layout != "" {
+				app.Use(TimeFormat(tc.layout))
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/report", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); body != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, body)
+			}
+		})
+	}
+
+	t.Run("binding", func(st *testing.T) {
+		app := New()
+		app.AddTimeFormatAlias("month", "2006-01")
+
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"day":"2021-03-04","month":"2021-03","created":"2021-03-04T05:06:07Z","archived":null}`))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeJSON)
+
+		ctx := newContext(httptest.NewRecorder(), req)
+		ctx.engine = app
+
+		var bound Report
+		if err := ctx.BindJSON(&bound); err != nil {
+			st.Fatalf("expected binding to succeed; got %v", err)
+		}
+
+		month := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		if !bound.Day.Equal(moment.Truncate(24*time.Hour)) || bound.Month == nil || !bound.Month.Equal(month) || !bound.Created.Equal(moment) || bound.Archived != nil {
+			st.Errorf("expected report to be bound using time formats; got %+v", bound)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+//go:build nano_nojsoniter || nano_nojsontime
+
+package nano
+
+import (
+	stdjson "encoding/json"
+	"io"
+)
+
+// stdJSONCodec is json codec of encoding/json.
+type stdJSONCodec struct{}
+
+// newJSONCodec returns encoding/json codec, time format aliases & layout are ignored.
+func newJSONCodec(aliases map[string]string, layout string) JSONCodec {
+	return stdJSONCodec{}
+}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return stdjson.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return stdjson.Unmarshal(data, v)
+}
+
+func (stdJSONCodec) NewEncoder(w io.Writer) JSONEncoder {
+	return stdjson.NewEncoder(w)
+}
+
+func (stdJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return stdjson.NewDecoder(r)
+}
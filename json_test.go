@@ -0,0 +1,83 @@
+package nano
+
+import (
+	stdjson "encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// countingJSONCodec is encoding/json codec counting its calls.
+type countingJSONCodec struct {
+	marshals, decoders int
+}
+
+func (j *countingJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	j.marshals++
+	return stdjson.Marshal(v)
+}
+
+func (j *countingJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return stdjson.Unmarshal(data, v)
+}
+
+func (j *countingJSONCodec) NewEncoder(w io.Writer) JSONEncoder {
+	return stdjson.NewEncoder(w)
+}
+
+func (j *countingJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	j.decoders++
+	return stdjson.NewDecoder(r)
+}
+
+func TestSetJSONCodec(t *testing.T) {
+	type Person struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	codec := &countingJSONCodec{}
+
+	app := New()
+	app.SetJSONCodec(codec)
+	app.POST("/people", func(c *Context) {
+		var person Person
+		if !c.MustBind(&person) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, person)
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"john"}`))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeJSON)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"name":"john"}` {
+		t.Errorf("expected response to be 201 {\"name\":\"john\"}; got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if codec.decoders != 1 || codec.marshals != 1 {
+		t.Errorf("expected codec to decode & marshal once; got %d decoders & %d marshals", codec.decoders, codec.marshals)
+	}
+
+	t.Run("restore default codec", func(st *testing.T) {
+		app := New()
+		app.SetJSONCodec(codec)
+		app.SetJSONCodec(nil)
+
+		ctx := newContext(httptest.NewRecorder(), req)
+		ctx.engine = app
+
+		if ctx.jsonCodec() != json {
+			st.Errorf("expected default codec to be used")
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package nano
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// websocketGUID is used to compute websocket handshake accept key (RFC 6455).
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// LiveReloadConfig defines nano livereload configuration.
+type LiveReloadConfig struct {
+	// Path is websocket endpoint used by injected script, default is /__livereload.
+	Path string
+}
+
+// LiveReload reloads browser when static files or templates change. It only works in debug mode.
+type LiveReload struct {
+	path    string
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// LiveReload functions to enable browser livereload in debug mode.
+// livereload script is injected into html responses, and connected browsers are reloaded
+// when static directories change. call Reload to trigger it manually, e.g. when templates are reloaded.
+func (ng *Engine) LiveReload(config LiveReloadConfig) *LiveReload {
+	if config.Path == "" {
+		config.Path = "/__livereload"
+	}
+
+	lr := &LiveReload{
+		path:    config.Path,
+		clients: make(map[chan struct{}]struct{}),
+	}
+
+	ng.Use(lr.Handle)
+	ng.OnStaticChange(func(changed []string) {
+		lr.Reload()
+	})
+
+	return lr
+}
+
+// Reload signals all connected browsers to reload.
+func (lr *LiveReload) Reload() {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	for client := range lr.clients {
+		close(client)
+		delete(lr.clients, client)
+	}
+}
+
+// script returns livereload script injected into html responses.
+func (lr *LiveReload) script() string {
+	return fmt.Sprintf(`<script>(function(){var s=location.protocol==="https:"?"wss://":"ws://";`+
+		`new WebSocket(s+location.host+"%s").onmessage=function(){location.reload()}})();</script>`, lr.path)
+}
+
+// Handle serves livereload websocket endpoint and injects livereload script into html responses.
+func (lr *LiveReload) Handle(c *Context) {
+	if c.engine == nil || !c.engine.debug {
+		c.Next()
+		return
+	}
+
+	if c.Path == lr.path {
+		lr.serveWebsocket(c)
+		return
+	}
+
+	writer := &liveReloadWriter{ResponseWriter: c.Writer, statusCode: http.StatusOK}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	// other responses are already written through.
+	if !writer.buffering {
+		return
+	}
+
+	writer.Header().Del(HeaderContentLength)
+	c.Writer.WriteHeader(writer.statusCode)
+	c.Writer.Write(injectScript(writer.body.Bytes(), lr.script()))
+}
+
+// injectScript inserts script before closing body tag, or appends it when there is no body tag.
+func injectScript(html []byte, script string) []byte {
+	index := bytes.LastIndex(bytes.ToLower(html), []byte("</body>"))
+	if index < 0 {
+		return append(html, script...)
+	}
+
+	injected := make([]byte, 0, len(html)+len(script))
+	injected = append(injected, html[:index]...)
+	injected = append(injected, script...)
+
+	return append(injected, html[index:]...)
+}
+
+// serveWebsocket upgrades connection and sends reload message when Reload is called.
+func (lr *LiveReload) serveWebsocket(c *Context) {
+	key := c.GetRequestHeader("Sec-WebSocket-Key")
+	if !c.IsWebsocket() || key == "" {
+		c.String(http.StatusBadRequest, "websocket upgrade required")
+		return
+	}
+
+	hijacker, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		c.String(http.StatusInternalServerError, "websocket is not supported")
+		return
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// register client before completing the handshake, so reload right after the handshake is not missed.
+	reload := make(chan struct{})
+	lr.mu.Lock()
+	lr.clients[reload] = struct{}{}
+	lr.mu.Unlock()
+
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		base64.StdEncoding.EncodeToString(hash[:]))
+	rw.Flush()
+
+	// browser doesn't send anything except close frame, so read error means the browser has gone.
+	gone := make(chan struct{})
+	go func(r *bufio.Reader) {
+		io.Copy(ioutil.Discard, r)
+		close(gone)
+	}(rw.Reader)
+
+	select {
+	case <-reload:
+		// unmasked text frame followed by close frame.
+		message := "reload"
+		rw.Write([]byte{0x81, byte(len(message))})
+		rw.WriteString(message)
+		rw.Write([]byte{0x88, 0x00})
+		rw.Flush()
+	case <-gone:
+		lr.mu.Lock()
+		delete(lr.clients, reload)
+		lr.mu.Unlock()
+	}
+}
+
+// liveReloadWriter buffers html response, so livereload script could be injected into it.
+// other responses are written through, so streaming & hijacking keep working.
+type liveReloadWriter struct {
+	http.ResponseWriter
+	body       bytes.Buffer
+	statusCode int
+	// decided is set once response content type is known, buffering is set when it's html.
+	decided   bool
+	buffering bool
+}
+
+// decide starts buffering html response, or writes status code of other response through.
+func (w *liveReloadWriter) decide() {
+	if w.decided {
+		return
+	}
+
+	w.decided = true
+	w.buffering = strings.HasPrefix(w.Header().Get(HeaderContentType), MimeHTML)
+
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(w.statusCode)
+	}
+}
+
+// Write buffers html response body, other response body is written through.
+func (w *liveReloadWriter) Write(data []byte) (int, error) {
+	w.decide()
+
+	if w.buffering {
+		return w.body.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteHeader defers writing status code until response content type is known, or html response is complete.
+func (w *liveReloadWriter) WriteHeader(code int) {
+	if w.decided {
+		return
+	}
+
+	w.statusCode = code
+	w.decide()
+}
+
+// Flush implements http.Flusher. flushing html response stops buffering, so streamed html isn't injected.
+func (w *liveReloadWriter) Flush() {
+	w.decide()
+
+	if w.buffering {
+		w.buffering = false
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		w.body.WriteTo(w.ResponseWriter)
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *liveReloadWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, ErrHijackNotSupported
+}
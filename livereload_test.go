@@ -0,0 +1,120 @@
+package nano
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLiveReloadInjection(t *testing.T) {
+	app := New()
+	app.LiveReload(LiveReloadConfig{})
+	app.GET("/", func(c *Context) {
+		c.HTML(http.StatusOK, "<html><body>hello</body></html>")
+	})
+	app.GET("/api", func(c *Context) {
+		c.JSON(http.StatusCreated, H{"hello": "world"})
+	})
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if body := request("/").Body.String(); strings.Contains(body, "<script>") {
+		t.Errorf("expected livereload script not to be injected outside debug mode; got %s", body)
+	}
+
+	app.SetDebug(true)
+
+	if body := request("/").Body.String(); !strings.Contains(body, `/__livereload").onmessage`) || !strings.HasSuffix(body, "</script></body></html>") {
+		t.Errorf("expected livereload script to be injected before closing body tag; got %s", body)
+	}
+
+	if rec := request("/api"); rec.Code != http.StatusCreated || rec.Body.String() != `{"hello":"world"}` {
+		t.Errorf("expected json response to be untouched; got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLiveReloadStreaming(t *testing.T) {
+	app := New()
+	app.SetDebug(true)
+	app.LiveReload(LiveReloadConfig{})
+	app.GET("/events", func(c *Context) {
+		c.SetContentType("text/event-stream")
+		c.Status(http.StatusOK)
+		c.Writer.Write([]byte("data: first\n\n"))
+
+		flusher, ok := c.Writer.(http.Flusher)
+		if !ok {
+			c.Writer.Write([]byte("flusher is not supported"))
+			return
+		}
+		flusher.Flush()
+
+		if _, ok := c.Writer.(http.Hijacker); !ok {
+			c.Writer.Write([]byte("hijacker is not supported"))
+		}
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/events", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if !rec.Flushed || rec.Body.String() != "data: first\n\n" {
+		t.Errorf("expected event stream to be flushed untouched; got %v %q", rec.Flushed, rec.Body.String())
+	}
+}
+
+func TestLiveReloadWebsocket(t *testing.T) {
+	app := New()
+	app.SetDebug(true)
+	lr := app.LiveReload(LiveReloadConfig{})
+
+	server := httptest.NewServer(app)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		log.Fatalf("could not connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	io.WriteString(conn, "GET /__livereload HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		log.Fatalf("could not read handshake response: %v", err)
+	}
+
+	if accept := res.Header.Get("Sec-WebSocket-Accept"); res.StatusCode != http.StatusSwitchingProtocols || accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("expected websocket handshake to succeed; got %d %s", res.StatusCode, accept)
+	}
+
+	lr.Reload()
+
+	frame := make([]byte, 8)
+	if _, err := io.ReadFull(reader, frame); err != nil {
+		t.Fatalf("could not read reload frame: %v", err)
+	}
+
+	if string(frame[2:]) != "reload" {
+		t.Errorf("expected reload message; got %q", frame)
+	}
+}
@@ -0,0 +1,62 @@
+package nano
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// HeaderXRequestID is request id header, it's used by Context.RequestID.
+const HeaderXRequestID = "X-Request-ID"
+
+// SetLogger functions to set base logger of Context.Logger, default is slog.Default().
+func (ng *Engine) SetLogger(logger *slog.Logger) {
+	ng.logger = logger
+}
+
+// Logger returns request-scoped logger having request_id, method, route, and path attributes,
+// so log records of the same request could be correlated. route is the url pattern of matching route,
+// it's empty when no route matches.
+func (c *Context) Logger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+
+	base := slog.Default()
+	if c.engine != nil && c.engine.logger != nil {
+		base = c.engine.logger
+	}
+
+	c.logger = base.With(
+		slog.String("request_id", c.RequestID()),
+		slog.String("method", c.Method),
+		slog.String("route", c.routePattern),
+		slog.String("path", c.Path),
+	)
+
+	return c.logger
+}
+
+// SetLogger replaces request-scoped logger, e.g. to add attributes for the next handlers.
+//
+//	c.SetLogger(c.Logger().With("user_id", user.ID))
+func (c *Context) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// RequestID returns id of current request taken from X-Request-ID header,
+// random id is generated when client doesn't send it.
+func (c *Context) RequestID() string {
+	if c.requestID != "" {
+		return c.requestID
+	}
+
+	c.requestID = c.GetRequestHeader(HeaderXRequestID)
+	if c.requestID == "" {
+		id := make([]byte, 16)
+		rand.Read(id)
+		c.requestID = hex.EncodeToString(id)
+	}
+
+	return c.requestID
+}
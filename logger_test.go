@@ -0,0 +1,82 @@
+package nano
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContextLogger(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	app := New()
+	app.SetLogger(slog.New(slog.NewJSONHandler(output, nil)))
+	app.Use(Recovery())
+
+	app.GET("/users/:id", func(c *Context) {
+		c.SetLogger(c.Logger().With("user_id", c.Param("id")))
+		c.Logger().Info("user fetched")
+	})
+
+	app.GET("/panic", func(c *Context) {
+		panic("boom")
+	})
+
+	serve := func(urlPath, requestID string) map[string]interface{} {
+		output.Reset()
+
+		req, err := http.NewRequest(http.MethodGet, urlPath, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		if requestID != "" {
+			req.Header.Set(HeaderXRequestID, requestID)
+		}
+
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		var record map[string]interface{}
+		if err := stdjson.Unmarshal(output.Bytes(), &record); err != nil {
+			t.Fatalf("expected log record to be json; got %s", output.String())
+		}
+
+		return record
+	}
+
+	t.Run("request attributes", func(st *testing.T) {
+		record := serve("/users/7", "abc")
+
+		expected := map[string]string{"msg": "user fetched", "request_id": "abc", "method": "GET", "route": "/users/:id", "path": "/users/7", "user_id": "7"}
+		for key, value := range expected {
+			if record[key] != value {
+				st.Errorf("expected %s to be %s; got %v", key, value, record[key])
+			}
+		}
+	})
+
+	t.Run("generated request id", func(st *testing.T) {
+		record := serve("/users/7", "")
+
+		if id, _ := record["request_id"].(string); len(id) != 32 {
+			st.Errorf("expected request id to be generated; got %v", record["request_id"])
+		}
+	})
+
+	t.Run("recovery uses context logger", func(st *testing.T) {
+		record := serve("/panic", "xyz")
+
+		if record["level"] != "ERROR" || record["request_id"] != "xyz" || record["error"] != "boom" {
+			st.Errorf("expected panic to be logged with request id; got %v", record)
+		}
+
+		if stack, _ := record["stack"].(string); !strings.Contains(stack, "goroutine") {
+			st.Errorf("expected stack trace to be logged")
+		}
+	})
+}
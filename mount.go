@@ -0,0 +1,89 @@
+package nano
+
+import (
+	"net/http"
+)
+
+// Mount functions to serve another http handler under given prefix, the prefix is stripped from request path.
+// When the handler is nano engine, its routes, names, router group middlewares, NoRoute, NoMethod, and OnTimeout
+// handlers are merged into this group instead, so they're listed in Routes and served without another routing pass.
+// Default handler of mounted engine becomes NoRoute handler of the prefix unless its root group has one.
+// Routes registered into mounted engine after Mount is called are not merged, neither are its Pre middlewares
+// and engine-wide settings (e.g. codecs, validator, and error handler), since they apply to this engine.
+func (rg *RouterGroup) Mount(prefix string, handler http.Handler) {
+	if engine, ok := handler.(*Engine); ok {
+		rg.mountEngine(prefix, engine)
+		return
+	}
+
+	rg.MountWithConfig(prefix, handler, MountConfig{})
+}
+
+// MountConfig defines configuration of mounted http handler.
+type MountConfig struct {
+	// KeepPrefix passes request path unchanged, e.g. to grpc-gateway mux whose routes include the full path.
+	KeepPrefix bool
+}
+
+// MountWithConfig functions to serve http handler under given prefix like Mount. Middlewares of this group
+// (e.g. auth, logging, CORS) run around the handler, and the handler could read nano context using ContextFromRequest,
+// e.g. to forward authenticated user as grpc metadata. So services of grpc-gateway could be moved into nano gradually.
+//
+//	gateway := runtime.NewServeMux(runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+//		c, _ := nano.ContextFromRequest(r)
+//		return metadata.Pairs("user-id", c.Bag.GetString("user_id"))
+//	}))
+//	api.MountWithConfig("/v1", gateway, nano.MountConfig{KeepPrefix: true})
+func (rg *RouterGroup) MountWithConfig(prefix string, handler http.Handler, config MountConfig) {
+	if !config.KeepPrefix {
+		handler = http.StripPrefix(rg.prefix+prefix, handler)
+	}
+
+	serve := WrapHandler(handler)
+
+	rg.Any(prefix, serve)
+	rg.Any(prefix+"/*path", serve)
+}
+
+// mountEngine merges routes & router groups of other engine with given prefix.
+func (rg *RouterGroup) mountEngine(prefix string, other *Engine) {
+	mounted := rg.Group(prefix)
+
+	for _, group := range other.groups {
+		mountedGroup := mounted
+		if group != other.RouterGroup {
+			mountedGroup = rg.Group(prefix + group.prefix)
+		}
+
+		mountedGroup.Use(group.middlewares...)
+		mountedGroup.UseAfter(group.after...)
+		mountedGroup.noRoute, mountedGroup.noMethod = group.noRoute, group.noMethod
+		mountedGroup.timeoutFallback = group.timeoutFallback
+	}
+
+	if mounted.noRoute == nil {
+		mounted.noRoute = other.router.defaultHandler
+	}
+
+	for _, route := range other.router.routes {
+		info := route.info
+		chain := other.router.handlers[route.key]
+
+		merged := rg.engine.router.register(RouteInfo{
+			Method: info.Method,
+			Path:   mounted.prefix + info.Path,
+			Group:  mounted.prefix + info.Group,
+		}, chain...)
+		merged.request, merged.response = route.request, route.response
+
+		// route-level handlers are already in the chain.
+		if route.override != nil {
+			merged.override = route.override
+			rg.engine.router.setOverride(merged.key, route.override)
+		}
+
+		if info.Name != "" {
+			merged.Name(info.Name)
+		}
+	}
+}
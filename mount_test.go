@@ -0,0 +1,168 @@
+package nano
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMount(t *testing.T) {
+	billing := New()
+	billing.Use(func(c *Context) {
+		c.SetHeader("X-Module", "billing")
+		c.Next()
+	})
+	billing.GET("/invoices/:id", func(c *Context) {
+		c.String(http.StatusOK, "invoice %s", c.Param("id"))
+	}).Name("invoice.show")
+
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+
+	app := New()
+	api := app.Group("/api")
+	api.Mount("/billing", billing)
+	app.Mount("/legacy", legacy)
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := request("/api/billing/invoices/7")
+	if rec.Body.String() != "invoice 7" || rec.Header().Get("X-Module") != "billing" {
+		t.Errorf("expected mounted engine route to be served with its middleware; got %s %v", rec.Body.String(), rec.Header())
+	}
+
+	if rec := request("/invoices/7"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected mounted route not to be served without prefix; got %d", rec.Code)
+	}
+
+	if location, _ := app.URL("invoice.show", map[string]string{"id": "7"}); location != "/api/billing/invoices/7" {
+		t.Errorf("expected mounted route name to be merged; got %s", location)
+	}
+
+	if rec := request("/legacy/ping"); rec.Body.String() != "pong" {
+		t.Errorf("expected mounted http handler to be served with stripped prefix; got %s", rec.Body.String())
+	}
+}
+
+func TestMountFallbacks(t *testing.T) {
+	billing := New()
+	billing.Default(func(c *Context) {
+		c.String(http.StatusNotFound, "billing not found")
+	})
+	billing.GET("/invoices", func(c *Context) {
+		c.String(http.StatusOK, "invoices")
+	})
+
+	reports := billing.Group("/reports")
+	reports.NoMethod(func(c *Context) {
+		c.String(http.StatusMethodNotAllowed, "reports method not allowed")
+	})
+	reports.OnTimeout(func(c *Context) {
+		c.String(http.StatusGatewayTimeout, "reports timeout")
+	})
+	reports.GET("/daily", func(c *Context) {
+		<-c.Request.Context().Done()
+	})
+
+	app := New()
+	app.Use(Timeout(20 * time.Millisecond))
+	app.Mount("/billing", billing)
+
+	tt := []struct {
+		method string
+		url    string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "/billing/unknown", http.StatusNotFound, "billing not found"},
+		{http.MethodPost, "/billing/reports/daily", http.StatusMethodNotAllowed, "reports method not allowed"},
+		{http.MethodGet, "/billing/reports/daily", http.StatusGatewayTimeout, "reports timeout"},
+		{http.MethodGet, "/unknown", http.StatusNotFound, "nano/1.0 not found"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.method+" "+tc.url, func(st *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestMountWithConfig(t *testing.T) {
+	gateway := http.NewServeMux()
+	gateway.HandleFunc("/v1/users/", func(w http.ResponseWriter, r *http.Request) {
+		c, ok := ContextFromRequest(r)
+		if !ok {
+			http.Error(w, "missing nano context", http.StatusInternalServerError)
+			return
+		}
+
+		fmt.Fprintf(w, "%s %s", r.URL.Path, c.Bag.GetString("user"))
+	})
+
+	app := New()
+	app.Use(func(c *Context) {
+		if c.GetRequestHeader("Authorization") == "" {
+			c.String(http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		c.Bag.Set("user", "john")
+		c.Next()
+	})
+	app.MountWithConfig("/v1", gateway, MountConfig{KeepPrefix: true})
+
+	tt := []struct {
+		name   string
+		auth   string
+		status int
+		body   string
+	}{
+		{"middleware runs around handler", "", http.StatusUnauthorized, "unauthorized"},
+		{"path is kept", "Bearer token", http.StatusOK, "/v1/users/1 john"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/users/1", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %q; got %d %q", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if _, ok := ContextFromRequest(httptest.NewRequest(http.MethodGet, "/", nil)); ok {
+		t.Errorf("expected request outside mounted handler not to have nano context")
+	}
+}
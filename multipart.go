@@ -0,0 +1,55 @@
+package nano
+
+import (
+	"errors"
+	"mime/multipart"
+)
+
+// DefaultMaxMultipartMemory is maximum bytes of multipart form kept in memory, the rest of uploaded files
+// are stored in temporary files.
+const DefaultMaxMultipartMemory = 32 << 20
+
+// SetMaxMultipartMemory functions to set maximum bytes of multipart form kept in memory while binding,
+// default is DefaultMaxMultipartMemory. Use BindMultipartFormWithMemory to override it per call.
+func (ng *Engine) SetMaxMultipartMemory(maxMemory int64) {
+	ng.maxMultipartMemory = maxMemory
+}
+
+// BindMultipartFormWithMemory functions to bind multipart form like BindMultipartForm,
+// keeping at most maxMemory bytes in memory instead of the engine setting.
+func (c *Context) BindMultipartFormWithMemory(targetStruct interface{}, maxMemory int64) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.bindMultipartFormWith(targetStruct, maxMemory)
+	})
+}
+
+// maxMultipartMemory returns maximum bytes of multipart form kept in memory.
+func (c *Context) maxMultipartMemory() int64 {
+	if c.engine == nil || c.engine.maxMultipartMemory <= 0 {
+		return DefaultMaxMultipartMemory
+	}
+
+	return c.engine.maxMultipartMemory
+}
+
+// parseMultipartForm parses multipart form once, temporary files of uploaded files are removed
+// after the request is served, so copy the file inside handler when it needs to be kept.
+func (c *Context) parseMultipartForm(maxMemory int64) error {
+	if c.Request.MultipartForm != nil {
+		return nil
+	}
+
+	err := c.Request.ParseMultipartForm(maxMemory)
+
+	if form := c.Request.MultipartForm; form != nil && form.File != nil {
+		c.AfterResponse(func() {
+			form.RemoveAll()
+		})
+	}
+
+	if errors.Is(err, multipart.ErrMessageTooLarge) {
+		return ErrPayloadTooLarge
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package nano
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+)
+
+// MultipartWriter writes parts of multipart response, e.g. batch api responding several json parts or files.
+// Each part is flushed to the client once it's written. Close must be called to write the closing boundary.
+type MultipartWriter struct {
+	c      *Context
+	writer *multipart.Writer
+}
+
+// Multipart functions to start multipart response, contentType is multipart mime e.g. MimeMultipartMixed,
+// default to MimeMultipartMixed when it's empty. The boundary is generated and appended to the content type.
+func (c *Context) Multipart(statusCode int, contentType string) *MultipartWriter {
+	if contentType == "" {
+		contentType = MimeMultipartMixed
+	}
+
+	writer := multipart.NewWriter(c.Writer)
+
+	c.SetContentType(mime.FormatMediaType(contentType, map[string]string{"boundary": writer.Boundary()}))
+	c.Status(statusCode)
+
+	return &MultipartWriter{c: c, writer: writer}
+}
+
+// Part functions to write raw part, the returned writer is valid until the next part is created or Close is called.
+func (m *MultipartWriter) Part(contentType string, headers map[string]string) (io.Writer, error) {
+	header := make(textproto.MIMEHeader)
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+
+	if contentType != "" {
+		header.Set(HeaderContentType, contentType)
+	}
+
+	m.flush()
+
+	return m.writer.CreatePart(header)
+}
+
+// JSON functions to write object as json part, encoded using json codec of the engine.
+func (m *MultipartWriter) JSON(object interface{}, headers map[string]string) error {
+	rs, err := m.c.jsonCodec().Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	part, err := m.Part(MimeJSON, headers)
+	if err != nil {
+		return err
+	}
+
+	_, err = part.Write(rs)
+	return err
+}
+
+// File functions to write file as attachment part, content type is detected from the file extension.
+func (m *MultipartWriter) File(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	name := filepath.Base(path)
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	part, err := m.Part(contentType, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": name}),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("could not write part %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close functions to write the closing boundary and flush the response.
+func (m *MultipartWriter) Close() error {
+	err := m.writer.Close()
+	m.flush()
+
+	return err
+}
+
+// flush sends written parts to the client.
+func (m *MultipartWriter) flush() {
+	if flusher, ok := m.c.Writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
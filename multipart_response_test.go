@@ -0,0 +1,84 @@
+package nano
+
+import (
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMultipartResponse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(path, []byte("report"), 0644); err != nil {
+		log.Fatalf("could not create file: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/batch", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	response := ctx.Multipart(http.StatusOK, "")
+	if err := response.JSON(H{"id": 1}, map[string]string{"Content-ID": "<user-1>"}); err != nil {
+		t.Fatalf("expected json part error to be nil; got %v", err)
+	}
+
+	if err := response.File(path); err != nil {
+		t.Fatalf("expected file part error to be nil; got %v", err)
+	}
+
+	if err := response.File(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("expected missing file part to fail")
+	}
+
+	if err := response.Close(); err != nil {
+		t.Fatalf("expected close error to be nil; got %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get(HeaderContentType))
+	if err != nil || mediaType != MimeMultipartMixed || params["boundary"] == "" {
+		t.Fatalf("expected content type to be multipart/mixed with boundary; got %s", rec.Header().Get(HeaderContentType))
+	}
+
+	tt := []struct {
+		contentType string
+		header      string
+		value       string
+		body        string
+	}{
+		{MimeJSON, "Content-ID", "<user-1>", `{"id":1}`},
+		{"text/plain; charset=utf-8", "Content-Disposition", `attachment; filename=report.txt`, "report"},
+	}
+
+	reader := multipart.NewReader(rec.Body, params["boundary"])
+	for _, tc := range tt {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("expected part to be read; got %v", err)
+		}
+
+		if contentType := part.Header.Get(HeaderContentType); contentType != tc.contentType {
+			t.Errorf("expected part content type to be %s; got %s", tc.contentType, contentType)
+		}
+
+		if value := part.Header.Get(tc.header); value != tc.value {
+			t.Errorf("expected part %s header to be %s; got %s", tc.header, tc.value, value)
+		}
+
+		if body, _ := io.ReadAll(part); string(body) != tc.body {
+			t.Errorf("expected part body to be %s; got %s", tc.body, body)
+		}
+	}
+
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("expected closing boundary after the last part; got %v", err)
+	}
+}
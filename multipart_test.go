@@ -0,0 +1,116 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMultipartMemory(t *testing.T) {
+	newUpload := func(content string) *http.Request {
+		body := new(bytes.Buffer)
+		form := multipart.NewWriter(body)
+		part, _ := form.CreateFormFile("document", "notes.txt")
+		part.Write([]byte(content))
+		form.Close()
+
+		req, err := http.NewRequest(http.MethodPost, "/upload", body)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, form.FormDataContentType())
+
+		return req
+	}
+
+	type Upload struct {
+		Document *multipart.FileHeader `file:"document"`
+	}
+
+	t.Run("temporary file is removed after request", func(st *testing.T) {
+		app := New()
+		app.SetMaxMultipartMemory(8)
+
+		var tempFile string
+		app.POST("/upload", func(c *Context) {
+			var upload Upload
+			if err := c.Bind(&upload); err != nil {
+				st.Fatalf("expected error to be nil; got %v", err)
+			}
+
+			file, err := upload.Document.Open()
+			if err != nil {
+				st.Fatalf("expected file to be opened; got %v", err)
+			}
+			defer file.Close()
+
+			stored, ok := file.(*os.File)
+			if !ok {
+				st.Fatalf("expected file to be stored in temporary file; got %T", file)
+			}
+
+			tempFile = stored.Name()
+			c.String(http.StatusOK, "ok")
+		})
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, newUpload("more than eight bytes"))
+
+		if rec.Code != http.StatusOK {
+			st.Fatalf("expected status code to be 200; got %d", rec.Code)
+		}
+
+		if _, err := os.Stat(tempFile); !os.IsNotExist(err) {
+			st.Errorf("expected temporary file %s to be removed; got %v", tempFile, err)
+		}
+	})
+
+	t.Run("file is kept in memory under the limit", func(st *testing.T) {
+		app := New()
+
+		app.POST("/upload", func(c *Context) {
+			var upload Upload
+			if err := c.BindMultipartFormWithMemory(&upload, 1<<10); err != nil {
+				st.Fatalf("expected error to be nil; got %v", err)
+			}
+
+			file, _ := upload.Document.Open()
+			defer file.Close()
+
+			if _, ok := file.(*os.File); ok {
+				st.Errorf("expected file to be kept in memory")
+			}
+		})
+
+		app.ServeHTTP(httptest.NewRecorder(), newUpload("small"))
+	})
+
+	t.Run("too large form", func(st *testing.T) {
+		body := new(bytes.Buffer)
+		form := multipart.NewWriter(body)
+		form.WriteField("note", strings.Repeat("a", 10<<20+1<<10))
+		form.Close()
+
+		req, err := http.NewRequest(http.MethodPost, "/", body)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, form.FormDataContentType())
+
+		ctx := newContext(httptest.NewRecorder(), req)
+
+		var note struct {
+			Note string `form:"note"`
+		}
+
+		err = ctx.BindMultipartFormWithMemory(&note, 1)
+		if errBinding, ok := err.(ErrBinding); !ok || errBinding.Status != http.StatusRequestEntityTooLarge {
+			st.Errorf("expected error to be 413 ErrBinding; got %v", err)
+		}
+	})
+}
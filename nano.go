@@ -5,18 +5,20 @@
 package nano
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
 	"net/http"
+	"reflect"
 	"strings"
-
-	jsontime "github.com/liamylian/jsontime/v2/v2"
+	"sync"
+	"time"
 )
 
-func init() {
-	jsontime.AddTimeFormatAlias("sql_date", "2006-01-02")
-	jsontime.AddTimeFormatAlias("sql_datetime", "2006-01-02 15:04:02")
-}
-
 const (
 	// HeaderAcceptEncoding is accept encoding.
 	HeaderAcceptEncoding = "Accept-Encoding"
@@ -30,6 +32,16 @@ const (
 	HeaderAccept = "Accept"
 	// HeaderOrigin is request origin.
 	HeaderOrigin = "Origin"
+	// HeaderUserAgent is client user agent.
+	HeaderUserAgent = "User-Agent"
+	// HeaderUpgrade is protocol upgrade request.
+	HeaderUpgrade = "Upgrade"
+	// HeaderConnection is connection control.
+	HeaderConnection = "Connection"
+	// HeaderXForwardedProto is protocol used by client to connect to proxy.
+	HeaderXForwardedProto = "X-Forwarded-Proto"
+	// HeaderCacheControl is caching directives.
+	HeaderCacheControl = "Cache-Control"
 	// HeaderVary is request vary.
 	HeaderVary = "Vary"
 	// HeaderAccessControlRequestMethod is cors request method.
@@ -42,23 +54,36 @@ const (
 	HeaderAccessControlAllowMethods = "Access-Control-Allow-Methods"
 	// HeaderAccessControlAllowHeader is cors allowed headers.
 	HeaderAccessControlAllowHeader = "Access-Control-Allow-Header"
+	// HeaderAllow is allowed methods of requested resource.
+	HeaderAllow = "Allow"
 
 	// MimeJSON is standard json mime.
 	MimeJSON = "application/json"
+	// MimeMergePatchJSON is RFC 7396 json merge patch mime.
+	MimeMergePatchJSON = "application/merge-patch+json"
+	// MimeJSONPatch is RFC 6902 json patch mime.
+	MimeJSONPatch = "application/json-patch+json"
 	// MimeXML is standard json mime.
 	MimeXML = "application/xml"
 	// MimeHTML is standard html mime.
 	MimeHTML = "text/html"
 	// MimePlainText is standard plain text mime.
 	MimePlainText = "text/plain"
+	// MimeMsgPack is msgpack mime.
+	MimeMsgPack = "application/x-msgpack"
+	// MimeProtoBuf is protocol buffers mime.
+	MimeProtoBuf = "application/x-protobuf"
 	// MimeMultipartForm is standard multipart form mime.
 	MimeMultipartForm = "multipart/form-data"
+	// MimeProblemJSON is RFC 7807 problem details mime.
+	MimeProblemJSON = "application/problem+json"
+	// MimeMultipartMixed is standard multipart mixed mime, used by batch response.
+	MimeMultipartMixed = "multipart/mixed"
 	// MimeFormURLEncoded is standard urlencoded form mime.
 	MimeFormURLEncoded = "application/x-www-form-urlencoded"
 )
 
 var (
-	json = jsontime.ConfigWithCustomTimeFormat
 	// ErrDefaultHandler should be returned when user try to set default handler for seconds time.
 	ErrDefaultHandler = errors.New("default handler already registered")
 )
@@ -66,9 +91,69 @@ var (
 // Engine defines nano web engine.
 type Engine struct {
 	*RouterGroup
-	router *router
-	debug  bool
-	groups []*RouterGroup
+	router        *router
+	debug         bool
+	groups        []*RouterGroup
+	strictBinding bool
+	adminPrefix   string
+	maintenance   int32 // accessed atomically.
+	codecs        map[string]Codec
+	server        *http.Server
+	warmups       []WarmupFunc
+	warmupConfig  WarmupConfig
+	ready         int32 // accessed atomically.
+	staticFiles   []*staticFiles
+	staticHooks   []func(changed []string)
+	listeners     map[EventType][]EventHandler
+	// slowRequestThreshold is minimum request duration to emit EventSlowRequest.
+	slowRequestThreshold time.Duration
+	// debugOutput is writer of debug output, nil means os.Stdout.
+	debugOutput io.Writer
+	// redirect & case options of the built-in router, see redirect.go.
+	redirectTrailingSlash  bool
+	redirectFixedPath      bool
+	caseInsensitiveRouting bool
+	// healthConfig is set by EnableHealthChecks.
+	healthConfig *HealthCheckConfig
+	shuttingDown int32 // accessed atomically.
+	// formConverters are registered by RegisterFormConverter.
+	formConverters map[reflect.Type]FormConverter
+	// maxMultipartMemory is set by SetMaxMultipartMemory.
+	maxMultipartMemory int64
+	// logger is base logger of Context.Logger, it's set by SetLogger.
+	logger *slog.Logger
+	// messages are translated messages keyed by normalized locale, they're registered by AddMessages.
+	messages map[string]map[string]string
+	// renderer is set by SetRenderer, LoadHTMLGlob, or LoadHTMLFS.
+	renderer Renderer
+	// templateFuncs are set by SetFuncMap.
+	templateFuncs map[string]interface{}
+	// validator validates bound struct, it's set by SetValidator.
+	validator Validator
+	// jsonCodec is set by SetJSONCodec, nil means the default codec.
+	jsonCodec JSONCodec
+	// timeFormats are time layout aliases added by AddTimeFormatAlias.
+	timeFormats map[string]string
+	// timeCodecs caches default json codecs keyed by default time layout.
+	timeCodecs sync.Map
+	// tasks are background tasks started by Go and Context.Defer.
+	tasks backgroundTasks
+	// scheduler runs jobs registered by Schedule.
+	scheduler scheduler
+	// reload holds hooks registered by OnConfigReload.
+	reload configReload
+	// errorHandler renders HTTPError, it's set by SetErrorHandler.
+	errorHandler func(c *Context, err *HTTPError)
+	// problemDetails is set by EnableProblemDetails.
+	problemDetails bool
+	// baseDomain is set by SetBaseDomain.
+	baseDomain string
+	// pre are middlewares called before routing, set by Pre.
+	pre []HandlerFunc
+	// basePath is set by SetBasePath.
+	basePath string
+	// container holds dependencies registered by Provide & ProvideScoped.
+	container container
 }
 
 // RouterGroup defines collection of route that has same prefix
@@ -77,6 +162,15 @@ type RouterGroup struct {
 	engine      *Engine
 	middlewares []HandlerFunc
 	parent      *RouterGroup
+	// after are trailing middlewares registered by UseAfter.
+	after []HandlerFunc
+	// timeoutFallback is set by OnTimeout.
+	timeoutFallback HandlerFunc
+	// noRoute & noMethod are set by NoRoute & NoMethod.
+	noRoute  HandlerFunc
+	noMethod HandlerFunc
+	// version is api version of group created by Version.
+	version string
 }
 
 // H defines json wrapper.
@@ -88,8 +182,11 @@ type HandlerFunc func(c *Context)
 // New is nano constructor
 func New() *Engine {
 	engine := &Engine{
-		router: newRouter(),
-		debug:  false,
+		router:             newRouter(),
+		debug:              false,
+		strictBinding:      true,
+		maxMultipartMemory: DefaultMaxMultipartMemory,
+		validator:          newDefaultValidator(),
 	}
 
 	engine.RouterGroup = &RouterGroup{engine: engine}
@@ -98,13 +195,42 @@ func New() *Engine {
 	return engine
 }
 
+// SetStrictBinding functions to toggle strict form binding.
+// When enabled (default), form value that could not be converted into its field type
+// (e.g. age=abc into int field) makes binding fail with 422 status code.
+// When disabled, the field silently set to its zero value.
+func (ng *Engine) SetStrictBinding(strict bool) {
+	ng.strictBinding = strict
+}
+
 // Use functions to apply middleware function(s).
 func (rg *RouterGroup) Use(middlewares ...HandlerFunc) {
+	rg.engine.router.mustNotBeFrozen("add middleware")
+
 	rg.middlewares = append(rg.middlewares, middlewares...)
 }
 
+// UseAfter functions to apply trailing middleware function(s), they're called in order after the handlers stack
+// completes, even when it's aborted or a middleware doesn't call Next, e.g. for audit logging and timing.
+// Response may already be written by then, use Context.BeforeWrite to change it. Calling Next inside them does nothing.
+func (rg *RouterGroup) UseAfter(middlewares ...HandlerFunc) {
+	rg.engine.router.mustNotBeFrozen("add trailing middleware")
+
+	rg.after = append(rg.after, middlewares...)
+}
+
+// Pre functions to apply middleware function(s) called before routing, e.g. Rewrite, so they could change
+// request path used to select router group middlewares and matching route. They're called for every request.
+func (ng *Engine) Pre(middlewares ...HandlerFunc) {
+	ng.router.mustNotBeFrozen("add pre-routing middleware")
+
+	ng.pre = append(ng.pre, middlewares...)
+}
+
 // Group functions to create new router group.
 func (rg *RouterGroup) Group(prefix string) *RouterGroup {
+	rg.engine.router.mustNotBeFrozen("create router group " + rg.prefix + prefix)
+
 	group := &RouterGroup{
 		prefix: rg.prefix + prefix,
 		parent: rg,
@@ -117,43 +243,73 @@ func (rg *RouterGroup) Group(prefix string) *RouterGroup {
 }
 
 // HEAD functions to register route with HEAD request method.
-func (rg *RouterGroup) HEAD(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodHead, urlPattern, handler...)
+func (rg *RouterGroup) HEAD(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodHead, urlPattern, handler...)
 }
 
 // GET functions to register route with GET request method.
-func (rg *RouterGroup) GET(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodGet, urlPattern, handler...)
+func (rg *RouterGroup) GET(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodGet, urlPattern, handler...)
 }
 
 // POST functions to register route with POST request method.
-func (rg *RouterGroup) POST(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodPost, urlPattern, handler...)
+func (rg *RouterGroup) POST(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodPost, urlPattern, handler...)
 }
 
 // PUT functions to register route with PUT request method.
-func (rg *RouterGroup) PUT(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodPut, urlPattern, handler...)
+func (rg *RouterGroup) PUT(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodPut, urlPattern, handler...)
 }
 
 // OPTIONS functions to register route with OPTIONS request method.
-func (rg *RouterGroup) OPTIONS(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodOptions, urlPattern, handler...)
+func (rg *RouterGroup) OPTIONS(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodOptions, urlPattern, handler...)
 }
 
 // PATCH functions to register route with PATCH request method.
-func (rg *RouterGroup) PATCH(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodPatch, urlPattern, handler...)
+func (rg *RouterGroup) PATCH(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodPatch, urlPattern, handler...)
 }
 
 // DELETE functions to register route with DELETE request method.
-func (rg *RouterGroup) DELETE(urlPattern string, handler ...HandlerFunc) {
-	rg.addRoute(http.MethodDelete, urlPattern, handler...)
+func (rg *RouterGroup) DELETE(urlPattern string, handler ...HandlerFunc) *Route {
+	return rg.addRoute(http.MethodDelete, urlPattern, handler...)
+}
+
+// anyMethods is request methods registered by Any.
+var anyMethods = []string{
+	http.MethodHead,
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodOptions,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// Any functions to register route with HEAD, GET, POST, PUT, OPTIONS, PATCH, and DELETE request method.
+func (rg *RouterGroup) Any(urlPattern string, handler ...HandlerFunc) RouteSet {
+	return rg.Match(anyMethods, urlPattern, handler...)
+}
+
+// Match functions to register route with given request methods.
+func (rg *RouterGroup) Match(requestMethods []string, urlPattern string, handler ...HandlerFunc) RouteSet {
+	routes := make(RouteSet, 0, len(requestMethods))
+	for _, requestMethod := range requestMethods {
+		routes = append(routes, rg.addRoute(strings.ToUpper(requestMethod), urlPattern, handler...))
+	}
+
+	return routes
 }
 
 // Default functions to register default handler when no matching routes.
 // Only one Default handler allowed to register.
 func (rg *RouterGroup) Default(handler HandlerFunc) error {
+	if err := rg.engine.router.checkFrozen("set default handler"); err != nil {
+		return err
+	}
+
 	// reject overriding.
 	if rg.engine.router.defaultHandler != nil {
 		return ErrDefaultHandler
@@ -163,43 +319,235 @@ func (rg *RouterGroup) Default(handler HandlerFunc) error {
 	return nil
 }
 
+// NoRoute functions to set handler of unmatched request under this group, e.g. json 404 for /api
+// and html error page for web. handler of the most specific group is used, Default handler otherwise.
+func (rg *RouterGroup) NoRoute(handler HandlerFunc) {
+	rg.engine.router.mustNotBeFrozen("set NoRoute handler")
+
+	rg.noRoute = handler
+}
+
+// NoMethod functions to set handler of request whose path is registered under this group using another method,
+// Allow header is set to the registered methods. Without NoMethod handler, such request is unmatched.
+func (rg *RouterGroup) NoMethod(handler HandlerFunc) {
+	rg.engine.router.mustNotBeFrozen("set NoMethod handler")
+
+	rg.noMethod = handler
+}
+
 // Static creates static file server.
 func (rg *RouterGroup) Static(baseURL string, rootDir http.FileSystem) {
-	if strings.Contains(baseURL, ":") || strings.Contains(baseURL, "*") {
+	if strings.ContainsAny(baseURL, ":*{") {
 		panic("cannot use dynamic url parameter in file server base url")
 	}
 
 	urlPattern := baseURL + "/*filepath"
-	handler := fileServerHandler(rg.prefix, baseURL, rootDir)
+	files := newStaticFiles(rootDir)
+	rg.engine.staticFiles = append(rg.engine.staticFiles, files)
+
+	handler := fileServerHandler(files)
 	rg.GET(urlPattern, handler)
 	rg.HEAD(urlPattern, handler)
 }
 
+// StaticFS creates static file server of file system, e.g. embed.FS.
+// dir selects sub directory of the file system as root directory, use "." or "" to serve the whole file system.
+func (rg *RouterGroup) StaticFS(baseURL string, fsys fs.FS, dir string) {
+	if dir != "" && dir != "." {
+		sub, err := fs.Sub(fsys, dir)
+		if err != nil {
+			panic(err)
+		}
+
+		fsys = sub
+	}
+
+	rg.Static(baseURL, http.FS(fsys))
+}
+
 // addRoute functions to register new route with current group prefix.
-func (rg *RouterGroup) addRoute(requestMethod, urlPattern string, handler ...HandlerFunc) {
+func (rg *RouterGroup) addRoute(requestMethod, urlPattern string, handler ...HandlerFunc) *Route {
 	// append router group prefix.
 	prefixedURLPattern := rg.prefix + urlPattern
 
-	rg.engine.router.addRoute(requestMethod, prefixedURLPattern, handler...)
+	return rg.engine.router.register(RouteInfo{Method: requestMethod, Path: prefixedURLPattern, Group: rg.prefix}, handler...)
+}
+
+// AddRoute functions to register route with current group prefix like GET, POST, etc.
+// but it returns ErrRouteConflict instead of panic when the route conflicts with registered route.
+func (rg *RouterGroup) AddRoute(requestMethod, urlPattern string, handler ...HandlerFunc) (*Route, error) {
+	return rg.engine.router.tryRegister(RouteInfo{Method: requestMethod, Path: rg.prefix + urlPattern, Group: rg.prefix}, handler...)
+}
+
+// Routes returns registered routes ordered by registration.
+func (ng *Engine) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(ng.router.routes))
+	for _, route := range ng.router.routes {
+		routes = append(routes, route.info)
+	}
+
+	return routes
+}
+
+// SetRouter functions to replace the built-in route matching with custom router.
+// routes registered before and after SetRouter are added to the custom router.
+// Engine keeps route metadata, so Routes, URL, and Route.Use keep working. pass nil to restore the built-in router.
+// It must be called before the engine starts serving requests.
+func (ng *Engine) SetRouter(resolver Router) {
+	ng.router.mustNotBeFrozen("set router")
+
+	ng.router.resolver = resolver
+	if resolver == nil {
+		return
+	}
+
+	for _, route := range ng.router.routes {
+		resolver.Add(route.info.Method, route.info.Path, ng.router.handlers[route.key])
+	}
+}
+
+// SetDebug functions to toggle debug mode.
+// In debug mode, registered routes and route warnings are printed when the application starts,
+// each request is traced, and Recovery middleware responds with the panic stack trace.
+func (ng *Engine) SetDebug(debug bool) {
+	ng.debug = debug
+}
+
+// printRoutes prints routing table to w.
+func (ng *Engine) printRoutes(w io.Writer) {
+	for _, route := range ng.Routes() {
+		fmt.Fprintf(w, "[nano] %-7s %-30s --> %s\n", route.Method, route.Path, route.HandlerName)
+	}
 }
 
 // ServeHTTP implements multiplexer.
 func (ng *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	middlewares := make([]HandlerFunc, 0)
+	// request outside base path is served by default route handler.
+	inside := true
+	if ng.basePath != "" {
+		r, inside = ng.stripBasePath(r)
+	}
+
+	// only admin api and health checks are served in maintenance mode.
+	if ng.IsMaintenance() && !ng.isAdminPath(r.URL.Path) && !ng.isHealthCheck(r.URL.Path) {
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	// routes are read without locking from now on.
+	if !ng.IsFrozen() {
+		ng.Freeze()
+	}
+
+	ctx := newContext(w, r)
+	ctx.engine = ng
+	defer ctx.runAfterResponse()
+
+	if ng.hasListener(EventHandlerPanicked) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				ctx.emit(EventHandlerPanicked, 0, recovered)
+				panic(recovered)
+			}
+		}()
+	}
+
+	switch {
+	case !inside:
+		ng.router.serveDefaultHandler(ctx)
+	case len(ng.pre) > 0:
+		ctx.handlers = append(append(ctx.handlers, ng.pre...), ng.route)
+		ctx.Next()
+	default:
+		ng.route(ctx)
+	}
+
+	if ng.slowRequestThreshold > 0 && time.Since(ctx.startedAt) > ng.slowRequestThreshold {
+		ctx.emit(EventSlowRequest, ctx.response.status, nil)
+	}
+
+	if ng.debug {
+		ng.traceRequest(ctx, ctx.response.status)
+	}
+}
+
+// route collects middlewares of router groups matching request path, and serves the request using router.
+func (ng *Engine) route(c *Context) {
+	var after []HandlerFunc
 
 	// scanning for router group middleware.
 	for _, group := range ng.groups {
-		if strings.HasPrefix(r.URL.Path, group.prefix) {
-			middlewares = append(middlewares, group.middlewares...)
+		if strings.HasPrefix(c.Request.URL.Path, group.prefix) {
+			c.handlers = append(c.handlers, group.middlewares...)
+			after = append(after, group.after...)
 		}
 	}
 
-	ctx := newContext(w, r)
-	ctx.handlers = middlewares
-	ng.router.handle(ctx)
+	ng.router.handle(c)
+	c.runAfter(after)
 }
 
 // Run application.
+// Warm-up tasks are executed in background while the server is listening,
+// so readiness check could tell load balancer to wait until the engine is ready.
+// Scheduled jobs are started as well, and SIGHUP signal triggers ReloadConfig.
 func (ng *Engine) Run(address string) error {
-	return http.ListenAndServe(address, ng)
+	ng.server = &http.Server{Addr: address, Handler: ng}
+
+	return ng.serve(ng.server.ListenAndServe)
+}
+
+// RunTLS runs application like Run using https. The certificate is reloaded from certFile and keyFile by ReloadConfig,
+// so renewed certificate is served without restarting.
+func (ng *Engine) RunTLS(address, certFile, keyFile string) error {
+	certs, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	ng.OnConfigReload(certs.Reload)
+	ng.server = &http.Server{
+		Addr:      address,
+		Handler:   ng,
+		TLSConfig: &tls.Config{GetCertificate: certs.GetCertificate},
+	}
+
+	return ng.serve(func() error {
+		return ng.server.ListenAndServeTLS("", "")
+	})
+}
+
+// serve calls listen of ng.server after starting debug watcher, scheduled jobs, warm-up tasks, and reload signal listener.
+func (ng *Engine) serve(listen func() error) error {
+	if ng.debug {
+		ng.printRoutes(ng.debugWriter())
+		ng.printWarnings(ng.debugWriter())
+
+		// watch static directories for changes while the server is running.
+		stopWatch := make(chan struct{})
+		defer close(stopWatch)
+		go ng.watchStatic(staticWatchInterval, stopWatch)
+	}
+
+	ng.StartScheduler()
+
+	stopReload := ng.watchReloadSignal()
+	defer stopReload()
+
+	warmupErr := make(chan error, 1)
+	go func() {
+		if err := ng.RunWarmup(context.Background()); err != nil {
+			warmupErr <- err
+			ng.server.Close()
+		}
+	}()
+
+	err := listen()
+
+	select {
+	case err := <-warmupErr:
+		return err
+	default:
+		return err
+	}
 }
@@ -1,9 +1,13 @@
 package nano
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +23,71 @@ func TestUseMiddleware(t *testing.T) {
 	}
 }
 
+func TestUseAfter(t *testing.T) {
+	var calls []string
+	record := func(name string) HandlerFunc {
+		return func(c *Context) {
+			calls = append(calls, name)
+			c.Next()
+		}
+	}
+
+	app := New()
+	app.Use(record("middleware"))
+	app.UseAfter(func(c *Context) {
+		calls = append(calls, fmt.Sprintf("audit %d aborted=%t", c.response.status, c.IsAborted()))
+		c.Next()
+	})
+
+	api := app.Group("/api")
+	api.Use(func(c *Context) {
+		if c.GetRequestHeader("X-Token") == "" {
+			c.String(http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		c.Next()
+	})
+	api.UseAfter(record("api timing"))
+	api.GET("/users", func(c *Context) {
+		calls = append(calls, "handler")
+		c.String(http.StatusOK, "users")
+	})
+	api.GET("/abort", func(c *Context) {
+		c.AbortWithError(NewHTTPError(http.StatusConflict, ""))
+	})
+
+	tt := []struct {
+		name  string
+		url   string
+		token string
+		calls []string
+	}{
+		{"completed stack", "/api/users", "secret", []string{"middleware", "handler", "audit 200 aborted=false", "api timing"}},
+		{"middleware without next", "/api/users", "", []string{"middleware", "audit 401 aborted=false", "api timing"}},
+		{"aborted stack", "/api/abort", "secret", []string{"middleware", "audit 409 aborted=true", "api timing"}},
+		{"outside group", "/", "", []string{"middleware", "audit 404 aborted=false"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			calls = nil
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set("X-Token", tc.token)
+
+			app.ServeHTTP(httptest.NewRecorder(), req)
+
+			if strings.Join(calls, ", ") != strings.Join(tc.calls, ", ") {
+				st.Errorf("expected calls to be %v; got %v", tc.calls, calls)
+			}
+		})
+	}
+}
+
 func TestGroup(t *testing.T) {
 	app := New()
 
@@ -47,6 +116,54 @@ func TestRouteRegistration(t *testing.T) {
 	}
 }
 
+func TestAnyAndMatch(t *testing.T) {
+	app := New()
+
+	emptyHandler := func(c *Context) {}
+	app.Any("/webhook", emptyHandler)
+	app.Match([]string{http.MethodGet, "post"}, "/callback", emptyHandler)
+
+	if hlen := len(app.router.handlers); hlen != 9 {
+		t.Errorf("expected num of registered routes to be 9; got %d", hlen)
+	}
+
+	if _, exists := app.router.handlers["POST-/callback"]; !exists {
+		t.Errorf("expected request method of Match to be upper cased")
+	}
+}
+
+func listProducts(c *Context) {}
+
+func TestRoutes(t *testing.T) {
+	app := New()
+
+	emptyHandler := func(c *Context) {}
+	app.GET("/", emptyHandler)
+
+	api := app.Group("/api")
+	api.GET("/products", emptyHandler, listProducts)
+
+	if _, err := api.AddRoute(http.MethodGet, "/products", listProducts); !errors.Is(err, ErrRouteConflict) {
+		t.Errorf("expected registering route twice to return ErrRouteConflict; got %v", err)
+	}
+
+	routes := app.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("expected num of routes to be 2; got %d", len(routes))
+	}
+
+	expected := RouteInfo{Method: http.MethodGet, Path: "/api/products", HandlerName: "github.com/hariadivicky/nano.listProducts", Group: "/api"}
+	if routes[1] != expected {
+		t.Errorf("expected route to be %+v; got %+v", expected, routes[1])
+	}
+
+	var out bytes.Buffer
+	app.printRoutes(&out)
+	if !strings.Contains(out.String(), "/api/products") {
+		t.Errorf("expected routing table to contain /api/products; got %s", out.String())
+	}
+}
+
 func TestDefaultHandler(t *testing.T) {
 	app := New()
 
@@ -75,6 +192,74 @@ func TestDefaultHandler(t *testing.T) {
 	})
 }
 
+func TestGroupFallback(t *testing.T) {
+	app := New()
+	app.Default(func(c *Context) {
+		c.String(http.StatusNotFound, "default")
+	})
+
+	api := app.Group("/api")
+	api.NoRoute(func(c *Context) {
+		c.JSON(http.StatusNotFound, H{"error": "not found"})
+	})
+	api.NoMethod(func(c *Context) {
+		c.JSON(http.StatusMethodNotAllowed, H{"error": "method not allowed"})
+	})
+	api.GET("/users", func(c *Context) {})
+	api.POST("/users", func(c *Context) {})
+
+	v2 := api.Group("/v2")
+	v2.NoRoute(func(c *Context) {
+		c.String(http.StatusNotFound, "v2")
+	})
+
+	web := app.Group("/web")
+	web.Use(func(c *Context) {
+		// stops the chain, fallback must not be called.
+		if c.Query("blocked") != "" {
+			c.String(http.StatusForbidden, "blocked")
+			return
+		}
+
+		c.Next()
+	})
+
+	tt := []struct {
+		name   string
+		method string
+		url    string
+		status int
+		body   string
+		allow  string
+	}{
+		{"group no route", http.MethodGet, "/api/posts", http.StatusNotFound, `{"error":"not found"}`, ""},
+		{"group no method", http.MethodDelete, "/api/users", http.StatusMethodNotAllowed, `{"error":"method not allowed"}`, "GET, POST"},
+		{"most specific group", http.MethodGet, "/api/v2/posts", http.StatusNotFound, "v2", ""},
+		{"default handler", http.MethodGet, "/posts", http.StatusNotFound, "default", ""},
+		{"middleware stops chain", http.MethodGet, "/web/posts?blocked=1", http.StatusForbidden, "blocked", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+
+			if allow := rec.Header().Get(HeaderAllow); allow != tc.allow {
+				st.Errorf("expected allow header to be %s; got %s", tc.allow, allow)
+			}
+		})
+	}
+}
+
 func TestServeHTTP(t *testing.T) {
 	app := New()
 	app.GET("/", func(c *Context) {
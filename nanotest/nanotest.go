@@ -0,0 +1,220 @@
+// Package nanotest provides fluent http client to test nano handlers without running server.
+//
+//	client := nanotest.New(app)
+//	client.GET("/users/1").WithHeader("Authorization", "Bearer token").Expect(t).Status(http.StatusOK).JSON(&user)
+package nanotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Client sends requests to handler, e.g. nano engine.
+type Client struct {
+	handler http.Handler
+	header  http.Header
+}
+
+// New creates client of the handler.
+func New(handler http.Handler) *Client {
+	return &Client{handler: handler, header: make(http.Header)}
+}
+
+// WithHeader functions to set header sent by every request of the client, e.g. authorization token.
+func (c *Client) WithHeader(key, value string) *Client {
+	c.header.Set(key, value)
+	return c
+}
+
+// Request creates request of given method & path, path may contain query string.
+func (c *Client) Request(method, path string) *Request {
+	return &Request{client: c, method: method, path: path, header: c.header.Clone(), query: make(url.Values)}
+}
+
+// GET creates GET request.
+func (c *Client) GET(path string) *Request {
+	return c.Request(http.MethodGet, path)
+}
+
+// POST creates POST request.
+func (c *Client) POST(path string) *Request {
+	return c.Request(http.MethodPost, path)
+}
+
+// PUT creates PUT request.
+func (c *Client) PUT(path string) *Request {
+	return c.Request(http.MethodPut, path)
+}
+
+// PATCH creates PATCH request.
+func (c *Client) PATCH(path string) *Request {
+	return c.Request(http.MethodPatch, path)
+}
+
+// DELETE creates DELETE request.
+func (c *Client) DELETE(path string) *Request {
+	return c.Request(http.MethodDelete, path)
+}
+
+// HEAD creates HEAD request.
+func (c *Client) HEAD(path string) *Request {
+	return c.Request(http.MethodHead, path)
+}
+
+// OPTIONS creates OPTIONS request.
+func (c *Client) OPTIONS(path string) *Request {
+	return c.Request(http.MethodOptions, path)
+}
+
+// Request is pending request, it's sent by Do or Expect.
+type Request struct {
+	client *Client
+	method string
+	path   string
+	header http.Header
+	query  url.Values
+	body   []byte
+	// err is encoding error of the body, it's reported by Expect.
+	err error
+}
+
+// WithHeader functions to set request header.
+func (r *Request) WithHeader(key, value string) *Request {
+	r.header.Set(key, value)
+	return r
+}
+
+// WithQuery functions to add url query value.
+func (r *Request) WithQuery(key, value string) *Request {
+	r.query.Add(key, value)
+	return r
+}
+
+// WithBody functions to set raw request body and its content type.
+func (r *Request) WithBody(contentType string, body []byte) *Request {
+	r.header.Set("Content-Type", contentType)
+	r.body = body
+	return r
+}
+
+// WithJSON functions to set request body encoded as json.
+func (r *Request) WithJSON(body interface{}) *Request {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		r.err = err
+	}
+
+	return r.WithBody("application/json", encoded)
+}
+
+// WithForm functions to set request body encoded as url encoded form.
+func (r *Request) WithForm(form url.Values) *Request {
+	return r.WithBody("application/x-www-form-urlencoded", []byte(form.Encode()))
+}
+
+// Do sends the request and returns recorded response.
+func (r *Request) Do() *httptest.ResponseRecorder {
+	target := r.path
+	if len(r.query) > 0 {
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+
+		target += separator + r.query.Encode()
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+
+	req := httptest.NewRequest(r.method, target, body)
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+
+	rec := httptest.NewRecorder()
+	r.client.handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// Expect sends the request and returns response assertion reporting failures to t.
+func (r *Request) Expect(t testing.TB) *Response {
+	t.Helper()
+
+	if r.err != nil {
+		t.Fatalf("could not encode request body of %s %s: %v", r.method, r.path, r.err)
+	}
+
+	return &Response{t: t, Recorder: r.Do(), name: r.method + " " + r.path}
+}
+
+// Response asserts recorded response, failed assertion is reported using t.Errorf.
+type Response struct {
+	t        testing.TB
+	Recorder *httptest.ResponseRecorder
+	name     string
+}
+
+// Status asserts response status code.
+func (r *Response) Status(code int) *Response {
+	r.t.Helper()
+
+	if r.Recorder.Code != code {
+		r.t.Errorf("expected status code of %s to be %d; got %d", r.name, code, r.Recorder.Code)
+	}
+
+	return r
+}
+
+// Header asserts response header value.
+func (r *Response) Header(key, value string) *Response {
+	r.t.Helper()
+
+	if actual := r.Recorder.Header().Get(key); actual != value {
+		r.t.Errorf("expected %s header of %s to be %q; got %q", key, r.name, value, actual)
+	}
+
+	return r
+}
+
+// Body asserts response body.
+func (r *Response) Body(body string) *Response {
+	r.t.Helper()
+
+	if actual := r.Recorder.Body.String(); actual != body {
+		r.t.Errorf("expected body of %s to be %s; got %s", r.name, body, actual)
+	}
+
+	return r
+}
+
+// BodyContains asserts response body contains the text.
+func (r *Response) BodyContains(text string) *Response {
+	r.t.Helper()
+
+	if actual := r.Recorder.Body.String(); !strings.Contains(actual, text) {
+		r.t.Errorf("expected body of %s to contain %s; got %s", r.name, text, actual)
+	}
+
+	return r
+}
+
+// JSON decodes json response body into out.
+func (r *Response) JSON(out interface{}) *Response {
+	r.t.Helper()
+
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), out); err != nil {
+		r.t.Errorf("could not decode json body of %s: %v", r.name, err)
+	}
+
+	return r
+}
@@ -0,0 +1,85 @@
+package nanotest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hariadivicky/nano"
+)
+
+// recorderT records failures instead of failing the test.
+type recorderT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorderT) Helper() {}
+
+func (r *recorderT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func newApp() *nano.Engine {
+	app := nano.New()
+	app.POST("/users", func(c *nano.Context) {
+		var user struct {
+			Name string `json:"name" form:"name"`
+		}
+
+		if err := c.Bind(&user); err != nil {
+			c.String(http.StatusBadRequest, "bad request")
+			return
+		}
+
+		c.SetHeader("X-Tenant", c.GetRequestHeader("X-Tenant"))
+		c.JSON(http.StatusCreated, nano.H{"name": user.Name, "page": c.Query("page"), "token": c.GetRequestHeader("Authorization")})
+	})
+
+	return app
+}
+
+func TestClient(t *testing.T) {
+	client := New(newApp()).WithHeader("Authorization", "secret")
+
+	var out map[string]string
+	client.POST("/users?page=1").
+		WithHeader("X-Tenant", "acme").
+		WithQuery("sort", "asc").
+		WithJSON(map[string]string{"name": "john"}).
+		Expect(t).
+		Status(http.StatusCreated).
+		Header("X-Tenant", "acme").
+		BodyContains(`"name":"john"`).
+		JSON(&out)
+
+	expected := map[string]string{"name": "john", "page": "1", "token": "secret"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("expected json body to be %v; got %v", expected, out)
+	}
+
+	client.POST("/users").WithForm(url.Values{"name": {"jane"}}).Expect(t).Status(http.StatusCreated).BodyContains("jane")
+}
+
+func TestResponseFailures(t *testing.T) {
+	rt := &recorderT{TB: t}
+
+	New(newApp()).GET("/users").Expect(rt).
+		Status(http.StatusOK).
+		Header("X-Tenant", "acme").
+		Body("ok").
+		BodyContains("users")
+
+	expected := []string{
+		"expected status code of GET /users to be 200; got 404",
+		`expected X-Tenant header of GET /users to be "acme"; got ""`,
+		"expected body of GET /users to be ok; got nano/1.0 not found",
+		"expected body of GET /users to contain users; got nano/1.0 not found",
+	}
+
+	if !reflect.DeepEqual(rt.failures, expected) {
+		t.Errorf("expected failures to be %v; got %v", expected, rt.failures)
+	}
+}
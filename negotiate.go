@@ -0,0 +1,143 @@
+package nano
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptRange defines single media range in Accept header.
+type acceptRange struct {
+	mime string
+	q    float64
+}
+
+// parseAccept parses Accept header value into media ranges, sorted by their quality.
+func parseAccept(header string) []acceptRange {
+	ranges := make([]acceptRange, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		params := strings.Split(part, ";")
+		accept := acceptRange{mime: strings.ToLower(strings.TrimSpace(params[0])), q: 1}
+
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+				accept.q = q
+			}
+		}
+
+		ranges = append(ranges, accept)
+	}
+
+	// keep header ordering for the same quality.
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	return ranges
+}
+
+// matchSpecificity returns how specific media range matches the mime, -1 when it doesn't match.
+func matchSpecificity(mediaRange, mime string) int {
+	switch {
+	case mediaRange == mime:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mime, mediaRange[:len(mediaRange)-1]):
+		return 1
+	}
+
+	return -1
+}
+
+// NegotiateFormat returns the best offered mime type based on request Accept header (with q-values).
+// The first offered type is returned when client doesn't send Accept header,
+// and empty string is returned when none of offered types is acceptable.
+func (c *Context) NegotiateFormat(offered ...string) string {
+	if len(offered) == 0 {
+		offered = c.produces
+	}
+
+	if len(offered) == 0 {
+		return ""
+	}
+
+	header := c.GetRequestHeader(HeaderAccept)
+	if header == "" {
+		return offered[0]
+	}
+
+	ranges := parseAccept(header)
+	best := ""
+	bestQ := 0.0
+
+	for _, offer := range offered {
+		mime := strings.ToLower(offer)
+		// quality of offer is taken from the most specific matching range.
+		specificity := -1
+		q := 0.0
+
+		for _, accept := range ranges {
+			if s := matchSpecificity(accept.mime, mime); s > specificity {
+				specificity = s
+				q = accept.q
+			}
+		}
+
+		if q > bestQ {
+			best = offer
+			bestQ = q
+		}
+	}
+
+	return best
+}
+
+// Negotiate writes data as response in the best format accepted by client.
+// Supported formats are MimeJSON, MimeXML, MimeHTML, MimePlainText, and content types of registered codecs.
+// When offers is empty, content types set by Route.Produces are offered,
+// otherwise MimeJSON, MimeXML, MimeHTML, and MimePlainText are offered.
+// HTML format writes data as escaped text.
+// It writes 406 response when client doesn't accept any offered format.
+func (c *Context) Negotiate(statusCode int, data interface{}, offers ...string) {
+	if len(offers) == 0 {
+		offers = c.produces
+	}
+
+	if len(offers) == 0 {
+		offers = []string{MimeJSON, MimeXML, MimeHTML, MimePlainText}
+	}
+
+	format := c.NegotiateFormat(offers...)
+	switch format {
+	case MimeJSON:
+		c.JSON(statusCode, data)
+	case MimeXML:
+		c.XML(statusCode, data)
+	case MimeHTML:
+		c.HTML(statusCode, html.EscapeString(fmt.Sprint(data)))
+	case MimePlainText:
+		c.String(statusCode, "%v", data)
+	default:
+		if format != "" && c.codecOf(format) != nil {
+			c.Encode(statusCode, format, data)
+			return
+		}
+
+		c.String(http.StatusNotAcceptable, "not acceptable")
+	}
+}
@@ -0,0 +1,91 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tt := []struct {
+		name    string
+		accept  string
+		offered []string
+		result  string
+	}{
+		{"without accept header", "", []string{MimeJSON, MimeXML}, MimeJSON},
+		{"exact match", "application/xml", []string{MimeJSON, MimeXML}, MimeXML},
+		{"quality ordering", "application/json;q=0.5, application/xml", []string{MimeJSON, MimeXML}, MimeXML},
+		{"wildcard subtype", "text/*", []string{MimeJSON, MimeHTML}, MimeHTML},
+		{"specific range wins over wildcard", "*/*;q=0.8, application/json;q=0.1", []string{MimeJSON, MimeXML}, MimeXML},
+		{"rejected by zero quality", "application/json;q=0", []string{MimeJSON}, ""},
+		{"not acceptable", "image/png", []string{MimeJSON, MimeXML}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderAccept, tc.accept)
+			ctx := newContext(httptest.NewRecorder(), req)
+
+			if format := ctx.NegotiateFormat(tc.offered...); format != tc.result {
+				st.Errorf("expected negotiated format to be %q; got %q", tc.result, format)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tt := []struct {
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"application/json", http.StatusOK, MimeJSON},
+		{"text/html", http.StatusOK, MimeHTML},
+		{"text/plain", http.StatusOK, MimePlainText},
+		{"application/xml", http.StatusOK, MimeXML},
+		{"image/png", http.StatusNotAcceptable, MimePlainText},
+	}
+
+	for _, tc := range tt {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderAccept, tc.accept)
+		rec := httptest.NewRecorder()
+		ctx := newContext(rec, req)
+
+		ctx.Negotiate(http.StatusOK, "hello")
+
+		if rec.Code != tc.status {
+			t.Errorf("expected status code for %s to be %d; got %d", tc.accept, tc.status, rec.Code)
+		}
+
+		if contentType := rec.Header().Get(HeaderContentType); contentType != tc.contentType {
+			t.Errorf("expected content type for %s to be %s; got %s", tc.accept, tc.contentType, contentType)
+		}
+	}
+}
+
+func TestNegotiateEscapesHTML(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderAccept, MimeHTML)
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	ctx.Negotiate(http.StatusOK, "<script>alert(1)</script>")
+
+	expected := "&lt;script&gt;alert(1)&lt;/script&gt;"
+	if body := rec.Body.String(); body != expected {
+		t.Errorf("expected html body to be %q; got %q", expected, body)
+	}
+}
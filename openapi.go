@@ -0,0 +1,342 @@
+package nano
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// OpenAPIDocument is OpenAPI 3 document, only the parts used by nano are described.
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components,omitempty"`
+}
+
+// OpenAPIInfo describes the api.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// OpenAPIPathItem describes operations of single path, e.g. /users/{id}.
+type OpenAPIPathItem struct {
+	Parameters []OpenAPIParameter `json:"parameters,omitempty"`
+	Get        *OpenAPIOperation  `json:"get,omitempty"`
+	Put        *OpenAPIOperation  `json:"put,omitempty"`
+	Post       *OpenAPIOperation  `json:"post,omitempty"`
+	Delete     *OpenAPIOperation  `json:"delete,omitempty"`
+	Options    *OpenAPIOperation  `json:"options,omitempty"`
+	Head       *OpenAPIOperation  `json:"head,omitempty"`
+	Patch      *OpenAPIOperation  `json:"patch,omitempty"`
+}
+
+// OpenAPIOperation describes single api operation.
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter describes path, query, or header parameter.
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody describes request body.
+type OpenAPIRequestBody struct {
+	Description string                      `json:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes response of single status code.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType describes body schema of a content type.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIComponents holds reusable schemas, referenced by #/components/schemas/{name}.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty"`
+}
+
+// OpenAPISchema describes value, only the keywords checked by nano are described.
+type OpenAPISchema struct {
+	Ref        string                    `json:"$ref,omitempty"`
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Nullable   bool                      `json:"nullable,omitempty"`
+	Enum       []interface{}             `json:"enum,omitempty"`
+	Minimum    *float64                  `json:"minimum,omitempty"`
+	Maximum    *float64                  `json:"maximum,omitempty"`
+	MinLength  *int                      `json:"minLength,omitempty"`
+	MaxLength  *int                      `json:"maxLength,omitempty"`
+	Pattern    string                    `json:"pattern,omitempty"`
+	MinItems   *int                      `json:"minItems,omitempty"`
+	MaxItems   *int                      `json:"maxItems,omitempty"`
+	Items      *OpenAPISchema            `json:"items,omitempty"`
+	Required   []string                  `json:"required,omitempty"`
+	Properties map[string]*OpenAPISchema `json:"properties,omitempty"`
+	// AdditionalProperties is only checked when it's false, that is unknown object properties are rejected.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+}
+
+// openAPIPatterns caches compiled schema patterns.
+var openAPIPatterns sync.Map
+
+// ReadOpenAPI functions to read OpenAPI 3 document stored as json, YAML document should be converted into json first.
+func ReadOpenAPI(r io.Reader) (*OpenAPIDocument, error) {
+	var doc OpenAPIDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// operation returns operation of request method, it returns nil when the method isn't described.
+func (item OpenAPIPathItem) operation(requestMethod string) *OpenAPIOperation {
+	switch strings.ToUpper(requestMethod) {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	case "PATCH":
+		return item.Patch
+	}
+
+	return nil
+}
+
+// match returns path template & its item matching url path, along with path parameter values.
+// template having more static segments takes precedence, e.g. /users/me over /users/{id}.
+func (doc *OpenAPIDocument) match(urlPath string) (string, OpenAPIPathItem, map[string]string, bool) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	best, bestStatic := "", -1
+	var bestParams map[string]string
+
+	for template := range doc.Paths {
+		templateParts := strings.Split(strings.Trim(template, "/"), "/")
+		if len(templateParts) != len(parts) {
+			continue
+		}
+
+		static, params := 0, make(map[string]string)
+		for i, part := range templateParts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") && parts[i] != "" {
+				params[part[1:len(part)-1]] = parts[i]
+				continue
+			}
+
+			if part != parts[i] {
+				static = -1
+				break
+			}
+
+			static++
+		}
+
+		// templates are compared by name on tie, so the match doesn't depend on map order.
+		if static > bestStatic || (static == bestStatic && static >= 0 && template < best) {
+			best, bestStatic, bestParams = template, static, params
+		}
+	}
+
+	if bestStatic < 0 {
+		return "", OpenAPIPathItem{}, nil, false
+	}
+
+	return best, doc.Paths[best], bestParams, true
+}
+
+// resolve follows schema reference, it returns nil when the reference is unknown.
+func (doc *OpenAPIDocument) resolve(schema *OpenAPISchema) *OpenAPISchema {
+	for schema != nil && schema.Ref != "" {
+		schema = doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+
+	return schema
+}
+
+// validateSchema returns problems of value against schema, name is location of the value, e.g. body.items[0].name.
+func (doc *OpenAPIDocument) validateSchema(schema *OpenAPISchema, value interface{}, name string) []string {
+	schema = doc.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+
+		return []string{name + " must not be null"}
+	}
+
+	problems := make([]string, 0)
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{name + " must be object"}
+		}
+
+		for _, field := range schema.Required {
+			if _, exists := object[field]; !exists {
+				problems = append(problems, name+"."+field+" is required")
+			}
+		}
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			property, exists := schema.Properties[key]
+			if !exists {
+				if additional, ok := schema.AdditionalProperties.(bool); ok && !additional {
+					problems = append(problems, name+"."+key+" is not allowed")
+				}
+
+				continue
+			}
+
+			problems = append(problems, doc.validateSchema(property, object[key], name+"."+key)...)
+		}
+
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{name + " must be array"}
+		}
+
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			problems = append(problems, fmt.Sprintf("%s must have at least %d items", name, *schema.MinItems))
+		}
+
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			problems = append(problems, fmt.Sprintf("%s must have at most %d items", name, *schema.MaxItems))
+		}
+
+		for i, item := range items {
+			problems = append(problems, doc.validateSchema(schema.Items, item, fmt.Sprintf("%s[%d]", name, i))...)
+		}
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []string{name + " must be string"}
+		}
+
+		problems = append(problems, validateString(schema, text, name)...)
+
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok || (schema.Type == "integer" && number != math.Trunc(number)) {
+			return []string{name + " must be " + schema.Type}
+		}
+
+		if schema.Minimum != nil && number < *schema.Minimum {
+			problems = append(problems, fmt.Sprintf("%s must be at least %v", name, *schema.Minimum))
+		}
+
+		if schema.Maximum != nil && number > *schema.Maximum {
+			problems = append(problems, fmt.Sprintf("%s must be at most %v", name, *schema.Maximum))
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{name + " must be boolean"}
+		}
+	}
+
+	if len(schema.Enum) > 0 && !openAPIEnumAllowed(schema.Enum, value) {
+		problems = append(problems, fmt.Sprintf("%s must be one of %v", name, schema.Enum))
+	}
+
+	return problems
+}
+
+// validateString returns problems of string value against length, pattern, and format keywords.
+func validateString(schema *OpenAPISchema, text, name string) []string {
+	problems := make([]string, 0)
+	length := utf8.RuneCountInString(text)
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		problems = append(problems, fmt.Sprintf("%s must be at least %d characters", name, *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		problems = append(problems, fmt.Sprintf("%s must be at most %d characters", name, *schema.MaxLength))
+	}
+
+	if schema.Pattern != "" {
+		pattern, ok := openAPIPatterns.Load(schema.Pattern)
+		if !ok {
+			compiled, err := regexp.Compile(schema.Pattern)
+			if err != nil {
+				return append(problems, fmt.Sprintf("%s has invalid pattern %s", name, schema.Pattern))
+			}
+
+			pattern, _ = openAPIPatterns.LoadOrStore(schema.Pattern, compiled)
+		}
+
+		if !pattern.(*regexp.Regexp).MatchString(text) {
+			problems = append(problems, fmt.Sprintf("%s must match %s", name, schema.Pattern))
+		}
+	}
+
+	layouts := map[string]string{"date": "2006-01-02", "date-time": time.RFC3339}
+	if layout, ok := layouts[schema.Format]; ok {
+		if _, err := time.Parse(layout, text); err != nil {
+			problems = append(problems, fmt.Sprintf("%s must be %s", name, schema.Format))
+		}
+	}
+
+	return problems
+}
+
+// openAPIEnumAllowed returns true when value is one of enum values.
+func openAPIEnumAllowed(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,335 @@
+package nano
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GenerateOpenAPI functions to describe registered routes as OpenAPI 3 document, using description given by
+// Route.Doc as summary, description, tags, and deprecation of the operation. payload types given by
+// Route.WithRequest & Route.WithResponse are described as schemas. request fields having query, header,
+// or uri tag are described as parameters, other fields are query parameters of GET, HEAD, DELETE, and OPTIONS
+// routes, or json request body otherwise.
+func (ng *Engine) GenerateOpenAPI(info OpenAPIInfo) *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI:    "3.0.3",
+		Info:       info,
+		Paths:      make(map[string]OpenAPIPathItem),
+		Components: OpenAPIComponents{Schemas: make(map[string]*OpenAPISchema)},
+	}
+
+	for _, route := range ng.router.routes {
+		template, pathParams := openAPIPath(route.info.Path)
+
+		operation := &OpenAPIOperation{
+			OperationID: route.info.Name,
+			Responses:   map[string]OpenAPIResponse{"200": {Description: http.StatusText(http.StatusOK)}},
+		}
+
+		if doc := route.info.Doc; doc != nil {
+			operation.Summary = doc.Summary
+			operation.Description = doc.Description
+			operation.Tags = doc.Tags
+			operation.Deprecated = doc.Deprecated
+		}
+
+		for _, name := range pathParams {
+			operation.Parameters = append(operation.Parameters, OpenAPIParameter{Name: name, In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"}})
+		}
+
+		if route.request != nil {
+			doc.describeRequest(operation, route.info.Method, route.request)
+		}
+
+		if route.response != nil {
+			operation.Responses["200"] = OpenAPIResponse{
+				Description: http.StatusText(http.StatusOK),
+				Content:     map[string]OpenAPIMediaType{MimeJSON: {Schema: doc.schemaOf(route.response)}},
+			}
+		}
+
+		item := doc.Paths[template]
+		if !item.setOperation(route.info.Method, operation) {
+			continue
+		}
+
+		doc.Paths[template] = item
+	}
+
+	return doc
+}
+
+// ServeOpenAPI functions to serve OpenAPI document of registered routes as json at given path.
+// the document is generated on first request, so routes registered later are included.
+// YAML isn't supported, json document is valid YAML though, so YAML tooling could read it as well.
+// Document that could not be marshaled is responded with 500 status code and the error is logged.
+func (rg *RouterGroup) ServeOpenAPI(urlPath string, info OpenAPIInfo) *Route {
+	var once sync.Once
+	var document []byte
+	var err error
+
+	return rg.GET(urlPath, func(c *Context) {
+		once.Do(func() {
+			document, err = c.jsonCodec().Marshal(rg.engine.GenerateOpenAPI(info))
+		})
+
+		if err != nil {
+			c.Logger().Error("could not marshal openapi document", slog.Any("error", err))
+			c.String(http.StatusInternalServerError, "could not generate openapi document")
+			return
+		}
+
+		c.SetHeader(HeaderContentType, MimeJSON)
+		c.Data(http.StatusOK, document)
+	})
+}
+
+// ServeSwaggerUI functions to serve Swagger UI page at given path, documenting OpenAPI document at spec url,
+// e.g. the path given to ServeOpenAPI. Swagger UI assets are loaded from unpkg.com.
+func (rg *RouterGroup) ServeSwaggerUI(urlPath, specURL string) *Route {
+	page := fmt.Sprintf(swaggerUIPage, strconv.Quote(specURL))
+
+	return rg.GET(urlPath, func(c *Context) {
+		c.HTML(http.StatusOK, page)
+	})
+}
+
+// swaggerUIPage is Swagger UI html, %s is quoted spec url.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>API Documentation</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>window.ui = SwaggerUIBundle({url: %s, dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// setOperation sets operation of request method, it returns false when the method isn't supported by OpenAPI.
+func (item *OpenAPIPathItem) setOperation(requestMethod string, operation *OpenAPIOperation) bool {
+	switch requestMethod {
+	case http.MethodGet:
+		item.Get = operation
+	case http.MethodPut:
+		item.Put = operation
+	case http.MethodPost:
+		item.Post = operation
+	case http.MethodDelete:
+		item.Delete = operation
+	case http.MethodOptions:
+		item.Options = operation
+	case http.MethodHead:
+		item.Head = operation
+	case http.MethodPatch:
+		item.Patch = operation
+	default:
+		return false
+	}
+
+	return true
+}
+
+// openAPIPath converts url pattern into OpenAPI path template, e.g. /users/:id(\d+) into /users/{id}.
+// it returns the parameter names too.
+func openAPIPath(urlPattern string) (string, []string) {
+	parts := createURLParts(urlPattern)
+	params := make([]string, 0)
+
+	for i, part := range parts {
+		if param, ok, _ := parseParam(part); ok {
+			parts[i] = "{" + param.name + "}"
+			params = append(params, param.name)
+		}
+	}
+
+	return "/" + strings.Join(parts, "/"), params
+}
+
+// describeRequest describes request payload as parameters & request body of the operation.
+func (doc *OpenAPIDocument) describeRequest(operation *OpenAPIOperation, requestMethod string, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	hasBody := requestMethod != http.MethodGet && requestMethod != http.MethodHead &&
+		requestMethod != http.MethodDelete && requestMethod != http.MethodOptions
+
+	if !isSchemaStruct(t) {
+		if hasBody {
+			operation.RequestBody = jsonRequestBody(doc.schemaOf(t))
+		}
+
+		return
+	}
+
+	body := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+
+	for _, field := range structFields(t) {
+		parameter := OpenAPIParameter{Required: hasValidationRule(field.Tag.Get("validate"), "required"), Schema: doc.fieldSchema(field)}
+
+		for _, source := range []struct{ tag, in string }{{"query", "query"}, {"header", "header"}, {"uri", "path"}} {
+			if name := strings.SplitN(field.Tag.Get(source.tag), ",", 2)[0]; name != "" && name != "-" {
+				parameter.Name, parameter.In = name, source.in
+				break
+			}
+		}
+
+		switch {
+		case parameter.In == "path":
+			replaceParameter(operation, parameter)
+		case parameter.In != "":
+			operation.Parameters = append(operation.Parameters, parameter)
+		case !hasBody:
+			parameter.Name, parameter.In = enumFieldName(field), "query"
+			operation.Parameters = append(operation.Parameters, parameter)
+		default:
+			name := schemaFieldName(field)
+			if name == "" {
+				continue
+			}
+
+			body.Properties[name] = parameter.Schema
+			if parameter.Required {
+				body.Required = append(body.Required, name)
+			}
+		}
+	}
+
+	if hasBody && len(body.Properties) > 0 {
+		operation.RequestBody = jsonRequestBody(body)
+	}
+}
+
+// replaceParameter replaces path parameter of the operation having the same name.
+func replaceParameter(operation *OpenAPIOperation, parameter OpenAPIParameter) {
+	parameter.Required = true
+
+	for i, existing := range operation.Parameters {
+		if existing.In == parameter.In && existing.Name == parameter.Name {
+			operation.Parameters[i] = parameter
+			return
+		}
+	}
+}
+
+// jsonRequestBody returns required json request body of the schema.
+func jsonRequestBody(schema *OpenAPISchema) *OpenAPIRequestBody {
+	return &OpenAPIRequestBody{Required: true, Content: map[string]OpenAPIMediaType{MimeJSON: {Schema: schema}}}
+}
+
+// structFields returns exported fields of struct, untagged embedded struct is flattened like encoding/json does.
+func structFields(t reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" && isSchemaStruct(elemOf(field.Type)) {
+			fields = append(fields, structFields(elemOf(field.Type))...)
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// fieldSchema returns schema of struct field, enum tag is included.
+func (doc *OpenAPIDocument) fieldSchema(field reflect.StructField) *OpenAPISchema {
+	schema := doc.schemaOf(field.Type)
+
+	values := enumValues(field)
+	if len(values) == 0 {
+		return schema
+	}
+
+	// schema of named struct is a reference shared by other fields, so it's copied before it's changed.
+	enumSchema := *schema
+	for _, value := range values {
+		if number, err := strconv.ParseFloat(value, 64); err == nil && (schema.Type == "integer" || schema.Type == "number") {
+			enumSchema.Enum = append(enumSchema.Enum, number)
+			continue
+		}
+
+		enumSchema.Enum = append(enumSchema.Enum, value)
+	}
+
+	return &enumSchema
+}
+
+// schemaOf returns schema of go type. named struct is registered as component and referenced.
+func (doc *OpenAPIDocument) schemaOf(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		format := "int64"
+		if t.Bits() <= 32 {
+			format = "int32"
+		}
+
+		return &OpenAPISchema{Type: "integer", Format: format}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &OpenAPISchema{Type: "string", Format: "byte"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &OpenAPISchema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case isSchemaStruct(t) && t.Name() != "":
+		ref := &OpenAPISchema{Ref: "#/components/schemas/" + t.Name()}
+		if _, exists := doc.Components.Schemas[t.Name()]; !exists {
+			// registered before fields are described, so self-referencing type terminates.
+			doc.Components.Schemas[t.Name()] = &OpenAPISchema{}
+			*doc.Components.Schemas[t.Name()] = *doc.structSchema(t)
+		}
+
+		return ref
+	case isSchemaStruct(t):
+		return doc.structSchema(t)
+	}
+
+	return &OpenAPISchema{}
+}
+
+// structSchema returns object schema of struct fields.
+func (doc *OpenAPIDocument) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+
+	for _, field := range structFields(t) {
+		name := schemaFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		schema.Properties[name] = doc.fieldSchema(field)
+		if hasValidationRule(field.Tag.Get("validate"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,170 @@
+package nano
+
+import (
+	"errors"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateOpenAPI(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	type User struct {
+		ID        int64     `json:"id"`
+		Name      string    `json:"name"`
+		Role      string    `json:"role" enum:"admin|member"`
+		Address   *Address  `json:"address"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	type CreateUserRequest struct {
+		Name    string  `json:"name" validate:"required"`
+		Role    string  `json:"role" enum:"admin|member"`
+		Address Address `json:"address"`
+		Tenant  string  `header:"X-Tenant" validate:"required"`
+	}
+
+	type ListUsersRequest struct {
+		Limit int    `form:"limit" enum:"10|50"`
+		Sort  string `query:"sort"`
+	}
+
+	type UserRequest struct {
+		ID int64 `uri:"id"`
+	}
+
+	app := New()
+	app.GET("/users", func(c *Context) {}).WithRequest(ListUsersRequest{}).WithResponse([]User{}).Name("users.index")
+	app.POST("/users", func(c *Context) {}).WithRequest(CreateUserRequest{}).WithResponse(User{})
+	app.GET("/users/:id", func(c *Context) {}).WithRequest(UserRequest{}).WithResponse(User{})
+	app.GET("/files/*path", func(c *Context) {})
+
+	doc := app.GenerateOpenAPI(OpenAPIInfo{Title: "users", Version: "1.0.0"})
+
+	index := doc.Paths["/users"].Get
+	if index == nil || index.OperationID != "users.index" {
+		t.Fatalf("expected GET /users operation named users.index; got %+v", index)
+	}
+
+	expectedParams := []OpenAPIParameter{
+		{Name: "limit", In: "query", Schema: &OpenAPISchema{Type: "integer", Format: "int64", Enum: []interface{}{float64(10), float64(50)}}},
+		{Name: "sort", In: "query", Schema: &OpenAPISchema{Type: "string"}},
+	}
+	if !reflect.DeepEqual(index.Parameters, expectedParams) {
+		t.Errorf("expected query parameters to be %+v; got %+v", expectedParams, index.Parameters)
+	}
+
+	create := doc.Paths["/users"].Post
+	body := create.RequestBody.Content[MimeJSON].Schema
+	if !reflect.DeepEqual(body.Required, []string{"name"}) || body.Properties["address"].Ref != "#/components/schemas/Address" {
+		t.Errorf("expected request body with required name and address reference; got %+v", body)
+	}
+
+	if len(create.Parameters) != 1 || create.Parameters[0].In != "header" || !create.Parameters[0].Required {
+		t.Errorf("expected required header parameter; got %+v", create.Parameters)
+	}
+
+	show := doc.Paths["/users/{id}"].Get
+	if len(show.Parameters) != 1 || show.Parameters[0].Schema.Type != "integer" {
+		t.Errorf("expected typed path parameter; got %+v", show.Parameters)
+	}
+
+	if files := doc.Paths["/files/{path}"].Get; files == nil || files.Parameters[0].Name != "path" {
+		t.Errorf("expected catch-all parameter to be described as path parameter; got %+v", files)
+	}
+
+	user := doc.Components.Schemas["User"]
+	if user.Properties["created_at"].Format != "date-time" || !reflect.DeepEqual(user.Properties["role"].Enum, []interface{}{"admin", "member"}) {
+		t.Errorf("expected user schema with date-time and enum; got %+v", user)
+	}
+
+	// generated document could be used by the validator.
+	app.Use(ValidateOpenAPI(doc))
+
+	req, err := http.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"role":"guest"}`))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeJSON)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	expected := `{"error":"request does not match api specification","fields":["header.X-Tenant is required","body.name is required","body.role must be one of [admin member]"]}`
+	if rec.Code != http.StatusBadRequest || rec.Body.String() != expected {
+		t.Errorf("expected response to be 400 %s; got %d %s", expected, rec.Code, rec.Body.String())
+	}
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	app := New()
+	app.ServeOpenAPI("/openapi.json", OpenAPIInfo{Title: "users", Version: "1.0.0"})
+	app.ServeSwaggerUI("/docs", "/openapi.json")
+	app.GET("/users", func(c *Context) {})
+
+	req, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	doc, err := ReadOpenAPI(rec.Body)
+	if err != nil {
+		t.Fatalf("could not read served document: %v", err)
+	}
+
+	if doc.Info.Title != "users" || doc.Paths["/users"].Get == nil {
+		t.Errorf("expected served document to describe routes registered later; got %+v", doc)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "/docs", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), `url: "/openapi.json"`) {
+		t.Errorf("expected swagger ui page to load /openapi.json; got %s", rec.Body.String())
+	}
+}
+
+// failingJSONCodec is encoding/json codec which could not marshal anything.
+type failingJSONCodec struct {
+	countingJSONCodec
+}
+
+func (failingJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return nil, errors.New("marshal failed")
+}
+
+func TestServeOpenAPIMarshalError(t *testing.T) {
+	app := New()
+	app.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	app.SetJSONCodec(&failingJSONCodec{})
+	app.ServeOpenAPI("/openapi.json", OpenAPIInfo{Title: "users", Version: "1.0.0"})
+
+	req, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status code to be %d; got %d %s", http.StatusInternalServerError, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,284 @@
+package nano
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// OpenAPIValidatorConfig defines OpenAPI request validation middleware configuration.
+type OpenAPIValidatorConfig struct {
+	// Document is the api specification, it's required.
+	Document *OpenAPIDocument
+	// BasePath is removed from request path before it's matched with document paths, e.g. /api/v1.
+	BasePath string
+	// ValidateResponses checks json responses in debug mode, mismatch is printed to debug output as warning.
+	ValidateResponses bool
+	// ErrorHandler responds invalid request, default responds 400 json with error and fields.
+	ErrorHandler func(c *Context, err ErrBinding)
+}
+
+// ValidateOpenAPI returns middleware validating requests against OpenAPI document.
+func ValidateOpenAPI(doc *OpenAPIDocument) HandlerFunc {
+	return ValidateOpenAPIWithConfig(OpenAPIValidatorConfig{Document: doc})
+}
+
+// ValidateOpenAPIWithConfig returns middleware validating path, query, header parameters and json body
+// of requests against OpenAPI document before handlers run. request whose path or method isn't described
+// in the document is passed through.
+func ValidateOpenAPIWithConfig(config OpenAPIValidatorConfig) HandlerFunc {
+	if config.Document == nil {
+		panic("openapi validator requires document")
+	}
+
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = func(c *Context, err ErrBinding) {
+			c.JSON(err.Status, H{"error": err.Text, "fields": err.Fields})
+		}
+	}
+
+	doc := config.Document
+
+	return func(c *Context) {
+		urlPath := strings.TrimPrefix(c.Path, config.BasePath)
+
+		template, item, pathParams, ok := doc.match(urlPath)
+		if !ok || item.operation(c.Method) == nil {
+			c.Next()
+			return
+		}
+
+		operation := item.operation(c.Method)
+
+		parameters := make([]OpenAPIParameter, 0, len(item.Parameters)+len(operation.Parameters))
+		parameters = append(append(parameters, item.Parameters...), operation.Parameters...)
+
+		bodyProblems, err := doc.validateRequestBody(c, operation.RequestBody)
+		if err != nil {
+			errBinding := c.errPayload(err)
+			errBinding.Fields = []string{}
+			config.ErrorHandler(c, errBinding)
+			return
+		}
+
+		problems := doc.validateParameters(c, parameters, pathParams)
+		problems = append(problems, bodyProblems...)
+
+		if len(problems) > 0 {
+			config.ErrorHandler(c, ErrBinding{
+				Status: http.StatusBadRequest,
+				Text:   "request does not match api specification",
+				Fields: problems,
+			})
+			return
+		}
+
+		if !config.ValidateResponses || c.engine == nil || !c.engine.debug {
+			c.Next()
+			return
+		}
+
+		recorder := &teeWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		for _, problem := range doc.validateResponse(operation, recorder) {
+			fmt.Fprintf(c.engine.debugWriter(), "[nano] [WARNING] %s %s response does not match api specification: %s\n", c.Method, template, problem)
+		}
+	}
+}
+
+// validateParameters returns problems of request parameters. operation parameter overrides path item parameter
+// having the same name & location.
+func (doc *OpenAPIDocument) validateParameters(c *Context, parameters []OpenAPIParameter, pathParams map[string]string) []string {
+	merged := make(map[string]OpenAPIParameter, len(parameters))
+	order := make([]string, 0, len(parameters))
+
+	for _, parameter := range parameters {
+		key := parameter.In + "." + parameter.Name
+		if _, exists := merged[key]; !exists {
+			order = append(order, key)
+		}
+
+		merged[key] = parameter
+	}
+
+	problems := make([]string, 0)
+	query := c.Request.URL.Query()
+
+	for _, key := range order {
+		parameter := merged[key]
+
+		var values []string
+		switch parameter.In {
+		case "path":
+			if value, ok := pathParams[parameter.Name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[parameter.Name]
+		case "header":
+			values = c.Request.Header.Values(parameter.Name)
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if parameter.Required || parameter.In == "path" {
+				problems = append(problems, key+" is required")
+			}
+
+			continue
+		}
+
+		problems = append(problems, doc.validateSchema(parameter.Schema, doc.coerceParam(parameter.Schema, values), key)...)
+	}
+
+	return problems
+}
+
+// coerceParam converts parameter values into type of the schema, value that could not be converted
+// is kept as string so the schema validation reports it.
+func (doc *OpenAPIDocument) coerceParam(schema *OpenAPISchema, values []string) interface{} {
+	schema = doc.resolve(schema)
+	if schema == nil {
+		return values[0]
+	}
+
+	switch schema.Type {
+	case "array":
+		// comma separated value is accepted as well as repeated parameter.
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		items := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			items = append(items, doc.coerceParam(schema.Items, []string{value}))
+		}
+
+		return items
+
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(values[0], 64); err == nil {
+			return number
+		}
+
+	case "boolean":
+		if boolean, err := strconv.ParseBool(values[0]); err == nil {
+			return boolean
+		}
+	}
+
+	return values[0]
+}
+
+// validateRequestBody returns problems of json request body, the body is restored for handlers.
+// it returns error when body exceeds the limit or is sent too slow, the request must be rejected then.
+func (doc *OpenAPIDocument) validateRequestBody(c *Context, requestBody *OpenAPIRequestBody) ([]string, error) {
+	if requestBody == nil || c.Request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := readBody(c.Request.Body, bufferLimit(c.payloadLimit))
+	c.Request.Body.Close()
+
+	// truncated body must not reach the handlers.
+	if err != nil && (c.isPayloadTooLarge(err) || c.isBodyTooSlow(err)) {
+		return nil, err
+	}
+
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return []string{"body could not be read"}, nil
+	}
+
+	if len(body) == 0 {
+		if requestBody.Required {
+			return []string{"body is required"}, nil
+		}
+
+		return nil, nil
+	}
+
+	contentType := mediaType(c.GetRequestHeader(HeaderContentType))
+	media, ok := requestBody.Content[contentType]
+	if !ok {
+		media, ok = requestBody.Content["*/*"]
+	}
+
+	if !ok {
+		return []string{fmt.Sprintf("body content type %s is not supported", contentType)}, nil
+	}
+
+	return doc.validateJSON(media.Schema, contentType, body, "body"), nil
+}
+
+// validateResponse returns problems of recorded json response.
+func (doc *OpenAPIDocument) validateResponse(operation *OpenAPIOperation, recorder *teeWriter) []string {
+	response, ok := operation.Responses[strconv.Itoa(recorder.status)]
+	if !ok {
+		response, ok = operation.Responses[fmt.Sprintf("%dXX", recorder.status/100)]
+	}
+
+	if !ok {
+		response, ok = operation.Responses["default"]
+	}
+
+	if !ok {
+		return []string{fmt.Sprintf("status code %d is not described", recorder.status)}
+	}
+
+	contentType := mediaType(recorder.Header().Get(HeaderContentType))
+	media, ok := response.Content[contentType]
+	if !ok {
+		return nil
+	}
+
+	return doc.validateJSON(media.Schema, contentType, recorder.body.Bytes(), "response")
+}
+
+// validateJSON returns problems of json document against schema, other content types are skipped.
+func (doc *OpenAPIDocument) validateJSON(schema *OpenAPISchema, contentType string, body []byte, name string) []string {
+	if schema == nil || (contentType != MimeJSON && !strings.HasSuffix(contentType, "+json")) {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{name + " must be valid json"}
+	}
+
+	return doc.validateSchema(schema, value, name)
+}
+
+// teeWriter copies response body into buffer while it's written to the client.
+type teeWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records status code.
+func (w *teeWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write copies data into buffer.
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher.
+func (w *teeWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,181 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testOpenAPIDocument = `{
+	"openapi": "3.0.3",
+	"info": {"title": "users", "version": "1.0.0"},
+	"paths": {
+		"/users": {
+			"get": {
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+					{"name": "sort", "in": "query", "schema": {"type": "string", "enum": ["asc", "desc"]}},
+					{"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+				],
+				"responses": {"200": {"description": "ok"}}
+			},
+			"post": {
+				"requestBody": {
+					"required": true,
+					"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}
+				},
+				"responses": {
+					"201": {"description": "created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+				}
+			}
+		},
+		"/users/{id}": {
+			"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+			"get": {"responses": {"200": {"description": "ok"}}}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["name", "email"],
+				"additionalProperties": false,
+				"properties": {
+					"name": {"type": "string", "minLength": 3},
+					"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"},
+					"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+				}
+			}
+		}
+	}
+}`
+
+func TestValidateOpenAPI(t *testing.T) {
+	doc, err := ReadOpenAPI(strings.NewReader(testOpenAPIDocument))
+	if err != nil {
+		t.Fatalf("could not read openapi document: %v", err)
+	}
+
+	tt := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		tenant string
+		status int
+		fields []string
+	}{
+		{"valid query", http.MethodGet, "/users?limit=10&sort=asc", "", "acme", http.StatusOK, nil},
+		{"invalid query", http.MethodGet, "/users?limit=500&sort=up", "", "", http.StatusBadRequest, []string{
+			"query.limit must be at most 100",
+			"query.sort must be one of [asc desc]",
+			"header.X-Tenant is required",
+		}},
+		{"not a number", http.MethodGet, "/users?limit=ten", "", "acme", http.StatusBadRequest, []string{"query.limit must be integer"}},
+		{"path parameter", http.MethodGet, "/users/abc", "", "", http.StatusBadRequest, []string{"path.id must be integer"}},
+		{"valid body", http.MethodPost, "/users", `{"name":"john","email":"john@example.com"}`, "", http.StatusCreated, nil},
+		{"invalid body", http.MethodPost, "/users", `{"name":"jo","tags":["a","b","c"],"age":20}`, "", http.StatusBadRequest, []string{
+			"body.email is required",
+			"body.age is not allowed",
+			"body.name must be at least 3 characters",
+			"body.tags must have at most 2 items",
+		}},
+		{"missing body", http.MethodPost, "/users", "", "", http.StatusBadRequest, []string{"body is required"}},
+		{"body too large", http.MethodPost, "/users", `{"name":"` + strings.Repeat("a", 64) + `"}`, "", http.StatusRequestEntityTooLarge, []string{}},
+		{"undescribed path", http.MethodGet, "/health", "", "", http.StatusOK, nil},
+	}
+
+	defaultMaxBufferedBody := DefaultMaxBufferedBody
+	DefaultMaxBufferedBody = 64
+	defer func() { DefaultMaxBufferedBody = defaultMaxBufferedBody }()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			var fields []string
+
+			app := New()
+			app.Use(ValidateOpenAPIWithConfig(OpenAPIValidatorConfig{
+				Document: doc,
+				ErrorHandler: func(c *Context, err ErrBinding) {
+					fields = err.Fields
+					c.JSON(err.Status, H{"error": err.Text})
+				},
+			}))
+			app.GET("/users", func(c *Context) {})
+			app.GET("/users/:id", func(c *Context) {})
+			app.GET("/health", func(c *Context) {})
+			app.POST("/users", func(c *Context) {
+				var user struct {
+					Name string `json:"name"`
+				}
+
+				// body must be restored for the handler.
+				if err := c.BindJSON(&user); err != nil || user.Name == "" {
+					c.String(http.StatusInternalServerError, "body is not restored")
+					return
+				}
+
+				c.String(http.StatusCreated, "created")
+			})
+
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+			if tc.tenant != "" {
+				req.Header.Set("X-Tenant", tc.tenant)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d %s", tc.status, rec.Code, rec.Body.String())
+			}
+
+			if !reflect.DeepEqual(fields, tc.fields) {
+				st.Errorf("expected error fields to be %v; got %v", tc.fields, fields)
+			}
+		})
+	}
+}
+
+func TestValidateOpenAPIResponse(t *testing.T) {
+	doc, err := ReadOpenAPI(strings.NewReader(testOpenAPIDocument))
+	if err != nil {
+		t.Fatalf("could not read openapi document: %v", err)
+	}
+
+	var out bytes.Buffer
+
+	app := New()
+	app.SetDebug(true)
+	app.SetDebugOutput(&out)
+	app.Use(ValidateOpenAPIWithConfig(OpenAPIValidatorConfig{Document: doc, ValidateResponses: true}))
+	app.POST("/users", func(c *Context) {
+		c.JSON(http.StatusCreated, H{"name": "john"})
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"john","email":"john@example.com"}`))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeJSON)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected response to be served as is; got %d", rec.Code)
+	}
+
+	warning := "[nano] [WARNING] POST /users response does not match api specification: response.email is required"
+	if !strings.Contains(out.String(), warning) {
+		t.Errorf("expected debug output to contain %s; got %s", warning, out.String())
+	}
+}
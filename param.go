@@ -0,0 +1,88 @@
+package nano
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrUnsafePath should be returned when route parameter could not be used as file system path.
+var ErrUnsafePath = errors.New("unsafe path")
+
+// UseRawPath functions to keep route parameters percent-encoded as sent by client, e.g. a%2Fb instead of a/b.
+// route parameters are decoded by default. either way, encoded slash doesn't separate path segments,
+// so /users/a%2Fb matches /users/:id.
+func (ng *Engine) UseRawPath(raw bool) {
+	ng.router.rawPath = raw
+}
+
+// ParamDecoder decodes raw route parameter value.
+type ParamDecoder func(value string) (string, error)
+
+// UnescapeParam decodes percent-encoded route parameter, e.g. a%2Fb became a/b.
+// route parameters are already decoded unless Engine.UseRawPath is enabled.
+func UnescapeParam(value string) (string, error) {
+	return url.PathUnescape(value)
+}
+
+// SafePathParam cleans route parameter into relative file system path that can't escape its root directory,
+// e.g. docs/../../etc/passwd became etc/passwd. value containing null byte is rejected.
+func SafePathParam(value string) (string, error) {
+	if strings.ContainsRune(value, 0) {
+		return "", ErrUnsafePath
+	}
+
+	return strings.TrimPrefix(path.Clean("/"+value), "/"), nil
+}
+
+// ChainParamDecoders combines decoders, they're applied in the given order.
+func ChainParamDecoders(decoders ...ParamDecoder) ParamDecoder {
+	return func(value string) (string, error) {
+		var err error
+		for _, decoder := range decoders {
+			if value, err = decoder(value); err != nil {
+				return "", err
+			}
+		}
+
+		return value, nil
+	}
+}
+
+// decodeParams returns handler decoding route parameters of the request.
+func decodeParams(decoders map[string]ParamDecoder) HandlerFunc {
+	return func(c *Context) {
+		for name, decoder := range decoders {
+			value, exists := c.Params[name]
+			if !exists {
+				continue
+			}
+
+			decoded, err := decoder(value)
+			if err != nil {
+				c.String(http.StatusBadRequest, "invalid route parameter %s", name)
+				return
+			}
+
+			c.Params[name] = decoded
+		}
+
+		c.Next()
+	}
+}
+
+// ParamSegments returns route parameter as path segments, e.g. *path of /files/a/b/c became [a b c].
+// empty segments are skipped.
+func (c *Context) ParamSegments(key string) []string {
+	segments := make([]string, 0)
+
+	for _, segment := range strings.Split(c.Param(key), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
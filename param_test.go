@@ -0,0 +1,94 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeParam(t *testing.T) {
+	app := New()
+
+	app.GET("/files/*filepath", func(c *Context) {
+		c.String(http.StatusOK, "%s %v", c.Param("filepath"), c.ParamSegments("filepath"))
+	}).DecodeParam("filepath", ChainParamDecoders(UnescapeParam, SafePathParam))
+
+	testCases := []struct {
+		name string
+		url  string
+		code int
+		body string
+	}{
+		{"escaped", "/files/docs/cv%20final.pdf", http.StatusOK, "docs/cv final.pdf [docs cv final.pdf]"},
+		{"traversal", "/files/docs/../../../etc/passwd", http.StatusOK, "etc/passwd [etc passwd]"},
+		{"null byte", "/files/a%00b", http.StatusBadRequest, "invalid route parameter filepath"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			// set decoded path directly, so the dot segments are kept.
+			req.URL.Path = tc.url
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.code || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.code, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestParamSegments(t *testing.T) {
+	ctx := &Context{Params: map[string]string{"path": "/a//b/c/"}}
+
+	if segments := ctx.ParamSegments("path"); !reflect.DeepEqual(segments, []string{"a", "b", "c"}) {
+		t.Errorf("expected segments to be [a b c]; got %v", segments)
+	}
+}
+
+func TestParamUnescape(t *testing.T) {
+	tt := []struct {
+		name string
+		raw  bool
+		url  string
+		body string
+	}{
+		{"space", false, "/users/john%20doe", "john doe"},
+		{"plus is literal", false, "/users/a+b", "a+b"},
+		{"encoded slash", false, "/users/a%2Fb", "a/b"},
+		{"catch-all encoded slash", false, "/files/docs/a%2Fb.txt", "docs/a/b.txt"},
+		{"raw encoded slash", true, "/users/a%2Fb", "a%2Fb"},
+		{"raw catch-all", true, "/files/docs/cv%20final.pdf", "docs/cv%20final.pdf"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.UseRawPath(tc.raw)
+			app.GET("/users/:id", func(c *Context) {
+				c.String(http.StatusOK, "%s", c.Param("id"))
+			})
+			app.GET("/files/*filepath", func(c *Context) {
+				c.String(http.StatusOK, "%s", c.Param("filepath"))
+			})
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be 200 %s; got %d %s", tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,460 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	stdjson "encoding/json"
+)
+
+// JSONPatchOperation defines single RFC 6902 operation.
+type JSONPatchOperation struct {
+	Op    string             `json:"op"`
+	Path  string             `json:"path"`
+	From  string             `json:"from,omitempty"`
+	Value stdjson.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch defines RFC 6902 json patch document.
+type JSONPatch []JSONPatchOperation
+
+// BindMergePatch applies RFC 7396 json merge patch from request body onto existing,
+// and stores the result into targetStruct. existing is never modified, so you could
+// pass the same pointer for both when you don't need the original value (e.g. for audit trails).
+// targetStruct must be pointer to user defined struct, and it's validated after the patch applied.
+func (c *Context) BindMergePatch(existing, targetStruct interface{}) error {
+	// only accept pointer
+	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
+		return ErrBindNonPointer
+	}
+
+	original, err := c.jsonCodec().Marshal(existing)
+	if err != nil {
+		return errBinding(err)
+	}
+
+	patch, err := c.readPatchBody()
+	if err != nil {
+		return err
+	}
+
+	merged, err := MergePatch(original, patch)
+	if err != nil {
+		return ErrBinding{
+			Status: http.StatusBadRequest,
+			Text:   err.Error(),
+			Err:    err,
+		}
+	}
+
+	// reset target, so removed members don't keep their previous value.
+	target := reflect.ValueOf(targetStruct).Elem()
+	target.Set(reflect.Zero(target.Type()))
+
+	if err := c.jsonCodec().Unmarshal(merged, targetStruct); err != nil {
+		return ErrBinding{
+			Status: http.StatusBadRequest,
+			Text:   err.Error(),
+			Err:    err,
+		}
+	}
+
+	return validate(c, targetStruct)
+}
+
+// BindJSONPatch decodes RFC 6902 json patch from request body.
+// Each operation is validated, invalid operations are reported as ErrBinding with 422 status code.
+func (c *Context) BindJSONPatch() (JSONPatch, error) {
+	body, err := c.readPatchBody()
+	if err != nil {
+		return nil, err
+	}
+
+	var patch JSONPatch
+	if err := stdjson.Unmarshal(body, &patch); err != nil {
+		return nil, ErrBinding{
+			Status: http.StatusBadRequest,
+			Text:   err.Error(),
+			Err:    err,
+		}
+	}
+
+	if fields := patch.validate(); len(fields) > 0 {
+		return nil, ErrBinding{
+			Status: http.StatusUnprocessableEntity,
+			Text:   "validation error",
+			Fields: fields,
+		}
+	}
+
+	return patch, nil
+}
+
+// readPatchBody reads whole request body.
+func (c *Context) readPatchBody() ([]byte, error) {
+	if c.Request.Body == nil {
+		return nil, ErrBinding{
+			Status: http.StatusBadRequest,
+			Text:   "empty patch document",
+		}
+	}
+	defer c.Request.Body.Close()
+
+	c.rewindBody()
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, c.errPayload(err)
+	}
+
+	return body, nil
+}
+
+// validate returns error message of each invalid operation.
+func (p JSONPatch) validate() []string {
+	fields := make([]string, 0)
+
+	for i, op := range p {
+		var problem string
+
+		switch {
+		case op.Op != "add" && op.Op != "remove" && op.Op != "replace" && op.Op != "move" && op.Op != "copy" && op.Op != "test":
+			problem = fmt.Sprintf("unknown operation %q", op.Op)
+		case op.Path != "" && op.Path[0] != '/':
+			problem = "path must be a json pointer"
+		case (op.Op == "move" || op.Op == "copy") && (op.From != "" && op.From[0] != '/'):
+			problem = "from must be a json pointer"
+		case op.Op == "move" && strings.HasPrefix(op.Path, op.From+"/"):
+			problem = "path must not be child of from"
+		case (op.Op == "add" || op.Op == "replace" || op.Op == "test") && op.Value == nil:
+			problem = "value is required"
+		}
+
+		if problem != "" {
+			fields = append(fields, fmt.Sprintf("operation %d: %s", i, problem))
+		}
+	}
+
+	return fields
+}
+
+// Apply applies patch operations onto targetStruct.
+// targetStruct is only modified when all operations succeed.
+func (p JSONPatch) Apply(targetStruct interface{}) error {
+	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
+		return ErrBindNonPointer
+	}
+
+	original, err := json.Marshal(targetStruct)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := stdjson.Unmarshal(original, &doc); err != nil {
+		return err
+	}
+
+	for i, op := range p {
+		if doc, err = op.apply(doc); err != nil {
+			return fmt.Errorf("operation %d: %v", i, err)
+		}
+	}
+
+	patched, err := stdjson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(targetStruct).Elem()
+	result := reflect.New(target.Type())
+	if err := json.Unmarshal(patched, result.Interface()); err != nil {
+		return err
+	}
+	target.Set(result.Elem())
+
+	return nil
+}
+
+// apply applies single operation onto document.
+func (op JSONPatchOperation) apply(doc interface{}) (interface{}, error) {
+	path := parsePointer(op.Path)
+
+	var value interface{}
+	if op.Value != nil {
+		if err := stdjson.Unmarshal(op.Value, &value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch op.Op {
+	case "add":
+		return pointerAdd(doc, path, value)
+	case "remove":
+		doc, _, err := pointerRemove(doc, path)
+		return doc, err
+	case "replace":
+		doc, _, err := pointerRemove(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case "move":
+		// location can't be moved into one of its children, see RFC 6902 section 4.4.
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("could not move %q into its child %q", op.From, op.Path)
+		}
+
+		doc, moved, err := pointerRemove(doc, parsePointer(op.From))
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, moved)
+	case "copy":
+		copied, err := pointerGet(doc, parsePointer(op.From))
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, deepCopy(copied))
+	case "test":
+		current, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("test failed at %q", op.Path)
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// MergePatch applies RFC 7396 json merge patch to the original json document.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var doc, patchDoc interface{}
+
+	if len(original) > 0 {
+		if err := stdjson.Unmarshal(original, &doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := stdjson.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, err
+	}
+
+	return stdjson.Marshal(mergePatch(doc, patchDoc))
+}
+
+// mergePatch merges patch into target recursively.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// errPointerNotFound is returned when json pointer doesn't reference any value.
+var errPointerNotFound = errors.New("path not found")
+
+// parsePointer splits RFC 6901 json pointer into unescaped reference tokens.
+func parsePointer(pointer string) []string {
+	if pointer == "" {
+		return []string{}
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return tokens
+}
+
+// arrayIndex parses array index token, allowMax allows index equals to array length.
+func arrayIndex(token string, length int, allowMax bool) (int, error) {
+	if allowMax && token == "-" {
+		return length, nil
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > length || (!allowMax && index == length) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	return index, nil
+}
+
+// pointerGet returns value referenced by path.
+func pointerGet(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, errPointerNotFound
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, errPointerNotFound
+		}
+	}
+
+	return doc, nil
+}
+
+// pointerAdd adds value at path, and returns the modified document.
+func pointerAdd(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			node[token] = value
+			return node, nil
+		}
+
+		child, ok := node[token]
+		if !ok {
+			return nil, errPointerNotFound
+		}
+
+		child, err := pointerAdd(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = child
+
+		return node, nil
+	case []interface{}:
+		if len(path) == 1 {
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+
+			return node, nil
+		}
+
+		index, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := pointerAdd(node[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = child
+
+		return node, nil
+	}
+
+	return nil, errPointerNotFound
+}
+
+// pointerRemove removes value at path, and returns the modified document and the removed value.
+func pointerRemove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+
+	token := path[0]
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, errPointerNotFound
+		}
+
+		if len(path) == 1 {
+			delete(node, token)
+			return node, child, nil
+		}
+
+		child, removed, err := pointerRemove(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = child
+
+		return node, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(path) == 1 {
+			removed := node[index]
+			return append(node[:index], node[index+1:]...), removed, nil
+		}
+
+		child, removed, err := pointerRemove(node[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[index] = child
+
+		return node, removed, nil
+	}
+
+	return nil, nil, errPointerNotFound
+}
+
+// deepCopy copies json value, so copied value doesn't share the same map or slice.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	}
+
+	return value
+}
@@ -0,0 +1,157 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type patchedProduct struct {
+	Name  string   `json:"name" validate:"required"`
+	Price int      `json:"price"`
+	Tags  []string `json:"tags"`
+	Note  string   `json:"note,omitempty"`
+}
+
+func newPatchContext(body, contentType string) *Context {
+	req, err := http.NewRequest(http.MethodPatch, "/", strings.NewReader(body))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, contentType)
+
+	return newContext(httptest.NewRecorder(), req)
+}
+
+func TestMergePatch(t *testing.T) {
+	tt := []struct {
+		name     string
+		original string
+		patch    string
+		result   string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"nested object", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":"g"}}`, `{"a":{"b":"c","f":"g"}}`},
+		{"non object patch", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			result, err := MergePatch([]byte(tc.original), []byte(tc.patch))
+			if err != nil {
+				st.Fatalf("expected error to be nil; got %v", err)
+			}
+
+			if string(result) != tc.result {
+				st.Errorf("expected result to be %s; got %s", tc.result, result)
+			}
+		})
+	}
+}
+
+func TestBindMergePatch(t *testing.T) {
+	existing := patchedProduct{Name: "book", Price: 10, Tags: []string{"paper"}, Note: "old"}
+
+	ctx := newPatchContext(`{"price":12,"note":null}`, MimeMergePatchJSON)
+
+	var updated patchedProduct
+	if err := ctx.BindMergePatch(existing, &updated); err != nil {
+		t.Fatalf("expected error to be nil; got %v", err)
+	}
+
+	if updated.Name != "book" || updated.Price != 12 || updated.Note != "" || len(updated.Tags) != 1 {
+		t.Errorf("expected patch to be applied; got %+v", updated)
+	}
+
+	if existing.Price != 10 {
+		t.Errorf("expected existing value to be untouched; got %+v", existing)
+	}
+
+	t.Run("patched value is validated", func(st *testing.T) {
+		skipWithoutValidator(st)
+
+		ctx := newPatchContext(`{"name":null}`, MimeMergePatchJSON)
+
+		err := ctx.BindMergePatch(&existing, &existing)
+		if errBinding, ok := err.(ErrBinding); !ok || errBinding.Status != http.StatusUnprocessableEntity {
+			st.Errorf("expected validation error; got %v", err)
+		}
+	})
+}
+
+func TestBindJSONPatch(t *testing.T) {
+	t.Run("invalid operations", func(st *testing.T) {
+		ctx := newPatchContext(`[{"op":"jump","path":"/a"},{"op":"add","path":"/a"},{"op":"remove","path":"a"},{"op":"move","from":"/a","path":"/a/b"}]`, MimeJSONPatch)
+
+		_, err := ctx.BindJSONPatch()
+		errBinding, ok := err.(ErrBinding)
+		if !ok || errBinding.Status != http.StatusUnprocessableEntity {
+			st.Fatalf("expected validation error; got %v", err)
+		}
+
+		if len(errBinding.Fields) != 4 {
+			st.Errorf("expected num of invalid operations to be 4; got %v", errBinding.Fields)
+		}
+	})
+
+	t.Run("apply operations", func(st *testing.T) {
+		ctx := newPatchContext(`[
+			{"op":"test","path":"/name","value":"book"},
+			{"op":"replace","path":"/price","value":15},
+			{"op":"add","path":"/tags/-","value":"sale"},
+			{"op":"add","path":"/tags/0","value":"new"},
+			{"op":"copy","from":"/name","path":"/note"},
+			{"op":"remove","path":"/tags/1"}
+		]`, MimeJSONPatch)
+
+		patch, err := ctx.BindJSONPatch()
+		if err != nil {
+			st.Fatalf("expected error to be nil; got %v", err)
+		}
+
+		product := patchedProduct{Name: "book", Price: 10, Tags: []string{"paper"}}
+		if err := patch.Apply(&product); err != nil {
+			st.Fatalf("expected error to be nil; got %v", err)
+		}
+
+		if product.Price != 15 || product.Note != "book" || strings.Join(product.Tags, ",") != "new,sale" {
+			st.Errorf("expected patch to be applied; got %+v", product)
+		}
+	})
+
+	t.Run("move into child", func(st *testing.T) {
+		patch := JSONPatch{
+			{Op: "move", From: "/tags", Path: "/tags/0"},
+		}
+
+		product := patchedProduct{Name: "book", Tags: []string{"paper"}}
+		if err := patch.Apply(&product); err == nil {
+			st.Fatalf("expected error to be returned")
+		}
+
+		if strings.Join(product.Tags, ",") != "paper" {
+			st.Errorf("expected product to be untouched; got %+v", product)
+		}
+	})
+
+	t.Run("failed test operation", func(st *testing.T) {
+		patch := JSONPatch{
+			{Op: "replace", Path: "/price", Value: []byte("1")},
+			{Op: "test", Path: "/name", Value: []byte(`"pen"`)},
+		}
+
+		product := patchedProduct{Name: "book", Price: 10}
+		if err := patch.Apply(&product); err == nil {
+			st.Fatalf("expected error to be returned")
+		}
+
+		if product.Price != 10 {
+			st.Errorf("expected product to be untouched; got %+v", product)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package nano
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	stdjson "encoding/json"
+)
+
+// ErrPayloadTooLarge is returned by request body reader when body size exceeds the configured limit.
+var ErrPayloadTooLarge = errors.New("request body too large")
+
+// DefaultMaxBufferedBody is maximum number of bytes of request body buffered in memory,
+// e.g. by RawBody or json limits check, when the route doesn't set MaxBodySize.
+var DefaultMaxBufferedBody int64 = 32 << 20
+
+// PayloadLimitConfig defines limits of request payload, checked while binding the request.
+// Zero value of each field means no limit.
+type PayloadLimitConfig struct {
+	// MaxBodySize is maximum number of bytes read from request body (after decompression).
+	MaxBodySize int64
+	// MaxJSONDepth is maximum nesting depth of json objects & arrays.
+	MaxJSONDepth int
+	// MaxJSONArrayLength is maximum number of elements in each json array.
+	MaxJSONArrayLength int
+}
+
+// limitedBody wraps request body to limit the number of bytes can be read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+// Read reads up to remaining bytes, and returns ErrPayloadTooLarge when body has more data.
+func (l *limitedBody) Read(p []byte) (int, error) {
+	// read one more byte to detect oversized body.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) <= l.remaining {
+		l.remaining -= int64(n)
+		return n, err
+	}
+
+	n = int(l.remaining)
+	l.remaining = 0
+	l.exceeded = true
+
+	return n, ErrPayloadTooLarge
+}
+
+// PayloadLimit protects route from abusive payload.
+// Attach it per route or per group, e.g. app.POST("/import", nano.PayloadLimit(config), importHandler).
+// Binding returns 413 status code when body exceeds MaxBodySize,
+// and 400 status code when json body exceeds depth or array length limit.
+func PayloadLimit(config PayloadLimitConfig) HandlerFunc {
+	return func(c *Context) {
+		c.payloadLimit = &config
+
+		// route-level body limit applies instead.
+		if c.override != nil && c.override.bodyLimit > 0 {
+			c.Next()
+			return
+		}
+
+		c.limitBody(config.MaxBodySize)
+	}
+}
+
+// routeBodyLimit returns middleware set by Route.WithBodyLimit, json limits of PayloadLimit are kept.
+func routeBodyLimit(maxBodySize int64) HandlerFunc {
+	return func(c *Context) {
+		config := PayloadLimitConfig{}
+		if c.payloadLimit != nil {
+			config = *c.payloadLimit
+		}
+
+		config.MaxBodySize = maxBodySize
+		c.payloadLimit = &config
+
+		c.limitBody(maxBodySize)
+	}
+}
+
+// limitBody wraps request body to limit its size and calls the next handler,
+// it responds 413 status code when request content length exceeds the limit.
+func (c *Context) limitBody(maxBodySize int64) {
+	if maxBodySize > 0 && c.Request.Body != nil {
+		// reject early when client tells us the body size.
+		if c.Request.ContentLength > maxBodySize {
+			c.String(http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+
+		c.Request.Body = &limitedBody{ReadCloser: c.Request.Body, remaining: maxBodySize}
+	}
+
+	c.Next()
+}
+
+// isPayloadTooLarge returns true when err caused by request body limit.
+// some decoders don't keep the original reader error, so we also check the body state.
+func (c *Context) isPayloadTooLarge(err error) bool {
+	if errors.Is(err, ErrPayloadTooLarge) {
+		return true
+	}
+
+	body := c.Request.Body
+	// skip progress reporting & read rate wrappers.
+	for {
+		p, ok := body.(*progressBody)
+		if !ok {
+			break
+		}
+
+		body = p.ReadCloser
+	}
+
+	limited, ok := body.(*limitedBody)
+
+	return ok && limited.exceeded
+}
+
+// bufferLimit returns maximum number of bytes of request body could be buffered in memory.
+func bufferLimit(limits *PayloadLimitConfig) int64 {
+	if limits != nil && limits.MaxBodySize > 0 {
+		return limits.MaxBodySize
+	}
+
+	return DefaultMaxBufferedBody
+}
+
+// readBody reads whole body up to limit bytes, it returns ErrPayloadTooLarge when body has more data.
+func readBody(body io.Reader, limit int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return data, err
+	}
+
+	if int64(len(data)) > limit {
+		return data[:limit], ErrPayloadTooLarge
+	}
+
+	return data, nil
+}
+
+// checkJSONLimits reads json body and validates its depth and array length against limits.
+// the body is returned as new reader so it can be decoded afterward.
+func checkJSONLimits(body io.Reader, limits *PayloadLimitConfig) (io.Reader, error) {
+	data, err := readBody(body, bufferLimit(limits))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := stdjson.NewDecoder(bytes.NewReader(data))
+	// stack of array element counters, -1 marks an object.
+	stack := make([]int, 0)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+
+		// syntax error will be reported by the actual decoder.
+		if err != nil {
+			break
+		}
+
+		// count element of current array.
+		if len(stack) > 0 && stack[len(stack)-1] >= 0 {
+			if delim, ok := token.(stdjson.Delim); !ok || delim == '[' || delim == '{' {
+				stack[len(stack)-1]++
+				if limits.MaxJSONArrayLength > 0 && stack[len(stack)-1] > limits.MaxJSONArrayLength {
+					return nil, fmt.Errorf("json array length exceeds limit of %d", limits.MaxJSONArrayLength)
+				}
+			}
+		}
+
+		delim, ok := token.(stdjson.Delim)
+		if !ok {
+			continue
+		}
+
+		switch delim {
+		case '[':
+			stack = append(stack, 0)
+		case '{':
+			stack = append(stack, -1)
+		default:
+			stack = stack[:len(stack)-1]
+		}
+
+		if limits.MaxJSONDepth > 0 && len(stack) > limits.MaxJSONDepth {
+			return nil, fmt.Errorf("json nesting depth exceeds limit of %d", limits.MaxJSONDepth)
+		}
+	}
+
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,91 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPayloadLimit(t *testing.T) {
+	type Payload struct {
+		Items []interface{} `json:"items"`
+	}
+
+	tt := []struct {
+		name   string
+		config PayloadLimitConfig
+		body   string
+		status int
+	}{
+		{"body within limits", PayloadLimitConfig{MaxBodySize: 64, MaxJSONDepth: 3, MaxJSONArrayLength: 3}, `{"items":[1,2,3]}`, http.StatusOK},
+		{"body too large", PayloadLimitConfig{MaxBodySize: 8}, `{"items":[1,2,3]}`, http.StatusRequestEntityTooLarge},
+		{"json too deep", PayloadLimitConfig{MaxJSONDepth: 2}, `{"items":[[1]]}`, http.StatusBadRequest},
+		{"json array too long", PayloadLimitConfig{MaxJSONArrayLength: 2}, `{"items":[1,2,3]}`, http.StatusBadRequest},
+		{"buffered json too large", PayloadLimitConfig{MaxJSONDepth: 3}, `{"items":[1,2,3,4,5,6,7,8,9]}`, http.StatusRequestEntityTooLarge},
+	}
+
+	defaultMaxBufferedBody := DefaultMaxBufferedBody
+	DefaultMaxBufferedBody = 24
+	defer func() { DefaultMaxBufferedBody = defaultMaxBufferedBody }()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.POST("/", PayloadLimit(tc.config), func(c *Context) {
+				var payload Payload
+				if err := c.Bind(&payload); err != nil {
+					c.String(err.(ErrBinding).Status, err.Error())
+					return
+				}
+
+				c.String(http.StatusOK, "ok")
+			})
+
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+			// hide content length to make sure the limit is enforced while reading.
+			req.ContentLength = -1
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d (%s)", tc.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPayloadLimitOnForm(t *testing.T) {
+	app := New()
+	app.POST("/", PayloadLimit(PayloadLimitConfig{MaxBodySize: 4}), func(c *Context) {
+		var person struct {
+			Name string `form:"name"`
+		}
+
+		if err := c.Bind(&person); err != nil {
+			c.String(err.(ErrBinding).Status, err.Error())
+			return
+		}
+
+		c.String(http.StatusOK, "ok")
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("name=foobar"))
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Set(HeaderContentType, MimeFormURLEncoded)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status code to be 413; got %d", rec.Code)
+	}
+}
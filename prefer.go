@@ -0,0 +1,93 @@
+package nano
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderPrefer is client preferences (RFC 7240).
+	HeaderPrefer = "Prefer"
+	// HeaderPreferenceApplied is preferences applied by server (RFC 7240).
+	HeaderPreferenceApplied = "Preference-Applied"
+)
+
+// Preferences returns parsed Prefer request header as preference name & value, e.g.
+// "return=minimal, respond-async" became {"return": "minimal", "respond-async": ""}.
+// preference parameters are ignored.
+func (c *Context) Preferences() map[string]string {
+	preferences := make(map[string]string)
+
+	for _, header := range c.Request.Header[HeaderPrefer] {
+		for _, preference := range strings.Split(header, ",") {
+			// ignore preference parameters.
+			preference = strings.TrimSpace(strings.SplitN(preference, ";", 2)[0])
+			if preference == "" {
+				continue
+			}
+
+			parts := strings.SplitN(preference, "=", 2)
+			name := strings.ToLower(strings.TrimSpace(parts[0]))
+
+			// the first occurrence wins.
+			if _, exists := preferences[name]; exists {
+				continue
+			}
+
+			value := ""
+			if len(parts) == 2 {
+				value = strings.Trim(strings.TrimSpace(parts[1]), `"`)
+			}
+
+			preferences[name] = value
+		}
+	}
+
+	return preferences
+}
+
+// ApplyPreference tells client that the preference is honored using Preference-Applied response header.
+func (c *Context) ApplyPreference(preference string) {
+	c.Writer.Header().Add(HeaderPreferenceApplied, preference)
+}
+
+// PreferMinimal returns true when client sends "Prefer: return=minimal".
+// Since the caller is expected to skip response body, the preference is marked as applied automatically.
+func (c *Context) PreferMinimal() bool {
+	if c.Preferences()["return"] != "minimal" {
+		return false
+	}
+
+	c.ApplyPreference("return=minimal")
+
+	return true
+}
+
+// PreferRepresentation returns true when client sends "Prefer: return=representation",
+// and marks the preference as applied.
+func (c *Context) PreferRepresentation() bool {
+	if c.Preferences()["return"] != "representation" {
+		return false
+	}
+
+	c.ApplyPreference("return=representation")
+
+	return true
+}
+
+// PreferWait returns duration of "Prefer: wait=seconds" preference, and false when it's not sent.
+// It's not marked as applied, call ApplyPreference when you honor it.
+func (c *Context) PreferWait() (time.Duration, bool) {
+	value, ok := c.Preferences()["wait"]
+	if !ok {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
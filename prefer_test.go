@@ -0,0 +1,46 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPreferences(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	req.Header.Add(HeaderPrefer, `return=minimal; foo="bar", respond-async`)
+	req.Header.Add(HeaderPrefer, "wait=10, return=representation")
+
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	preferences := ctx.Preferences()
+	if len(preferences) != 3 || preferences["return"] != "minimal" {
+		t.Errorf("expected 3 preferences with return=minimal; got %v", preferences)
+	}
+
+	if _, ok := preferences["respond-async"]; !ok {
+		t.Errorf("expected respond-async preference to be exists")
+	}
+
+	if ctx.PreferRepresentation() {
+		t.Errorf("expected return=representation to be ignored since return=minimal comes first")
+	}
+
+	if !ctx.PreferMinimal() {
+		t.Errorf("expected return=minimal to be preferred")
+	}
+
+	if wait, ok := ctx.PreferWait(); !ok || wait != 10*time.Second {
+		t.Errorf("expected wait preference to be 10s; got %v", wait)
+	}
+
+	if applied := rec.Header().Get(HeaderPreferenceApplied); applied != "return=minimal" {
+		t.Errorf("expected preference applied header to be return=minimal; got %s", applied)
+	}
+}
@@ -0,0 +1,96 @@
+package nano
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// StripPrefix returns middleware removing prefix from request path, e.g. when proxy forwards /api/v2/users as is
+// to the app serving /users. Request path without the prefix is kept. Register it using Engine.Pre.
+func StripPrefix(prefix string) HandlerFunc {
+	prefix = normalizeBasePath(prefix)
+
+	return func(c *Context) {
+		if urlPath, ok := trimBasePath(c.Request.URL.Path, prefix); ok && prefix != "" {
+			rawPath, _ := trimBasePath(c.Request.URL.RawPath, prefix)
+			c.setPath(urlPath, rawPath)
+		}
+
+		c.Next()
+	}
+}
+
+// AddPrefix returns middleware adding prefix to request path, e.g. when proxy strips /api/v2 from requests
+// of the app whose routes are registered under /api/v2. Register it using Engine.Pre.
+func AddPrefix(prefix string) HandlerFunc {
+	prefix = normalizeBasePath(prefix)
+
+	return func(c *Context) {
+		if prefix != "" {
+			c.setPath(strings.TrimSuffix(prefix+c.Request.URL.Path, "/"), "")
+		}
+
+		c.Next()
+	}
+}
+
+// SetBasePath functions to serve the app under base path, e.g. /api/v2, without changing route registrations.
+// The base path is stripped from request path before routing, and request outside the base path
+// is served by default route handler. Urls built by Engine.URL and redirects of the router include the base path.
+func (ng *Engine) SetBasePath(basePath string) {
+	ng.router.mustNotBeFrozen("set base path")
+
+	ng.basePath = normalizeBasePath(basePath)
+}
+
+// normalizeBasePath returns base path having leading slash and no trailing slash, root path is returned as empty string.
+func normalizeBasePath(basePath string) string {
+	basePath = strings.Trim(basePath, "/")
+	if basePath == "" {
+		return ""
+	}
+
+	return "/" + basePath
+}
+
+// trimBasePath removes base path from url path, it returns false when url path is outside the base path.
+func trimBasePath(urlPath, basePath string) (string, bool) {
+	if !strings.HasPrefix(urlPath, basePath) {
+		return urlPath, false
+	}
+
+	trimmed := urlPath[len(basePath):]
+	if trimmed == "" {
+		return "/", true
+	}
+
+	// /api/v2x is not under /api/v2.
+	if trimmed[0] != '/' {
+		return urlPath, false
+	}
+
+	return trimmed, true
+}
+
+// stripBasePath returns copy of request whose path doesn't have base path of the engine,
+// it returns false when request is outside the base path.
+func (ng *Engine) stripBasePath(r *http.Request) (*http.Request, bool) {
+	urlPath, ok := trimBasePath(r.URL.Path, ng.basePath)
+	if !ok {
+		return r, false
+	}
+
+	stripped := new(http.Request)
+	*stripped = *r
+	stripped.URL = new(url.URL)
+	*stripped.URL = *r.URL
+	stripped.URL.Path = urlPath
+	stripped.URL.RawPath = ""
+
+	if rawPath, ok := trimBasePath(r.URL.RawPath, ng.basePath); ok {
+		stripped.URL.RawPath = rawPath
+	}
+
+	return stripped, true
+}
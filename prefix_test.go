@@ -0,0 +1,103 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrefix(t *testing.T) {
+	handler := func(c *Context) {
+		c.String(http.StatusOK, "%s", c.Path)
+	}
+
+	tt := []struct {
+		name       string
+		middleware HandlerFunc
+		pattern    string
+		path       string
+		body       string
+	}{
+		{"strip prefix", StripPrefix("/api/v2/"), "/users", "/api/v2/users", "/users"},
+		{"strip prefix of root", StripPrefix("/api/v2"), "/", "/api/v2", "/"},
+		{"path without prefix", StripPrefix("/api/v2"), "/users", "/users", "/users"},
+		{"partial prefix", StripPrefix("/api/v2"), "/api/v2x", "/api/v2x", "/api/v2x"},
+		{"add prefix", AddPrefix("api/v2"), "/api/v2/users", "/users", "/api/v2/users"},
+		{"add prefix to root", AddPrefix("/api/v2"), "/api/v2", "/", "/api/v2"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.Pre(tc.middleware)
+			app.GET(tc.pattern, handler)
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); rec.Code != http.StatusOK || body != tc.body {
+				st.Errorf("expected response to be 200 %s; got %d %s", tc.body, rec.Code, body)
+			}
+		})
+	}
+}
+
+func TestBasePath(t *testing.T) {
+	app := New()
+	app.SetBasePath("/api/v2/")
+	app.SetRedirectTrailingSlash(true)
+
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "%s %s", c.Path, c.Param("id"))
+	}).Name("user.show")
+
+	app.GET("/me", func(c *Context) {
+		c.RedirectToRoute(http.StatusFound, "user.show", map[string]string{"id": "1"})
+	})
+
+	tt := []struct {
+		path     string
+		status   int
+		body     string
+		location string
+	}{
+		{"/api/v2/users/1", http.StatusOK, "/users/1 1", ""},
+		{"/api/v2/users/a%2Fb", http.StatusOK, "/users/a/b a/b", ""},
+		{"/api/v2/me", http.StatusFound, "", "/api/v2/users/1"},
+		{"/api/v2/users/1/", http.StatusMovedPermanently, "", "/api/v2/users/1"},
+		{"/users/1", http.StatusNotFound, "", ""},
+		{"/api/v2x/users/1", http.StatusNotFound, "", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if tc.body != "" && rec.Body.String() != tc.body {
+				st.Errorf("expected response body to be %s; got %s", tc.body, rec.Body.String())
+			}
+
+			if location := rec.Header().Get("Location"); location != tc.location {
+				st.Errorf("expected location to be %q; got %q", tc.location, location)
+			}
+		})
+	}
+
+	if location, _ := app.URL("user.show", map[string]string{"id": "2"}); location != "/api/v2/users/2" {
+		t.Errorf("expected route url to include base path; got %s", location)
+	}
+}
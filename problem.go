@@ -0,0 +1,65 @@
+package nano
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Problem writes RFC 7807 problem details as application/problem+json response. problemType is uri identifying
+// the problem, default is about:blank. title defaults to status text, and empty detail is omitted.
+// extensions are added as additional members, e.g. invalid fields or trace id.
+func (c *Context) Problem(statusCode int, problemType, title, detail string, extensions H) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	problem := make(H, len(extensions)+4)
+	for key, value := range extensions {
+		problem[key] = value
+	}
+
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+
+	if title == "" {
+		title = http.StatusText(statusCode)
+	}
+
+	problem["type"] = problemType
+	problem["title"] = title
+	problem["status"] = statusCode
+
+	if detail != "" {
+		problem["detail"] = detail
+	}
+
+	rs, err := c.jsonCodec().Marshal(problem)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return err
+	}
+
+	c.SetContentType(MimeProblemJSON)
+	c.Status(statusCode)
+	_, err = c.Writer.Write(rs)
+
+	return err
+}
+
+// EnableProblemDetails functions to respond errors of Context.AbortWithError (using the default error handler)
+// and binding errors of MustBind as problem details, instead of {"error": ...} json.
+// Html response for browsers is kept.
+func (ng *Engine) EnableProblemDetails() {
+	ng.problemDetails = true
+}
+
+// useProblemDetails returns true when errors should be responded as problem details.
+func (c *Context) useProblemDetails() bool {
+	return c.engine != nil && c.engine.problemDetails
+}
+
+// expectProblem returns true when client accepts json or problem details.
+func (c *Context) expectProblem() bool {
+	return c.ExpectJSON() || strings.Contains(c.GetRequestHeader(HeaderAccept), MimeProblemJSON)
+}
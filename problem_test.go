@@ -0,0 +1,94 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProblem(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	newContext(rec, req).Problem(http.StatusForbidden, "https://example.com/probs/out-of-credit", "You do not have enough credit.", "Your current balance is 30, but that costs 50.", H{"balance": 30, "status": 200})
+
+	expected := `{"balance":30,"detail":"Your current balance is 30, but that costs 50.","status":403,"title":"You do not have enough credit.","type":"https://example.com/probs/out-of-credit"}`
+	if rec.Code != http.StatusForbidden || rec.Body.String() != expected {
+		t.Errorf("expected response to be 403 %s; got %d %s", expected, rec.Code, rec.Body.String())
+	}
+
+	if contentType := rec.Header().Get(HeaderContentType); contentType != MimeProblemJSON {
+		t.Errorf("expected content type to be %s; got %s", MimeProblemJSON, contentType)
+	}
+
+	t.Run("default members", func(st *testing.T) {
+		rec := httptest.NewRecorder()
+		newContext(rec, req).Problem(http.StatusNotFound, "", "", "", nil)
+
+		if expected := `{"status":404,"title":"Not Found","type":"about:blank"}`; rec.Body.String() != expected {
+			st.Errorf("expected body to be %s; got %s", expected, rec.Body.String())
+		}
+	})
+}
+
+func TestEnableProblemDetails(t *testing.T) {
+	type Signup struct {
+		Email string `json:"email" validate:"required"`
+	}
+
+	app := New()
+	app.EnableProblemDetails()
+	app.GET("/users/:id", func(c *Context) {
+		c.AbortWithError(NewHTTPError(http.StatusNotFound, "user not found"))
+	})
+	app.POST("/signup", func(c *Context) {
+		var signup Signup
+		if !c.MustBind(&signup) {
+			return
+		}
+	})
+
+	tt := []struct {
+		name        string
+		method      string
+		url         string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"http error", http.MethodGet, "/users/1", MimeProblemJSON, MimeProblemJSON, `{"detail":"user not found","status":404,"title":"Not Found","type":"about:blank"}`},
+		{"validation error", http.MethodPost, "/signup", MimeJSON, MimeProblemJSON, `{"detail":"validation error","fields":["Email is a required field"],"status":422,"title":"Unprocessable Entity","type":"about:blank"}`},
+		{"html client", http.MethodGet, "/users/1", MimeHTML, MimeHTML, "<h1>404 Not Found</h1><p>user not found</p>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if tc.url == "/signup" {
+				skipWithoutValidator(st)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(`{}`))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+			req.Header.Set(HeaderAccept, tc.accept)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if contentType := rec.Header().Get(HeaderContentType); contentType != tc.contentType {
+				st.Errorf("expected content type to be %s; got %s", tc.contentType, contentType)
+			}
+
+			if rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, rec.Body.String())
+			}
+		})
+	}
+}
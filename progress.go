@@ -0,0 +1,144 @@
+package nano
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrBodyTooSlow is returned by request body reader when client sends the body slower than the configured rate.
+var ErrBodyTooSlow = errors.New("request body too slow")
+
+// ProgressFunc is called each time request body is read, with total number of bytes read so far.
+type ProgressFunc func(readBytes int64)
+
+// ReadRateConfig defines minimum rate of reading request body.
+type ReadRateConfig struct {
+	// MinBytesPerSecond is minimum average rate of request body, measured since the first read.
+	MinBytesPerSecond int64
+	// Grace is the period after first read before the rate is checked, default to 5 seconds.
+	Grace time.Duration
+}
+
+// progressBody wraps request body to report read progress and enforce read rate.
+type progressBody struct {
+	io.ReadCloser
+	read      int64
+	progress  ProgressFunc
+	rate      *ReadRateConfig
+	startedAt time.Time
+	slow      bool
+}
+
+// Read reads from underlying body, then reports progress and checks the read rate.
+func (p *progressBody) Read(b []byte) (int, error) {
+	// keep failing, some decoders retry on read error.
+	if p.slow {
+		return 0, ErrBodyTooSlow
+	}
+
+	// the clock starts on first read, that is when the server sends 100 Continue to the client.
+	if p.startedAt.IsZero() {
+		p.startedAt = time.Now()
+	}
+
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+
+		if p.progress != nil {
+			p.progress(p.read)
+		}
+	}
+
+	if p.rate != nil && err == nil {
+		elapsed := time.Since(p.startedAt)
+		if elapsed > p.rate.Grace && float64(p.read) < float64(p.rate.MinBytesPerSecond)*elapsed.Seconds() {
+			p.slow = true
+			return n, ErrBodyTooSlow
+		}
+	}
+
+	return n, err
+}
+
+// ReadRate protects route from clients which send request body too slow (slow-write attack).
+// Binding returns 408 status code when the average rate falls below MinBytesPerSecond.
+// A client which stops sending entirely should be handled by http.Server ReadTimeout.
+func ReadRate(config ReadRateConfig) HandlerFunc {
+	if config.Grace <= 0 {
+		config.Grace = 5 * time.Second
+	}
+
+	return func(c *Context) {
+		if config.MinBytesPerSecond > 0 && c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = &progressBody{ReadCloser: c.Request.Body, rate: &config}
+		}
+
+		c.Next()
+	}
+}
+
+// withProgress replaces request body with progress reporting body while calling bind.
+func (c *Context) withProgress(progress ProgressFunc, bind func() error) error {
+	if c.Request.Body == nil || progress == nil {
+		return bind()
+	}
+
+	body := c.Request.Body
+	c.Request.Body = &progressBody{ReadCloser: body, progress: progress}
+	defer func() { c.Request.Body = body }()
+
+	return bind()
+}
+
+// BindJSONWithProgress functions to bind json request body like BindJSON,
+// and calls progress each time the body is read.
+// Body is read lazily, so client which sent Expect: 100-continue only receives 100 Continue
+// when this method is called, handler may reject the request before that.
+func (c *Context) BindJSONWithProgress(targetStruct interface{}, progress ProgressFunc) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.withProgress(progress, func() error { return c.bindJSON(targetStruct) })
+	})
+}
+
+// BindMultipartFormWithProgress functions to bind multipart form like BindMultipartForm,
+// and calls progress each time the body is read.
+func (c *Context) BindMultipartFormWithProgress(targetStruct interface{}, progress ProgressFunc) error {
+	return c.bindAndValidate(targetStruct, func() error {
+		return c.withProgress(progress, func() error { return c.bindMultipartForm(targetStruct) })
+	})
+}
+
+// DataFromReaderWithProgress functions to write content of reader as response like DataFromReader,
+// and calls progress with total bytes read from reader each time it's copied to the client, e.g. to track download of large file.
+func (c *Context) DataFromReaderWithProgress(statusCode int, contentLength int64, contentType string, reader io.Reader, extraHeaders map[string]string, progress ProgressFunc) error {
+	if progress != nil {
+		reader = &progressBody{ReadCloser: io.NopCloser(reader), progress: progress}
+	}
+
+	return c.DataFromReader(statusCode, contentLength, contentType, reader, extraHeaders)
+}
+
+// isBodyTooSlow returns true when err caused by ReadRate.
+func (c *Context) isBodyTooSlow(err error) bool {
+	if errors.Is(err, ErrBodyTooSlow) {
+		return true
+	}
+
+	for body := c.Request.Body; body != nil; {
+		p, ok := body.(*progressBody)
+		if !ok {
+			return false
+		}
+
+		if p.slow {
+			return true
+		}
+
+		body = p.ReadCloser
+	}
+
+	return false
+}
@@ -0,0 +1,241 @@
+package nano
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowReader returns one byte per read after waiting delay.
+type slowReader struct {
+	data  []byte
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	if len(s.data) == 0 {
+		return 0, io.EOF
+	}
+
+	time.Sleep(s.delay)
+	p[0] = s.data[0]
+	s.data = s.data[1:]
+
+	return 1, nil
+}
+
+func TestBindWithProgress(t *testing.T) {
+	type Payload struct {
+		Name string `json:"name" form:"name"`
+	}
+
+	t.Run("json", func(st *testing.T) {
+		body := `{"name":"nano"}`
+		var read int64
+		var payload Payload
+
+		app := New()
+		app.POST("/", func(c *Context) {
+			if err := c.BindJSONWithProgress(&payload, func(n int64) { read = n }); err != nil {
+				c.String(err.(ErrBinding).Status, err.Error())
+				return
+			}
+
+			c.String(http.StatusOK, "ok")
+		})
+
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeJSON)
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			st.Errorf("expected status code to be %d; got %d (%s)", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		if read != int64(len(body)) {
+			st.Errorf("expected read bytes to be %d; got %d", len(body), read)
+		}
+
+		if payload.Name != "nano" {
+			st.Errorf("expected name to be nano; got %s", payload.Name)
+		}
+	})
+
+	t.Run("multipart", func(st *testing.T) {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		writer.WriteField("name", "nano")
+		writer.Close()
+		size := int64(body.Len())
+
+		var read int64
+		var payload Payload
+
+		app := New()
+		app.POST("/", func(c *Context) {
+			if err := c.BindMultipartFormWithProgress(&payload, func(n int64) { read = n }); err != nil {
+				c.String(err.(ErrBinding).Status, err.Error())
+				return
+			}
+
+			c.String(http.StatusOK, "ok")
+		})
+
+		req, err := http.NewRequest(http.MethodPost, "/", body)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, writer.FormDataContentType())
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			st.Errorf("expected status code to be %d; got %d (%s)", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		if read != size {
+			st.Errorf("expected read bytes to be %d; got %d", size, read)
+		}
+
+		if payload.Name != "nano" {
+			st.Errorf("expected name to be nano; got %s", payload.Name)
+		}
+	})
+
+	t.Run("payload limit", func(st *testing.T) {
+		app := New()
+		app.POST("/", PayloadLimit(PayloadLimitConfig{MaxBodySize: 4}), func(c *Context) {
+			var payload Payload
+			if err := c.BindJSONWithProgress(&payload, func(int64) {}); err != nil {
+				c.String(err.(ErrBinding).Status, err.Error())
+				return
+			}
+
+			c.String(http.StatusOK, "ok")
+		})
+
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"nano"}`))
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderContentType, MimeJSON)
+		req.ContentLength = -1
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			st.Errorf("expected status code to be %d; got %d", http.StatusRequestEntityTooLarge, rec.Code)
+		}
+	})
+}
+
+func TestReadRate(t *testing.T) {
+	tt := []struct {
+		name   string
+		delay  time.Duration
+		status int
+	}{
+		{"fast client", 0, http.StatusOK},
+		{"slow client", 5 * time.Millisecond, http.StatusRequestTimeout},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.POST("/", ReadRate(ReadRateConfig{MinBytesPerSecond: 1000, Grace: 20 * time.Millisecond}), func(c *Context) {
+				var payload struct {
+					Name string `json:"name"`
+				}
+
+				if err := c.BindJSON(&payload); err != nil {
+					c.String(err.(ErrBinding).Status, err.Error())
+					return
+				}
+
+				c.String(http.StatusOK, "ok")
+			})
+
+			body := &slowReader{data: []byte(`{"name":"nano nano nano nano"}`), delay: tc.delay}
+			req, err := http.NewRequest(http.MethodPost, "/", body)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d (%s)", tc.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDataFromReader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/download", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	t.Run("known content length", func(st *testing.T) {
+		rec := httptest.NewRecorder()
+		ctx := newContext(rec, req)
+
+		var progress []int64
+		err := ctx.DataFromReaderWithProgress(http.StatusOK, 5, "application/pdf", &slowReader{data: []byte("hello")}, map[string]string{
+			"Content-Disposition": `attachment; filename="hello.pdf"`,
+		}, func(readBytes int64) { progress = append(progress, readBytes) })
+		if err != nil {
+			st.Fatalf("expected err to be nil; got %v", err)
+		}
+
+		if rec.Body.String() != "hello" {
+			st.Errorf("expected body to be hello; got %s", rec.Body.String())
+		}
+
+		for header, value := range map[string]string{
+			HeaderContentType:     "application/pdf",
+			HeaderContentLength:   "5",
+			"Content-Disposition": `attachment; filename="hello.pdf"`,
+		} {
+			if got := rec.Header().Get(header); got != value {
+				st.Errorf("expected %s header to be %s; got %s", header, value, got)
+			}
+		}
+
+		if len(progress) != 5 || progress[4] != 5 {
+			st.Errorf("expected progress to be reported per read up to 5 bytes; got %v", progress)
+		}
+	})
+
+	t.Run("unknown content length", func(st *testing.T) {
+		rec := httptest.NewRecorder()
+		ctx := newContext(rec, req)
+
+		if err := ctx.DataFromReader(http.StatusOK, -1, "", strings.NewReader("hello"), nil); err != nil {
+			st.Fatalf("expected err to be nil; got %v", err)
+		}
+
+		if rec.Header().Get(HeaderContentLength) != "" {
+			st.Errorf("expected content length not to be set; got %s", rec.Header().Get(HeaderContentLength))
+		}
+
+		if rec.Body.String() != "hello" {
+			st.Errorf("expected body to be hello; got %s", rec.Body.String())
+		}
+	})
+}
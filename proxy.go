@@ -0,0 +1,117 @@
+package nano
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// HeaderXForwardedHost is original host requested by client to proxy.
+const HeaderXForwardedHost = "X-Forwarded-Host"
+
+// ProxyConfig defines nano reverse proxy configuration.
+type ProxyConfig struct {
+	// StripPrefix is removed from request path before it's forwarded, e.g. "/legacy".
+	StripPrefix string
+	// Rewrite rewrites request path after StripPrefix, e.g. to map "/users" into "/v1/users".
+	Rewrite func(path string) string
+	// Headers is list of header set on upstream request.
+	Headers map[string]string
+	// PreserveHost forwards client Host header instead of target host.
+	PreserveHost bool
+	// Transport is used to send upstream request, default is http.DefaultTransport.
+	Transport http.RoundTripper
+	// ModifyResponse modifies upstream response before it's sent to client.
+	ModifyResponse func(*http.Response) error
+	// ErrorHandler responds when upstream is unreachable or ModifyResponse fails, default responds 502 status code.
+	ErrorHandler func(c *Context, err error)
+}
+
+// Proxy returns handler that forwards request to target, e.g. app.Any("/legacy/*path", nano.Proxy(target, config)).
+func Proxy(target *url.URL, config ProxyConfig) HandlerFunc {
+	return func(c *Context) {
+		c.Proxy(target, config)
+	}
+}
+
+// Proxy functions to forward current request to target using httputil.ReverseProxy.
+// Target path is joined with the request path, query strings are merged,
+// and X-Forwarded-For, X-Forwarded-Host & X-Forwarded-Proto headers are set.
+func (c *Context) Proxy(target *url.URL, config ProxyConfig) {
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			config.direct(c, target, req)
+		},
+		Transport:      config.Transport,
+		ModifyResponse: config.ModifyResponse,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if config.ErrorHandler != nil {
+				config.ErrorHandler(c, err)
+				return
+			}
+
+			c.String(http.StatusBadGateway, "bad gateway")
+		},
+	}
+
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// direct rewrites outgoing request to target.
+func (config ProxyConfig) direct(c *Context, target *url.URL, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, config.StripPrefix)
+	if config.Rewrite != nil {
+		path = config.Rewrite(path)
+	}
+
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	req.URL.Path = joinURLPath(target.Path, path)
+	// escaped path of the client is no longer valid after rewrite.
+	req.URL.RawPath = ""
+
+	if target.RawQuery == "" || req.URL.RawQuery == "" {
+		req.URL.RawQuery = target.RawQuery + req.URL.RawQuery
+	} else {
+		req.URL.RawQuery = target.RawQuery + "&" + req.URL.RawQuery
+	}
+
+	req.Header.Set(HeaderXForwardedHost, c.Request.Host)
+	if c.IsSecure() {
+		req.Header.Set(HeaderXForwardedProto, "https")
+	} else {
+		req.Header.Set(HeaderXForwardedProto, "http")
+	}
+
+	// explicitly disable User-Agent, so it's not set to default value by http client.
+	if _, ok := req.Header[HeaderUserAgent]; !ok {
+		req.Header.Set(HeaderUserAgent, "")
+	}
+
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if !config.PreserveHost {
+		req.Host = target.Host
+	}
+}
+
+// joinURLPath joins base & path with single slash.
+func joinURLPath(base, path string) string {
+	if path == "" {
+		path = "/"
+	}
+
+	switch {
+	case base == "":
+		return path
+	case strings.HasSuffix(base, "/") && strings.HasPrefix(path, "/"):
+		return base + path[1:]
+	case !strings.HasSuffix(base, "/") && !strings.HasPrefix(path, "/"):
+		return base + "/" + path
+	}
+
+	return base + path
+}
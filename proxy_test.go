@@ -0,0 +1,124 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Upstream", "legacy")
+		fmt.Fprintf(w, "%s %s?%s host=%s forwarded=%s token=%s", r.Method, r.URL.Path, r.URL.RawQuery, r.Host, r.Header.Get(HeaderXForwardedHost), r.Header.Get("X-Token"))
+	}))
+	defer upstream.Close()
+
+	target, err := url.Parse(upstream.URL + "/api?key=1")
+	if err != nil {
+		t.Fatalf("could not parse upstream url: %v", err)
+	}
+
+	app := New()
+	app.Any("/legacy/*path", Proxy(target, ProxyConfig{
+		StripPrefix: "/legacy",
+		Headers:     map[string]string{"X-Token": "secret"},
+	}))
+	app.GET("/v2/users", func(c *Context) {
+		c.Proxy(target, ProxyConfig{
+			Rewrite: func(path string) string { return strings.Replace(path, "/v2", "/v1", 1) },
+		})
+	})
+
+	tt := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/legacy/users?page=2", "GET /api/users?key=1&page=2 host=%s forwarded=nano.test token=secret"},
+		{http.MethodPost, "/legacy/users", "POST /api/users?key=1 host=%s forwarded=nano.test token=secret"},
+		{http.MethodGet, "/v2/users", "GET /api/v1/users?key=1 host=%s forwarded=nano.test token="},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.method+" "+tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Host = "nano.test"
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			expected := fmt.Sprintf(tc.body, target.Host)
+			if rec.Body.String() != expected {
+				st.Errorf("expected body to be %s; got %s", expected, rec.Body.String())
+			}
+
+			if upstreamHeader := rec.Header().Get("X-Upstream"); upstreamHeader != "legacy" {
+				st.Errorf("expected upstream header to be forwarded; got %s", upstreamHeader)
+			}
+		})
+	}
+}
+
+func TestProxyError(t *testing.T) {
+	target, err := url.Parse("http://upstream.test")
+	if err != nil {
+		t.Fatalf("could not parse upstream url: %v", err)
+	}
+
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	app := New()
+	app.GET("/default", Proxy(target, ProxyConfig{Transport: failing}))
+	app.GET("/custom", Proxy(target, ProxyConfig{
+		Transport: failing,
+		ErrorHandler: func(c *Context, err error) {
+			c.JSON(http.StatusServiceUnavailable, H{"error": err.Error()})
+		},
+	}))
+
+	tt := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/default", http.StatusBadGateway, "bad gateway"},
+		{"/custom", http.StatusServiceUnavailable, `{"error":"connection refused"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+// roundTripFunc is http.RoundTripper implementation using function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,104 @@
+package nano
+
+import (
+	"strconv"
+	"strings"
+)
+
+// QueryInt parses url query as int, it returns error when query is empty or not a valid integer.
+func (c *Context) QueryInt(key string) (int, error) {
+	return strconv.Atoi(c.Query(key))
+}
+
+// QueryIntDefault returns default value when url query is empty or not a valid integer.
+func (c *Context) QueryIntDefault(key string, defaultValue int) int {
+	v, err := c.QueryInt(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return v
+}
+
+// QueryInt64 parses url query as int64.
+func (c *Context) QueryInt64(key string) (int64, error) {
+	return strconv.ParseInt(c.Query(key), 10, 64)
+}
+
+// QueryInt64Default returns default value when url query is empty or not a valid int64.
+func (c *Context) QueryInt64Default(key string, defaultValue int64) int64 {
+	v, err := c.QueryInt64(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return v
+}
+
+// QueryBool parses url query as bool, accepted values are the same as strconv.ParseBool.
+func (c *Context) QueryBool(key string) (bool, error) {
+	return strconv.ParseBool(c.Query(key))
+}
+
+// QueryBoolDefault returns default value when url query is empty or not a valid bool.
+func (c *Context) QueryBoolDefault(key string, defaultValue bool) bool {
+	v, err := c.QueryBool(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return v
+}
+
+// QueryFloat parses url query as float64.
+func (c *Context) QueryFloat(key string) (float64, error) {
+	return strconv.ParseFloat(c.Query(key), 64)
+}
+
+// QueryFloatDefault returns default value when url query is empty or not a valid float.
+func (c *Context) QueryFloatDefault(key string, defaultValue float64) float64 {
+	v, err := c.QueryFloat(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return v
+}
+
+// ParamInt parses route parameter as int.
+func (c *Context) ParamInt(key string) (int, error) {
+	return strconv.Atoi(c.Param(key))
+}
+
+// QueryArray returns all values of repeated url query, both ?id=1&id=2 and ?id[]=1&id[]=2 are supported.
+func (c *Context) QueryArray(key string) []string {
+	query := c.Request.URL.Query()
+	values := make([]string, 0, len(query[key])+len(query[key+"[]"]))
+
+	values = append(values, query[key]...)
+	values = append(values, query[key+"[]"]...)
+
+	return values
+}
+
+// QueryMap returns bracketed url query as map, e.g. ?filter[status]=active&filter[type]=book
+// became {"status": "active", "type": "book"}.
+func (c *Context) QueryMap(key string) map[string]string {
+	values := make(map[string]string)
+	prefix := key + "["
+
+	for name, value := range c.Request.URL.Query() {
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, "]") || len(value) == 0 {
+			continue
+		}
+
+		mapKey := name[len(prefix) : len(name)-1]
+		if mapKey == "" || strings.ContainsAny(mapKey, "[]") {
+			continue
+		}
+
+		values[mapKey] = value[0]
+	}
+
+	return values
+}
@@ -0,0 +1,73 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTypedQuery(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/?page=2&limit=abc&id=9007199254740993&active=true&ratio=0.5", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+	ctx.Params = map[string]string{"id": "12"}
+
+	if page, err := ctx.QueryInt("page"); err != nil || page != 2 {
+		t.Errorf("expected page to be 2; got %d (%v)", page, err)
+	}
+
+	if _, err := ctx.QueryInt("limit"); err == nil {
+		t.Errorf("expected invalid integer to return error")
+	}
+
+	if limit := ctx.QueryIntDefault("limit", 10); limit != 10 {
+		t.Errorf("expected invalid limit to fallback to 10; got %d", limit)
+	}
+
+	if id := ctx.QueryInt64Default("id", 0); id != 9007199254740993 {
+		t.Errorf("expected id to be 9007199254740993; got %d", id)
+	}
+
+	if active := ctx.QueryBoolDefault("active", false); !active {
+		t.Errorf("expected active to be true")
+	}
+
+	if ratio := ctx.QueryFloatDefault("ratio", 1); ratio != 0.5 {
+		t.Errorf("expected ratio to be 0.5; got %v", ratio)
+	}
+
+	if missing := ctx.QueryFloatDefault("missing", 1); missing != 1 {
+		t.Errorf("expected missing query to fallback to 1; got %v", missing)
+	}
+
+	if id, err := ctx.ParamInt("id"); err != nil || id != 12 {
+		t.Errorf("expected route parameter id to be 12; got %d (%v)", id, err)
+	}
+}
+
+func TestQueryArrayAndMap(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/?id=1&id=2&id[]=3&filter[status]=active&filter[type]=book&filter=x&filter[a][b]=c", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	if ids := ctx.QueryArray("id"); !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("expected ids to be [1 2 3]; got %v", ids)
+	}
+
+	if empty := ctx.QueryArray("missing"); len(empty) != 0 {
+		t.Errorf("expected missing query array to be empty; got %v", empty)
+	}
+
+	expected := map[string]string{"status": "active", "type": "book"}
+	if filter := ctx.QueryMap("filter"); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("expected filter to be %v; got %v", expected, filter)
+	}
+}
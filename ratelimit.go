@@ -0,0 +1,283 @@
+package nano
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderRateLimitLimit is maximum requests allowed in current window.
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+	// HeaderRateLimitRemaining is remaining requests in current window.
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderRateLimitReset is unix time when current window resets.
+	HeaderRateLimitReset = "X-RateLimit-Reset"
+	// HeaderRetryAfter is number of seconds client should wait before retrying.
+	HeaderRetryAfter = "Retry-After"
+)
+
+// minRateLimitSweepInterval is minimum interval between expired counters removal.
+const minRateLimitSweepInterval = time.Second
+
+// RateLimit defines number of requests allowed in a time window.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// withDefaults returns rate limit with default window of 1 minute when it's not set.
+func (limit RateLimit) withDefaults() RateLimit {
+	if limit.Window <= 0 {
+		limit.Window = time.Minute
+	}
+
+	return limit
+}
+
+// RateLimitProvider provides per-key rate limit overrides, e.g. plans of each tenant loaded from database.
+type RateLimitProvider interface {
+	RateLimits() (map[string]RateLimit, error)
+}
+
+// RateLimitConfig defines nano rate limit middleware configuration.
+type RateLimitConfig struct {
+	// Default is rate limit used for key that has no override, its Requests is required
+	// and its Window defaults to 1 minute.
+	Default RateLimit
+	// KeyFunc extracts rate limit key from request, default is client ip address.
+	KeyFunc func(c *Context) string
+	// Provider provides per-key overrides, it's optional.
+	Provider RateLimitProvider
+	// RefreshInterval is how often overrides are reloaded from provider, default is 1 minute.
+	RefreshInterval time.Duration
+}
+
+// rateCounter counts requests of single key in fixed window.
+type rateCounter struct {
+	count int
+	reset time.Time
+}
+
+// RateLimiter limits number of requests per key using fixed window counter.
+type RateLimiter struct {
+	config      RateLimitConfig
+	mu          sync.Mutex
+	overrides   map[string]RateLimit
+	counters    map[string]*rateCounter
+	refreshedAt time.Time
+	refreshing  bool
+	sweepAt     time.Time
+}
+
+// NewRateLimiter creates rate limiter, overrides are loaded from provider immediately.
+// It panics when config.Default.Requests isn't positive.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.Default.Requests <= 0 {
+		panic("rate limit requires positive default requests")
+	}
+
+	config.Default = config.Default.withDefaults()
+
+	if config.KeyFunc == nil {
+		config.KeyFunc = KeyByClientIP
+	}
+
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = time.Minute
+	}
+
+	rl := &RateLimiter{
+		config:    config,
+		overrides: make(map[string]RateLimit),
+		counters:  make(map[string]*rateCounter),
+	}
+
+	if config.Provider != nil {
+		rl.refresh()
+	}
+
+	return rl
+}
+
+// KeyByClientIP uses client ip address as rate limit key.
+func KeyByClientIP(c *Context) string {
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+
+	return host
+}
+
+// KeyByHeader uses request header value (e.g. X-API-Key) as rate limit key.
+func KeyByHeader(name string) func(c *Context) string {
+	return func(c *Context) string {
+		return c.GetRequestHeader(name)
+	}
+}
+
+// KeyByBag uses context bag value (e.g. tenant id stored by tenant middleware) as rate limit key.
+func KeyByBag(key string) func(c *Context) string {
+	return func(c *Context) string {
+		if value := c.Bag.Get(key); value != nil {
+			return fmt.Sprint(value)
+		}
+
+		return ""
+	}
+}
+
+// refresh reloads overrides from provider. previous overrides are kept when provider fails.
+// override without positive Requests is ignored, and its Window defaults to 1 minute.
+func (rl *RateLimiter) refresh() {
+	overrides, err := rl.config.Provider.RateLimits()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refreshedAt = time.Now()
+	rl.refreshing = false
+
+	if err != nil {
+		log.Printf("[rate limit] could not refresh rate limit overrides: %v\n", err)
+		return
+	}
+
+	rl.overrides = make(map[string]RateLimit, len(overrides))
+	for key, limit := range overrides {
+		if limit.Requests <= 0 {
+			log.Printf("[rate limit] ignoring rate limit override of %s without positive requests\n", key)
+			continue
+		}
+
+		rl.overrides[key] = limit.withDefaults()
+	}
+}
+
+// limitOf returns rate limit of given key.
+func (rl *RateLimiter) limitOf(key string) RateLimit {
+	if limit, ok := rl.overrides[key]; ok {
+		return limit
+	}
+
+	return rl.config.Default
+}
+
+// Allow counts request of given key and returns true when it's still within the limit.
+// It also returns the applied limit, remaining requests, and the window reset time.
+func (rl *RateLimiter) Allow(key string) (bool, RateLimit, int, time.Time) {
+	now := time.Now()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// reload overrides in background, so request doesn't wait for the provider.
+	if rl.config.Provider != nil && !rl.refreshing && now.Sub(rl.refreshedAt) >= rl.config.RefreshInterval {
+		rl.refreshing = true
+		go rl.refresh()
+	}
+
+	rl.sweep(now)
+
+	limit := rl.limitOf(key)
+	counter, ok := rl.counters[key]
+	if !ok || !now.Before(counter.reset) {
+		counter = &rateCounter{reset: now.Add(limit.Window)}
+		rl.counters[key] = counter
+	}
+
+	if counter.count >= limit.Requests {
+		return false, limit, 0, counter.reset
+	}
+
+	counter.count++
+
+	return true, limit, limit.Requests - counter.count, counter.reset
+}
+
+// sweep removes expired counters, so idle keys don't hold memory forever.
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Before(rl.sweepAt) {
+		return
+	}
+
+	for key, counter := range rl.counters {
+		if !now.Before(counter.reset) {
+			delete(rl.counters, key)
+		}
+	}
+
+	interval := rl.config.Default.Window
+	if interval < minRateLimitSweepInterval {
+		interval = minRateLimitSweepInterval
+	}
+
+	rl.sweepAt = now.Add(interval)
+}
+
+// SetDefault functions to replace rate limit of keys that have no override while serving requests,
+// e.g. on config reload. Counters of the current window are kept.
+// limit without positive Requests is ignored, and its Window defaults to 1 minute.
+func (rl *RateLimiter) SetDefault(limit RateLimit) {
+	if limit.Requests <= 0 {
+		log.Printf("[rate limit] ignoring default rate limit without positive requests\n")
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.config.Default = limit.withDefaults()
+}
+
+// Stats returns number of requests counted in current window of each key.
+func (rl *RateLimiter) Stats() map[string]int {
+	now := time.Now()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	stats := make(map[string]int, len(rl.counters))
+	for key, counter := range rl.counters {
+		if now.Before(counter.reset) {
+			stats[key] = counter.count
+		}
+	}
+
+	return stats
+}
+
+// Handle limits incoming request, it responds 429 when client exceeds the limit.
+// Request with empty key is not limited.
+func (rl *RateLimiter) Handle(c *Context) {
+	key := rl.config.KeyFunc(c)
+	if key == "" {
+		c.Next()
+		return
+	}
+
+	allowed, limit, remaining, reset := rl.Allow(key)
+
+	c.SetHeader(HeaderRateLimitLimit, strconv.Itoa(limit.Requests))
+	c.SetHeader(HeaderRateLimitRemaining, strconv.Itoa(remaining))
+	c.SetHeader(HeaderRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+
+	if !allowed {
+		retryAfter := int(time.Until(reset).Seconds() + 1)
+		c.SetHeader(HeaderRetryAfter, strconv.Itoa(retryAfter))
+		c.String(http.StatusTooManyRequests, "too many requests")
+		return
+	}
+
+	c.Next()
+}
+
+// RateLimitWithConfig returns rate limit middleware.
+func RateLimitWithConfig(config RateLimitConfig) HandlerFunc {
+	return NewRateLimiter(config).Handle
+}
@@ -0,0 +1,139 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type staticRateLimitProvider map[string]RateLimit
+
+func (p staticRateLimitProvider) RateLimits() (map[string]RateLimit, error) {
+	return p, nil
+}
+
+func TestRateLimit(t *testing.T) {
+	app := New()
+	app.Use(func(c *Context) {
+		c.Bag.Set("tenant", c.GetRequestHeader("X-Tenant"))
+		c.Next()
+	})
+
+	limiter := NewRateLimiter(RateLimitConfig{
+		Default:  RateLimit{Requests: 1, Window: time.Minute},
+		KeyFunc:  KeyByBag("tenant"),
+		Provider: staticRateLimitProvider{"premium": {Requests: 3, Window: time.Minute}},
+	})
+	app.Use(limiter.Handle)
+
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	tt := []struct {
+		tenant   string
+		requests int
+		allowed  int
+	}{
+		{"free", 3, 1},
+		{"premium", 5, 3},
+	}
+
+	for _, tc := range tt {
+		allowed := 0
+
+		for i := 0; i < tc.requests; i++ {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set("X-Tenant", tc.tenant)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code == http.StatusOK {
+				allowed++
+				continue
+			}
+
+			if rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("expected status code to be 429; got %d", rec.Code)
+			}
+
+			if rec.Header().Get(HeaderRetryAfter) == "" {
+				t.Errorf("expected retry after header to be set")
+			}
+		}
+
+		if allowed != tc.allowed {
+			t.Errorf("expected %s tenant to be allowed %d times; got %d", tc.tenant, tc.allowed, allowed)
+		}
+	}
+
+	if stats := limiter.Stats(); stats["premium"] != 3 {
+		t.Errorf("expected premium counter to be 3; got %v", stats)
+	}
+}
+
+func TestRateLimiterDefaults(t *testing.T) {
+	t.Run("default requests is required", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected rate limiter without default requests to panic")
+			}
+		}()
+
+		NewRateLimiter(RateLimitConfig{})
+	})
+
+	limiter := NewRateLimiter(RateLimitConfig{Default: RateLimit{Requests: 1}})
+	if limiter.config.Default.Window != time.Minute {
+		t.Errorf("expected default window to be 1 minute; got %v", limiter.config.Default.Window)
+	}
+
+	now := time.Now()
+	limiter.sweep(now)
+	if limiter.sweepAt.Sub(now) != time.Minute {
+		t.Errorf("expected next sweep to be after 1 minute; got %v", limiter.sweepAt.Sub(now))
+	}
+
+	limiter.SetDefault(RateLimit{Requests: 5, Window: time.Millisecond})
+	limiter.sweep(limiter.sweepAt)
+	if interval := limiter.sweepAt.Sub(now.Add(time.Minute)); interval != minRateLimitSweepInterval {
+		t.Errorf("expected sweep interval to be at least %v; got %v", minRateLimitSweepInterval, interval)
+	}
+
+	limiter.SetDefault(RateLimit{})
+	if limiter.config.Default.Requests != 5 {
+		t.Errorf("expected invalid default rate limit to be ignored; got %+v", limiter.config.Default)
+	}
+}
+
+func TestRateLimiterOverrideDefaults(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{
+		Default: RateLimit{Requests: 5, Window: time.Minute},
+		Provider: staticRateLimitProvider{
+			"no-window":   {Requests: 1},
+			"no-requests": {Window: time.Minute},
+		},
+	})
+
+	if limit := limiter.limitOf("no-window"); limit.Window != time.Minute {
+		t.Errorf("expected override window to default to 1 minute; got %v", limit.Window)
+	}
+
+	if allowed, _, _, _ := limiter.Allow("no-window"); !allowed {
+		t.Errorf("expected first request to be allowed")
+	}
+
+	if allowed, _, _, _ := limiter.Allow("no-window"); allowed {
+		t.Errorf("expected override without window to be limited")
+	}
+
+	if limit := limiter.limitOf("no-requests"); limit != limiter.config.Default {
+		t.Errorf("expected override without requests to be ignored; got %+v", limit)
+	}
+}
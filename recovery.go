@@ -1,14 +1,55 @@
 package nano
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"runtime"
+	"syscall"
 )
 
+// RecoveryRecord describes recovered panic, it's passed to RecoveryConfig.LogFunc.
+type RecoveryRecord struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Error  string `json:"error"`
+	Stack  string `json:"stack,omitempty"`
+	// BrokenPipe is true when panic is caused by client closing the connection.
+	BrokenPipe bool `json:"broken_pipe"`
+	// Aborted is true when handler panics with http.ErrAbortHandler to abort the connection.
+	Aborted bool `json:"aborted,omitempty"`
+}
+
+// RecoveryConfig defines nano recovery middleware configuration.
+type RecoveryConfig struct {
+	// StackSize is maximum size of printed stack trace in bytes, default is 4KB.
+	StackSize int
+	// DisableStackAll prints stack trace of current goroutine only, instead of all goroutines.
+	DisableStackAll bool
+	// LogFunc logs recovered panic. default logs it as error using Context.Logger, so the record has request id.
+	LogFunc func(record RecoveryRecord)
+	// Handler writes response of recovered panic. default responds 500 status code,
+	// in json when client accepts json and in plain text otherwise. in debug mode, the response includes
+	// the error and stack trace.
+	Handler func(c *Context, err error)
+}
+
 // Recovery is middleware to recover panic.
 func Recovery() HandlerFunc {
+	return RecoveryWithConfig(RecoveryConfig{})
+}
+
+// RecoveryWithConfig returns middleware to recover panic using given configuration.
+// Panic caused by client closing the connection (broken pipe) is logged without stack trace
+// and no response is written, since nobody is there to read it.
+// http.ErrAbortHandler is logged without stack trace and re-panicked, so net/http aborts the connection.
+// Panicked *HTTPError is responded like Context.AbortWithError, using the error handler of the engine.
+func RecoveryWithConfig(config RecoveryConfig) HandlerFunc {
+	if config.StackSize <= 0 {
+		config.StackSize = 4 << 10
+	}
+
 	return func(c *Context) {
 
 		// defered call
@@ -20,18 +61,97 @@ func Recovery() HandlerFunc {
 					err = fmt.Errorf("%v", recovered)
 				}
 
-				// Create 1kb stack size.
-				stacks := make([]byte, 1024)
-				length := runtime.Stack(stacks, true)
+				c.emit(EventHandlerPanicked, 0, recovered)
+
+				// panicked http error is intentional, so it's responded like AbortWithError without stack trace.
+				var httpErr *HTTPError
+				if errors.As(err, &httpErr) {
+					c.Abort()
+					c.respondError(httpErr)
+					return
+				}
 
-				// print error and stack trace.
-				log.Printf("[recovered] %v\n\nTrace %s\n", err, stacks[:length])
+				record := RecoveryRecord{
+					Method:     c.Method,
+					Path:       c.Path,
+					Error:      err.Error(),
+					BrokenPipe: isBrokenPipe(err),
+					Aborted:    errors.Is(err, http.ErrAbortHandler),
+				}
+
+				if !record.BrokenPipe && !record.Aborted {
+					stacks := make([]byte, config.StackSize)
+					length := runtime.Stack(stacks, !config.DisableStackAll)
+					record.Stack = string(stacks[:length])
+				}
+
+				if config.LogFunc != nil {
+					config.LogFunc(record)
+				} else {
+					logRecovery(c, record)
+				}
+
+				// net/http relies on the panic to abort the connection, so client could tell the response is incomplete.
+				if record.Aborted {
+					panic(recovered)
+				}
+
+				if record.BrokenPipe {
+					return
+				}
 
-				// response
-				c.String(http.StatusInternalServerError, "500 Internal Server Error")
+				if config.Handler != nil {
+					config.Handler(c, err)
+					return
+				}
+
+				respondRecovery(c, record)
 			}
 		}()
 
 		c.Next()
 	}
 }
+
+// isBrokenPipe returns true when err is caused by client closing the connection.
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// logRecovery logs recovered panic and its stack trace using request-scoped logger.
+func logRecovery(c *Context, record RecoveryRecord) {
+	if record.BrokenPipe {
+		c.Logger().Warn("client closed connection", slog.String("error", record.Error))
+		return
+	}
+
+	if record.Aborted {
+		c.Logger().Warn("handler aborted", slog.String("error", record.Error))
+		return
+	}
+
+	c.Logger().Error("panic recovered", slog.String("error", record.Error), slog.String("stack", record.Stack))
+}
+
+// respondRecovery responds 500 status code in format accepted by client.
+// panic details are only exposed in debug mode.
+func respondRecovery(c *Context, record RecoveryRecord) {
+	debug := c.engine != nil && c.engine.debug
+
+	if c.NegotiateFormat(MimePlainText, MimeJSON) == MimeJSON {
+		if debug {
+			c.JSON(http.StatusInternalServerError, H{"error": "internal server error", "panic": record.Error, "stack": record.Stack})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, H{"error": "internal server error"})
+		return
+	}
+
+	if debug {
+		c.String(http.StatusInternalServerError, "500 Internal Server Error\n\n%s\n\n%s", record.Error, record.Stack)
+		return
+	}
+
+	c.String(http.StatusInternalServerError, "500 Internal Server Error")
+}
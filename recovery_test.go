@@ -0,0 +1,129 @@
+package nano
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestRecoveryWithConfig(t *testing.T) {
+	brokenPipe := &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}
+
+	tt := []struct {
+		name       string
+		panic      interface{}
+		accept     string
+		status     int
+		body       string
+		brokenPipe bool
+	}{
+		{"plain text response", "boom", "", http.StatusInternalServerError, "500 Internal Server Error", false},
+		{"json response", errors.New("boom"), MimeJSON, http.StatusInternalServerError, `{"error":"internal server error"}`, false},
+		{"broken pipe", brokenPipe, "", http.StatusOK, "", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			var record RecoveryRecord
+
+			app := New()
+			app.Use(RecoveryWithConfig(RecoveryConfig{
+				StackSize:       512,
+				DisableStackAll: true,
+				LogFunc:         func(r RecoveryRecord) { record = r },
+			}))
+			app.GET("/panic", func(c *Context) {
+				panic(tc.panic)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "/panic", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderAccept, tc.accept)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, rec.Body.String())
+			}
+
+			if record.BrokenPipe != tc.brokenPipe {
+				st.Errorf("expected broken pipe to be %v; got %v", tc.brokenPipe, record.BrokenPipe)
+			}
+
+			if record.Path != "/panic" {
+				st.Errorf("expected logged path to be /panic; got %s", record.Path)
+			}
+
+			if !tc.brokenPipe && (len(record.Stack) == 0 || len(record.Stack) > 512 || !strings.Contains(record.Stack, "goroutine")) {
+				st.Errorf("expected stack trace of current goroutine within 512 bytes; got %d bytes", len(record.Stack))
+			}
+		})
+	}
+}
+
+func TestRecoveryAbortHandler(t *testing.T) {
+	var record RecoveryRecord
+
+	app := New()
+	app.Use(RecoveryWithConfig(RecoveryConfig{
+		LogFunc: func(r RecoveryRecord) { record = r },
+	}))
+	app.GET("/", func(c *Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to be re-panicked; got %v", recovered)
+		}
+
+		if !record.Aborted || record.BrokenPipe || record.Stack != "" {
+			t.Errorf("expected aborted handler to be logged without stack trace; got %+v", record)
+		}
+	}()
+
+	app.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestRecoveryHandler(t *testing.T) {
+	app := New()
+	app.Use(RecoveryWithConfig(RecoveryConfig{
+		LogFunc: func(RecoveryRecord) {},
+		Handler: func(c *Context, err error) {
+			c.String(http.StatusServiceUnavailable, "recovered: %v", err)
+		},
+	}))
+	app.GET("/", func(c *Context) {
+		panic("boom")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.String() != "recovered: boom" {
+		t.Errorf("expected custom handler response; got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,119 @@
+package nano
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SetRedirectTrailingSlash functions to redirect request whose path differs from matching route only
+// by trailing slash, e.g. /users/ into /users. GET & HEAD requests are redirected using 301 status code,
+// other methods using 308 so the method and body are preserved.
+// It's disabled by default, both paths are served by the same route.
+func (ng *Engine) SetRedirectTrailingSlash(enabled bool) {
+	ng.redirectTrailingSlash = enabled
+}
+
+// SetRedirectFixedPath functions to redirect request into fixed path, that is cleaned path
+// (e.g. /users//1/../2 into /users/2) whose static parts are written in letter case of matching route
+// (e.g. /USERS into /users). It uses the same status codes as SetRedirectTrailingSlash.
+func (ng *Engine) SetRedirectFixedPath(enabled bool) {
+	ng.redirectFixedPath = enabled
+}
+
+// SetCaseInsensitiveRouting functions to match static parts of request path regardless of letter case,
+// the request is served by matching route without redirect. parameter values keep their letter case.
+func (ng *Engine) SetCaseInsensitiveRouting(enabled bool) {
+	ng.caseInsensitiveRouting = enabled
+}
+
+// matchFixed looks up route of request path ignoring letter case, the path is cleaned first
+// when RedirectFixedPath is enabled. it returns given match when there is nothing to fix.
+func (r *router) matchFixed(c *Context, requestPath string, match RouteMatch, ok bool) (RouteMatch, bool) {
+	urlPath := requestPath
+	if c.engine.redirectFixedPath {
+		urlPath = cleanPath(urlPath)
+	}
+
+	if ok && urlPath == requestPath {
+		return match, ok
+	}
+
+	if !c.engine.redirectFixedPath && !c.engine.caseInsensitiveRouting {
+		return match, ok
+	}
+
+	if fixed, found := r.match(c.Method, urlPath, true); found {
+		return fixed, true
+	}
+
+	return match, ok
+}
+
+// redirectCanonical redirects request into path of matching route pattern according to enabled redirect options.
+// it returns false when request path is already canonical.
+func (r *router) redirectCanonical(c *Context, requestPath, pattern string) bool {
+	target := requestPath
+	if c.engine.redirectFixedPath {
+		target = fixPath(target, pattern)
+	}
+
+	// catch-all parameter owns the trailing slash.
+	if c.engine.redirectTrailingSlash && target != "/" && !hasCatchAll(pattern) {
+		target = strings.TrimSuffix(target, "/")
+
+		if pattern != "/" && strings.HasSuffix(pattern, "/") {
+			target += "/"
+		}
+	}
+
+	if target == requestPath {
+		return false
+	}
+
+	if c.Request.URL.RawQuery != "" {
+		target += "?" + c.Request.URL.RawQuery
+	}
+
+	status := http.StatusMovedPermanently
+	if c.Method != http.MethodGet && c.Method != http.MethodHead {
+		status = http.StatusPermanentRedirect
+	}
+
+	c.Redirect(status, c.engine.basePath+target)
+
+	return true
+}
+
+// cleanPath returns shortest path equivalent to url path, trailing slash is kept.
+func cleanPath(urlPath string) string {
+	cleaned := path.Clean("/" + urlPath)
+	if strings.HasSuffix(urlPath, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
+
+// fixPath returns cleaned url path whose static parts are written in letter case of the pattern.
+func fixPath(urlPath, pattern string) string {
+	cleaned := cleanPath(urlPath)
+	parts := strings.Split(strings.Trim(cleaned, "/"), "/")
+
+	for i, part := range createURLParts(pattern) {
+		if i >= len(parts) || isCatchAllPart(part) {
+			break
+		}
+
+		if !isParamPart(part) {
+			parts[i] = part
+		}
+	}
+
+	fixed := "/" + strings.Join(parts, "/")
+	if strings.HasSuffix(cleaned, "/") && fixed != "/" {
+		fixed += "/"
+	}
+
+	return fixed
+}
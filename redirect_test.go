@@ -0,0 +1,79 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectOptions(t *testing.T) {
+	tt := []struct {
+		name          string
+		trailingSlash bool
+		fixedPath     bool
+		ignoreCase    bool
+		method        string
+		url           string
+		status        int
+		location      string
+		body          string
+	}{
+		{"trailing slash is served when disabled", false, false, false, http.MethodGet, "/users/", http.StatusOK, "", "users"},
+		{"trailing slash is removed", true, false, false, http.MethodGet, "/users/?page=2", http.StatusMovedPermanently, "/users?page=2", ""},
+		{"trailing slash is added", true, false, false, http.MethodGet, "/docs", http.StatusMovedPermanently, "/docs/", ""},
+		{"canonical path is served", true, false, false, http.MethodGet, "/users", http.StatusOK, "", "users"},
+		{"post is redirected preserving method", true, false, false, http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users", ""},
+		{"catch-all owns trailing slash", true, false, false, http.MethodGet, "/files/a/", http.StatusOK, "", "a"},
+		{"letter case is not found when disabled", false, false, false, http.MethodGet, "/USERS/42", http.StatusNotFound, "", "nano/1.0 not found"},
+		{"letter case is fixed", false, true, false, http.MethodGet, "/USERS/Ab", http.StatusMovedPermanently, "/users/Ab", ""},
+		{"path is cleaned", false, true, false, http.MethodGet, "/docs//x/../", http.StatusMovedPermanently, "/docs/", ""},
+		{"fixed path with trailing slash", true, true, false, http.MethodGet, "/Users/", http.StatusMovedPermanently, "/users", ""},
+		{"case insensitive routing", false, false, true, http.MethodGet, "/USERS/Ab", http.StatusOK, "", "user Ab"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.SetRedirectTrailingSlash(tc.trailingSlash)
+			app.SetRedirectFixedPath(tc.fixedPath)
+			app.SetCaseInsensitiveRouting(tc.ignoreCase)
+
+			app.GET("/users", func(c *Context) {
+				c.String(http.StatusOK, "users")
+			})
+			app.POST("/users", func(c *Context) {
+				c.String(http.StatusCreated, "created")
+			})
+			app.GET("/users/:id", func(c *Context) {
+				c.String(http.StatusOK, "user %s", c.Param("id"))
+			})
+			app.GET("/docs/", func(c *Context) {
+				c.String(http.StatusOK, "docs")
+			})
+			app.GET("/files/*path", func(c *Context) {
+				c.String(http.StatusOK, c.Param("path"))
+			})
+
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if location := rec.Header().Get("Location"); location != tc.location {
+				st.Errorf("expected location to be %s; got %s", tc.location, location)
+			}
+
+			if tc.body != "" && rec.Body.String() != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package nano
+
+import (
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// configReload holds hooks registered by OnConfigReload.
+type configReload struct {
+	mu    sync.Mutex
+	hooks []func() error
+}
+
+// OnConfigReload functions to register hook called by ReloadConfig, e.g. to re-read config file and apply it
+// using CORS.Update or RateLimiter.SetDefault. Run listens SIGHUP signal to call ReloadConfig,
+// so configuration is changed without restarting the server.
+func (ng *Engine) OnConfigReload(hook func() error) {
+	ng.reload.mu.Lock()
+	defer ng.reload.mu.Unlock()
+
+	ng.reload.hooks = append(ng.reload.hooks, hook)
+}
+
+// ReloadConfig functions to call hooks registered by OnConfigReload in registration order.
+// Failing hook doesn't stop the next hooks, errors of all failing hooks are joined.
+// Reloads are serialized, so hooks never run concurrently.
+func (ng *Engine) ReloadConfig() error {
+	ng.reload.mu.Lock()
+	defer ng.reload.mu.Unlock()
+
+	var errs []error
+	for _, hook := range ng.reload.hooks {
+		if err := hook(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// watchReloadSignal calls ReloadConfig each time the process receives SIGHUP, until returned stop function is called.
+func (ng *Engine) watchReloadSignal() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				if err := ng.ReloadConfig(); err != nil {
+					ng.taskLogger().Error("config reload failed", slog.Any("error", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// CertReloader serves TLS certificate which could be reloaded from its files, e.g. after renewal.
+// Set GetCertificate as tls.Config.GetCertificate, and register Reload using OnConfigReload.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+}
+
+// NewCertReloader creates certificate reloader, the certificate is loaded immediately.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload functions to load certificate from its files, previous certificate is kept when loading fails.
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+
+	return nil
+}
+
+// GetCertificate returns the last loaded certificate, it implements tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
@@ -0,0 +1,148 @@
+package nano
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes self-signed certificate of common name into certFile & keyFile.
+func writeCertificate(certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		log.Fatalf("could not generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		log.Fatalf("could not create certificate: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		log.Fatalf("could not marshal key: %v", err)
+	}
+
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+}
+
+func TestReloadConfig(t *testing.T) {
+	cors := NewCORS(CORSConfig{AllowedOrigins: []string{"https://a.example"}})
+	limiter := NewRateLimiter(RateLimitConfig{Default: RateLimit{Requests: 1, Window: time.Minute}})
+
+	app := New()
+	app.Use(cors.Handle, limiter.Handle)
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	var calls []string
+
+	app.OnConfigReload(func() error {
+		calls = append(calls, "cors")
+		cors.Update(CORSConfig{AllowedOrigins: []string{"https://b.example"}})
+		return errFirst
+	})
+	app.OnConfigReload(func() error {
+		calls = append(calls, "rate limit")
+		limiter.SetDefault(RateLimit{Requests: 5, Window: time.Minute})
+		return errSecond
+	})
+
+	request := func() *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		req.Header.Set(HeaderOrigin, "https://b.example")
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if rec := request(); rec.Header().Get(HeaderAccessControlAllowOrigin) != "" {
+		t.Errorf("expected origin not to be allowed before reload")
+	}
+
+	if rec := request(); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected second request to be limited before reload; got %d", rec.Code)
+	}
+
+	err := app.ReloadConfig()
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("expected errors of all hooks to be joined; got %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "cors" || calls[1] != "rate limit" {
+		t.Errorf("expected hooks to be called in registration order; got %v", calls)
+	}
+
+	rec := request()
+	if rec.Header().Get(HeaderAccessControlAllowOrigin) != "https://b.example" {
+		t.Errorf("expected reloaded origin to be allowed; got %q", rec.Header().Get(HeaderAccessControlAllowOrigin))
+	}
+
+	if rec.Code != http.StatusOK || rec.Header().Get(HeaderRateLimitLimit) != "5" {
+		t.Errorf("expected reloaded rate limit to be applied; got %d with limit %s", rec.Code, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	if _, err := NewCertReloader(certFile, keyFile); err == nil {
+		t.Fatalf("expected missing certificate to fail")
+	}
+
+	writeCertificate(certFile, keyFile, "old.example")
+
+	certs, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("expected certificate to be loaded; got %v", err)
+	}
+
+	commonName := func() string {
+		cert, _ := certs.GetCertificate(nil)
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			log.Fatalf("could not parse certificate: %v", err)
+		}
+
+		return parsed.Subject.CommonName
+	}
+
+	writeCertificate(certFile, keyFile, "new.example")
+	if err := certs.Reload(); err != nil || commonName() != "new.example" {
+		t.Errorf("expected renewed certificate to be served; got %s, %v", commonName(), err)
+	}
+
+	os.WriteFile(keyFile, []byte("broken"), 0600)
+	if err := certs.Reload(); err == nil || commonName() != "new.example" {
+		t.Errorf("expected failed reload to keep previous certificate; got %s, %v", commonName(), err)
+	}
+}
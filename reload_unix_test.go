@@ -0,0 +1,32 @@
+//go:build unix
+
+package nano
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadSignal(t *testing.T) {
+	reloaded := make(chan struct{}, 1)
+
+	app := New()
+	app.OnConfigReload(func() error {
+		reloaded <- struct{}{}
+		return nil
+	})
+
+	stop := app.watchReloadSignal()
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("could not send SIGHUP: %v", err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Errorf("expected SIGHUP to reload config")
+	}
+}
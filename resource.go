@@ -0,0 +1,87 @@
+package nano
+
+import (
+	"fmt"
+)
+
+// ResourceParam is route parameter name of resource id, e.g. c.Param(nano.ResourceParam) in Show action.
+const ResourceParam = "id"
+
+// ResourceIndexer lists resources, it's registered as GET /resources.
+type ResourceIndexer interface {
+	Index(c *Context)
+}
+
+// ResourceShower shows a resource, it's registered as GET /resources/:id.
+type ResourceShower interface {
+	Show(c *Context)
+}
+
+// ResourceCreator creates a resource, it's registered as POST /resources.
+type ResourceCreator interface {
+	Create(c *Context)
+}
+
+// ResourceUpdater updates a resource, it's registered as PUT /resources/:id and PATCH /resources/:id.
+type ResourceUpdater interface {
+	Update(c *Context)
+}
+
+// ResourceDeleter deletes a resource, it's registered as DELETE /resources/:id.
+type ResourceDeleter interface {
+	Delete(c *Context)
+}
+
+// ResourceController implements all resource actions.
+type ResourceController interface {
+	ResourceIndexer
+	ResourceShower
+	ResourceCreator
+	ResourceUpdater
+	ResourceDeleter
+}
+
+// Resource functions to register RESTful routes of controller actions under urlPath, only implemented actions
+// (see ResourceIndexer, ResourceShower, etc.) are registered, so read-only controller could skip the others.
+// It returns router group of the resource, e.g. to attach middleware or register extra routes.
+// It panics when controller implements no action.
+//
+//	app.Resource("/users", usersController)
+//	// GET /users, POST /users, GET /users/:id, PUT /users/:id, PATCH /users/:id, DELETE /users/:id
+func (rg *RouterGroup) Resource(urlPath string, controller interface{}) *RouterGroup {
+	group := rg.Group(urlPath)
+	member := "/:" + ResourceParam
+	registered := false
+
+	if action, ok := controller.(ResourceIndexer); ok {
+		group.GET("", action.Index)
+		registered = true
+	}
+
+	if action, ok := controller.(ResourceCreator); ok {
+		group.POST("", action.Create)
+		registered = true
+	}
+
+	if action, ok := controller.(ResourceShower); ok {
+		group.GET(member, action.Show)
+		registered = true
+	}
+
+	if action, ok := controller.(ResourceUpdater); ok {
+		group.PUT(member, action.Update)
+		group.PATCH(member, action.Update)
+		registered = true
+	}
+
+	if action, ok := controller.(ResourceDeleter); ok {
+		group.DELETE(member, action.Delete)
+		registered = true
+	}
+
+	if !registered {
+		panic(fmt.Sprintf("resource controller %T of %s implements no action", controller, group.prefix))
+	}
+
+	return group
+}
@@ -0,0 +1,73 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type usersController struct{}
+
+func (usersController) Index(c *Context)  { c.String(http.StatusOK, "index") }
+func (usersController) Show(c *Context)   { c.String(http.StatusOK, "show %s", c.Param(ResourceParam)) }
+func (usersController) Create(c *Context) { c.String(http.StatusCreated, "create") }
+func (usersController) Update(c *Context) { c.String(http.StatusOK, "update %s", c.Param("id")) }
+func (usersController) Delete(c *Context) { c.String(http.StatusNoContent, "") }
+
+type postsController struct{}
+
+func (postsController) Index(c *Context) { c.String(http.StatusOK, "posts") }
+
+func TestResource(t *testing.T) {
+	var _ ResourceController = usersController{}
+
+	app := New()
+	users := app.Group("/api").Resource("/users", usersController{})
+	users.GET("/me", func(c *Context) {
+		c.String(http.StatusOK, "me")
+	})
+	app.Resource("/posts", postsController{})
+
+	tt := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "/api/users", http.StatusOK, "index"},
+		{http.MethodPost, "/api/users", http.StatusCreated, "create"},
+		{http.MethodGet, "/api/users/7", http.StatusOK, "show 7"},
+		{http.MethodPut, "/api/users/7", http.StatusOK, "update 7"},
+		{http.MethodPatch, "/api/users/7", http.StatusOK, "update 7"},
+		{http.MethodDelete, "/api/users/7", http.StatusNoContent, ""},
+		{http.MethodGet, "/api/users/me", http.StatusOK, "me"},
+		{http.MethodGet, "/posts", http.StatusOK, "posts"},
+		{http.MethodDelete, "/posts/1", http.StatusNotFound, "nano/1.0 not found"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.method+" "+tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %q; got %d %q", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("controller without action", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected controller without action to panic")
+			}
+		}()
+
+		New().Resource("/empty", struct{}{})
+	})
+}
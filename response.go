@@ -0,0 +1,91 @@
+package nano
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// responseWriter is the innermost response writer of context. it tracks status code, emits
+// EventResponseCommitted, and guards the response against superfluous status code and concurrent writes.
+type responseWriter struct {
+	http.ResponseWriter
+	c         *Context
+	mu        sync.Mutex
+	status    int
+	committed bool
+}
+
+// Written returns true when response status code is already written, e.g. by c.JSON or c.String,
+// so middleware could check whether handler has responded.
+func (c *Context) Written() bool {
+	if c.response == nil {
+		return false
+	}
+
+	c.response.mu.Lock()
+	defer c.response.mu.Unlock()
+
+	return c.response.committed
+}
+
+// WriteHeader writes status code once, superfluous status code (e.g. c.JSON after c.String) is dropped
+// and logged as warning with the route, instead of the stdlib superfluous WriteHeader message.
+func (w *responseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.writeHeader(code)
+}
+
+// writeHeader writes status code, w.mu must be held.
+func (w *responseWriter) writeHeader(code int) {
+	if w.committed {
+		w.c.Logger().Warn("response status code is already written, superfluous status code is dropped",
+			slog.Int("status", w.status), slog.Int("superfluous_status", code))
+		return
+	}
+
+	w.committed = true
+	w.status = code
+	w.c.emit(EventResponseCommitted, code, nil)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write commits response with 200 status code when status code isn't written yet.
+// concurrent writes, e.g. from goroutines started by handler, are serialized.
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.committed {
+		w.writeHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush implements http.Flusher.
+func (w *responseWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// ErrHijackNotSupported should be returned when response writer can't be hijacked.
+var ErrHijackNotSupported = errors.New("response writer does not support hijacking")
+
+// Hijack implements http.Hijacker.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, ErrHijackNotSupported
+}
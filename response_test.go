@@ -0,0 +1,80 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestResponseGuard(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	app := New()
+	app.SetLogger(slog.New(slog.NewTextHandler(output, nil)))
+
+	var writtenBefore, writtenAfter bool
+	app.Use(func(c *Context) {
+		writtenBefore = c.Written()
+		c.Next()
+		writtenAfter = c.Written()
+	})
+
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusCreated, "created")
+		c.JSON(http.StatusInternalServerError, H{"error": "too late"})
+	})
+
+	app.GET("/concurrent", func(c *Context) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Writer.Write([]byte("x"))
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	serve := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("superfluous status code", func(st *testing.T) {
+		output.Reset()
+		rec := serve("/users/1")
+
+		if rec.Code != http.StatusCreated {
+			st.Errorf("expected status code to be 201; got %d", rec.Code)
+		}
+
+		logged := output.String()
+		if !strings.Contains(logged, "level=WARN") || !strings.Contains(logged, "route=/users/:id") || !strings.Contains(logged, "superfluous_status=500") {
+			st.Errorf("expected warning with route to be logged; got %s", logged)
+		}
+
+		if writtenBefore || !writtenAfter {
+			st.Errorf("expected Written to be false before handler and true after; got %v %v", writtenBefore, writtenAfter)
+		}
+	})
+
+	t.Run("concurrent writes", func(st *testing.T) {
+		if body := serve("/concurrent").Body.String(); body != strings.Repeat("x", 10) {
+			st.Errorf("expected all writes to be kept; got %s", body)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package nano
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rewriteRule is compiled rule of Rewrite middleware.
+type rewriteRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Rewrite returns middleware rewriting request path using rules, so legacy urls are served by current routes
+// without registering them twice. Register it using Engine.Pre, so the path is rewritten before routing.
+//
+// Rule key is either wildcard pattern, whose * matches any characters and is referenced as $1, $2, ... in order,
+// e.g. "/old/*" -> "/new/$1", or regular expression starting with ^, e.g. "^/users/([0-9]+)$" -> "/members/$1".
+// Keys are matched against the whole path, longer keys first, and only the first matching rule is applied.
+// It panics when a regular expression is invalid.
+func Rewrite(rules map[string]string) HandlerFunc {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	compiled := make([]rewriteRule, 0, len(keys))
+	for _, key := range keys {
+		compiled = append(compiled, rewriteRule{pattern: compileRewrite(key), replacement: rules[key]})
+	}
+
+	return func(c *Context) {
+		for _, rule := range compiled {
+			if !rule.pattern.MatchString(c.Request.URL.Path) {
+				continue
+			}
+
+			c.rewritePath(rule.pattern.ReplaceAllString(c.Request.URL.Path, rule.replacement))
+			break
+		}
+
+		c.Next()
+	}
+}
+
+// compileRewrite compiles rule key of Rewrite middleware into anchored regular expression.
+func compileRewrite(key string) *regexp.Regexp {
+	if strings.HasPrefix(key, "^") {
+		return regexp.MustCompile(key)
+	}
+
+	parts := strings.Split(key, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, "(.*)") + "$")
+}
+
+// rewritePath replaces request path, query string in the new path replaces the current one.
+func (c *Context) rewritePath(urlPath string) {
+	if i := strings.IndexByte(urlPath, '?'); i >= 0 {
+		c.Request.URL.RawQuery = urlPath[i+1:]
+		urlPath = urlPath[:i]
+	}
+
+	c.setPath(urlPath, "")
+}
+
+// setPath replaces request path, raw path is the encoded path when it isn't the default encoding.
+func (c *Context) setPath(urlPath, rawPath string) {
+	c.Request.URL.Path = urlPath
+	c.Request.URL.RawPath = rawPath
+	c.Path = urlPath
+}
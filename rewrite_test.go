@@ -0,0 +1,71 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	app := New()
+	app.Pre(Rewrite(map[string]string{
+		"/old/*":              "/new/$1",
+		"/old/users/*/posts":  "/users/$1?tab=posts",
+		"^/members/([0-9]+)$": "/users/$1",
+	}))
+
+	app.Group("/new").Use(func(c *Context) {
+		c.SetHeader("X-Group", "new")
+		c.Next()
+	})
+
+	app.GET("/new/*path", func(c *Context) {
+		c.String(http.StatusOK, "new %s", c.Param("path"))
+	})
+
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "user %s %s", c.Param("id"), c.Query("tab"))
+	})
+
+	tt := []struct {
+		path  string
+		body  string
+		group string
+	}{
+		{"/old/docs/intro", "new docs/intro", "new"},
+		{"/old/users/7/posts", "user 7 posts", ""},
+		{"/members/7", "user 7 ", ""},
+		{"/members/abc", "nano/1.0 not found", ""},
+		{"/users/7", "user 7 ", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); body != tc.body {
+				st.Errorf("expected response body to be %q; got %q", tc.body, body)
+			}
+
+			if group := rec.Header().Get("X-Group"); group != tc.group {
+				st.Errorf("expected group middleware of rewritten path to be called; got %q", group)
+			}
+		})
+	}
+
+	t.Run("invalid regular expression", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected invalid regular expression to panic")
+			}
+		}()
+
+		Rewrite(map[string]string{"^/(": "/"})
+	})
+}
@@ -0,0 +1,279 @@
+package nano
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrRouteName should be returned when there is no route with given name.
+var ErrRouteName = errors.New("route name not found")
+
+// Route defines registered route. It's returned by route registration methods,
+// so middleware & name could be attached to an individual route.
+type Route struct {
+	info        RouteInfo
+	key         string
+	middlewares []HandlerFunc
+	handlers    []HandlerFunc
+	decoders    map[string]ParamDecoder
+	consumes    []string
+	produces    []string
+	router      *router
+	// request & response are payload types of the route, used to describe the api.
+	request  reflect.Type
+	response reflect.Type
+	// override is set by WithTimeout & WithBodyLimit.
+	override *routeOverride
+}
+
+// routeOverride is route-level options overriding middlewares of router groups.
+type routeOverride struct {
+	timeout   time.Duration
+	bodyLimit int64
+}
+
+// Use functions to apply middleware function(s) to this route only.
+// route middlewares are called after router group middlewares and before route handlers.
+func (r *Route) Use(middlewares ...HandlerFunc) *Route {
+	r.middlewares = append(r.middlewares, middlewares...)
+	r.rebuild()
+
+	return r
+}
+
+// DecodeParam functions to decode route parameter before route middlewares & handlers are called,
+// e.g. decode *filepath wildcard using SafePathParam. decoding error is responded with 400 status code.
+func (r *Route) DecodeParam(name string, decoder ParamDecoder) *Route {
+	if r.decoders == nil {
+		r.decoders = make(map[string]ParamDecoder)
+	}
+
+	r.decoders[name] = decoder
+	r.rebuild()
+
+	return r
+}
+
+// WithTimeout functions to limit duration of the route handlers like Timeout middleware,
+// Timeout middleware of router groups is skipped for this route, e.g. to give slow export endpoint longer timeout.
+// Timeout fallback of router group set by OnTimeout is still used.
+func (r *Route) WithTimeout(timeout time.Duration) *Route {
+	r.overrides().timeout = timeout
+	r.rebuild()
+
+	return r
+}
+
+// WithBodyLimit functions to limit request body size of the route like PayloadLimitConfig.MaxBodySize,
+// overriding body size limit of PayloadLimit middleware of router groups. Its json limits are kept.
+func (r *Route) WithBodyLimit(maxBodySize int64) *Route {
+	r.overrides().bodyLimit = maxBodySize
+	r.rebuild()
+
+	return r
+}
+
+// overrides returns route-level options, it's created on first use.
+func (r *Route) overrides() *routeOverride {
+	if r.override == nil {
+		r.override = new(routeOverride)
+	}
+
+	return r.override
+}
+
+// rebuild updates handlers stack of the route in router.
+func (r *Route) rebuild() {
+	r.router.mustNotBeFrozen("change handlers of " + r.info.Path)
+
+	chain := make([]HandlerFunc, 0, len(r.middlewares)+len(r.handlers)+2)
+	if len(r.consumes) > 0 || len(r.produces) > 0 {
+		chain = append(chain, routeContract(r.consumes, r.produces))
+	}
+
+	if len(r.decoders) > 0 {
+		chain = append(chain, decodeParams(r.decoders))
+	}
+
+	if r.override != nil {
+		if r.override.bodyLimit > 0 {
+			chain = append(chain, routeBodyLimit(r.override.bodyLimit))
+		}
+
+		if r.override.timeout > 0 {
+			chain = append(chain, timeoutHandler(TimeoutConfig{Timeout: r.override.timeout}, true))
+		}
+
+		r.router.setOverride(r.key, r.override)
+	}
+
+	chain = append(chain, r.middlewares...)
+	r.router.setHandlers(r.info.Method, r.info.Path, append(chain, r.handlers...))
+}
+
+// Name functions to name the route, so its url could be built using Engine.URL.
+// It panics when the name is already used by another route.
+func (r *Route) Name(name string) *Route {
+	r.router.mustNotBeFrozen("name " + r.info.Path)
+
+	if route, exists := r.router.named[name]; exists && route != r {
+		panic(fmt.Sprintf("route name %s already registered", name))
+	}
+
+	if r.info.Name != "" {
+		delete(r.router.named, r.info.Name)
+	}
+
+	r.info.Name = name
+	r.router.named[name] = r
+
+	return r
+}
+
+// WithRequest functions to describe request payload of the route using value of binding struct, e.g. CreateUserRequest{}.
+func (r *Route) WithRequest(payload interface{}) *Route {
+	r.request = reflect.TypeOf(payload)
+
+	return r
+}
+
+// WithResponse functions to describe response payload of the route using value of response struct.
+func (r *Route) WithResponse(payload interface{}) *Route {
+	r.response = reflect.TypeOf(payload)
+
+	return r
+}
+
+// Info returns route description.
+func (r *Route) Info() RouteInfo {
+	return r.info
+}
+
+// RouteSet is routes of the same url pattern registered by Any & Match,
+// its methods apply to every route, so the routes could be configured together.
+type RouteSet []*Route
+
+// Use functions to apply middleware function(s) to every route of the set.
+func (rs RouteSet) Use(middlewares ...HandlerFunc) RouteSet {
+	for _, r := range rs {
+		r.Use(middlewares...)
+	}
+
+	return rs
+}
+
+// DecodeParam functions to decode route parameter of every route of the set, see Route.DecodeParam.
+func (rs RouteSet) DecodeParam(name string, decoder ParamDecoder) RouteSet {
+	for _, r := range rs {
+		r.DecodeParam(name, decoder)
+	}
+
+	return rs
+}
+
+// WithTimeout functions to limit duration of handlers of every route of the set, see Route.WithTimeout.
+func (rs RouteSet) WithTimeout(timeout time.Duration) RouteSet {
+	for _, r := range rs {
+		r.WithTimeout(timeout)
+	}
+
+	return rs
+}
+
+// WithBodyLimit functions to limit request body size of every route of the set, see Route.WithBodyLimit.
+func (rs RouteSet) WithBodyLimit(maxBodySize int64) RouteSet {
+	for _, r := range rs {
+		r.WithBodyLimit(maxBodySize)
+	}
+
+	return rs
+}
+
+// Consumes functions to restrict request content types of every route of the set, see Route.Consumes.
+func (rs RouteSet) Consumes(contentTypes ...string) RouteSet {
+	for _, r := range rs {
+		r.Consumes(contentTypes...)
+	}
+
+	return rs
+}
+
+// Produces functions to declare response content types of every route of the set, see Route.Produces.
+func (rs RouteSet) Produces(contentTypes ...string) RouteSet {
+	for _, r := range rs {
+		r.Produces(contentTypes...)
+	}
+
+	return rs
+}
+
+// Name functions to name the first route of the set, since url of every route is the same.
+// It panics when the name is already used by another route.
+func (rs RouteSet) Name(name string) RouteSet {
+	if len(rs) > 0 {
+		rs[0].Name(name)
+	}
+
+	return rs
+}
+
+// URL functions to build url of named route. parameters are url-escaped,
+// except wildcard parameter which is escaped per path segment.
+// it returns error when parameter value doesn't satisfy its constraint, e.g. :id(\d+) or {id:int}.
+func (ng *Engine) URL(name string, params map[string]string) (string, error) {
+	route, exists := ng.router.named[name]
+	if !exists {
+		return "", ErrRouteName
+	}
+
+	parts := strings.Split(route.info.Path, "/")
+	for i, part := range parts {
+		param, isParam, _ := parseParam(part)
+		if !isParam {
+			continue
+		}
+
+		value, ok := params[param.name]
+		if !ok && !param.catchAll {
+			return "", fmt.Errorf("missing route parameter %s of route %s", param.name, name)
+		}
+
+		if !param.catchAll {
+			if !param.accepts(value) {
+				return "", fmt.Errorf("route parameter %s of route %s doesn't satisfy its constraint: %q", param.name, name, value)
+			}
+
+			parts[i] = url.PathEscape(value)
+			continue
+		}
+
+		segments := strings.Split(value, "/")
+		for j, segment := range segments {
+			segments[j] = url.PathEscape(segment)
+		}
+
+		parts[i] = strings.Join(segments, "/")
+	}
+
+	return ng.basePath + strings.Join(parts, "/"), nil
+}
+
+// RedirectToRoute redirects client to named route.
+func (c *Context) RedirectToRoute(statusCode int, name string, params map[string]string) error {
+	if c.engine == nil {
+		return ErrRouteName
+	}
+
+	location, err := c.engine.URL(name, params)
+	if err != nil {
+		return err
+	}
+
+	c.Redirect(statusCode, location)
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package nano
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRouteUse(t *testing.T) {
+	app := New()
+
+	app.Use(func(c *Context) {
+		c.Bag.Set("trace", "group")
+		c.Next()
+	})
+
+	route := app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "%s", c.Bag.Get("trace"))
+	})
+
+	route.Use(func(c *Context) {
+		c.Bag.Set("trace", c.Bag.Get("trace").(string)+",route")
+		c.Next()
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "group,route" {
+		t.Errorf("expected route middleware to be called after group middleware; got %s", body)
+	}
+}
+
+func TestRouteName(t *testing.T) {
+	app := New()
+
+	emptyHandler := func(c *Context) {}
+	app.Group("/users").GET("/:id/files/*path", emptyHandler).Name("user.file")
+	app.GET("/login", emptyHandler).Name("login")
+
+	location, err := app.URL("user.file", map[string]string{"id": "a b", "path": "docs/cv 1.pdf"})
+	if err != nil || location != "/users/a%20b/files/docs/cv%201.pdf" {
+		t.Errorf("expected url to be /users/a%%20b/files/docs/cv%%201.pdf; got %s (%v)", location, err)
+	}
+
+	if _, err := app.URL("user.file", nil); err == nil {
+		t.Errorf("expected missing route parameter to return error")
+	}
+
+	if _, err := app.URL("unknown", nil); err != ErrRouteName {
+		t.Errorf("expected unknown route name to return ErrRouteName; got %v", err)
+	}
+
+	if name := app.Routes()[1].Name; name != "login" {
+		t.Errorf("expected route name to be login; got %s", name)
+	}
+
+	t.Run("redirect to route", func(st *testing.T) {
+		app.GET("/logout", func(c *Context) {
+			if err := c.RedirectToRoute(http.StatusFound, "login", nil); err != nil {
+				st.Errorf("expected redirect to route not to fail; got %v", err)
+			}
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/logout", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if location := rec.Header().Get("Location"); rec.Code != http.StatusFound || location != "/login" {
+			st.Errorf("expected redirect to /login; got %d %s", rec.Code, location)
+		}
+	})
+
+	t.Run("duplicate name", func(st *testing.T) {
+		defer func() {
+			if recover() == nil {
+				st.Errorf("expected duplicate route name to panic")
+			}
+		}()
+
+		app.GET("/signin", emptyHandler).Name("login")
+	})
+}
+
+func TestRouteOverrides(t *testing.T) {
+	app := New()
+	app.Use(Timeout(20*time.Millisecond), PayloadLimit(PayloadLimitConfig{MaxBodySize: 8}))
+
+	handler := func(c *Context) {
+		time.Sleep(40 * time.Millisecond)
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+
+		c.String(http.StatusOK, "%d", len(body))
+	}
+
+	app.POST("/report", handler).WithTimeout(time.Second).WithBodyLimit(64)
+	app.POST("/import", handler)
+
+	tt := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"route overrides group limits", "/report", strings.Repeat("a", 32), http.StatusOK},
+		{"route body limit exceeded", "/report", strings.Repeat("a", 65), http.StatusRequestEntityTooLarge},
+		{"group timeout applies", "/import", "a", http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouteSet(t *testing.T) {
+	app := New()
+
+	routes := app.Match([]string{http.MethodGet, "post"}, "/items/:id", func(c *Context) {
+		c.String(http.StatusOK, "%s %s", c.Method, c.Bag.Get("trace"))
+	}).Use(func(c *Context) {
+		c.Bag.Set("trace", "route")
+		c.Next()
+	}).Name("item")
+
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes to be registered; got %d", len(routes))
+	}
+
+	if all := app.Any("/any"); len(all) != len(anyMethods) {
+		t.Errorf("expected Any to return %d routes; got %d", len(anyMethods), len(all))
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		req, err := http.NewRequest(method, "/items/1", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if body := rec.Body.String(); body != method+" route" {
+			t.Errorf("expected route middleware to be applied to %s route; got %s", method, body)
+		}
+	}
+
+	if location, err := app.URL("item", map[string]string{"id": "1"}); err != nil || location != "/items/1" {
+		t.Errorf("expected route set name to be registered; got %s (%v)", location, err)
+	}
+}
@@ -1,23 +1,71 @@
 package nano
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 )
 
+// RouteInfo describes registered route.
+type RouteInfo struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// HandlerName is function name of the last handler, middlewares passed along the handler are excluded.
+	HandlerName string `json:"handler"`
+	// Group is prefix of router group the route registered in.
+	Group string `json:"group"`
+	// Name is route name given by Route.Name.
+	Name string `json:"name,omitempty"`
+	// Doc is route description given by Route.Doc.
+	Doc *RouteDoc `json:"doc,omitempty"`
+}
+
+// RouteMatch is route resolving result.
+type RouteMatch struct {
+	// Pattern is url pattern of matching route.
+	Pattern  string
+	Handlers []HandlerFunc
+	Params   map[string]string
+}
+
+// Router resolves request path into route handlers.
+// Implement it to plug alternative matching implementation, e.g. map lookup for purely static api.
+type Router interface {
+	// Add registers handlers stack of request method & url pattern, it's called again when the stack changes.
+	Add(requestMethod, urlPattern string, handlers []HandlerFunc)
+	// Find returns matching route of request method & path, it returns false when no route matches.
+	Find(requestMethod, urlPath string) (RouteMatch, bool)
+}
+
 // router defines main router structure.
 type router struct {
 	nodes          map[string]*node
 	handlers       map[string][]HandlerFunc
+	routes         []*Route // ordered by registration.
+	named          map[string]*Route
 	defaultHandler HandlerFunc
+	// resolver is custom router set by Engine.SetRouter, it's nil when the built-in tree is used.
+	resolver Router
+	// rawPath keeps route parameters percent-encoded, it's set by Engine.UseRawPath.
+	rawPath bool
+	// frozen rejects route registration, it's set by Engine.Freeze. accessed atomically.
+	frozen int32
+	// overrides are route-level options keyed by route key, set by Route.WithTimeout & Route.WithBodyLimit.
+	overrides map[string]*routeOverride
 }
 
+// ErrRouteConflict is returned when registered route conflicts with another route.
+var ErrRouteConflict = errors.New("route conflict")
+
 // newRouter creates new router instance.
 func newRouter() *router {
 	return &router{
 		nodes:    make(map[string]*node),
 		handlers: make(map[string][]HandlerFunc),
+		named:    make(map[string]*Route),
 	}
 }
 
@@ -32,8 +80,8 @@ func createURLParts(urlPattern string) []string {
 		if path != "" {
 			urlParts = append(urlParts, path)
 
-			// only * wildcard is allowed.
-			if path[0] == '*' {
+			// catch-all parameter takes the rest of path.
+			if isCatchAllPart(path) {
 				break
 			}
 		}
@@ -44,9 +92,45 @@ func createURLParts(urlPattern string) []string {
 
 // addRoute registers route to router.
 // you could use multiple handler.
-func (r *router) addRoute(requestMethod, urlPattern string, handler ...HandlerFunc) {
+func (r *router) addRoute(requestMethod, urlPattern string, handler ...HandlerFunc) *Route {
+	return r.register(RouteInfo{Method: requestMethod, Path: urlPattern}, handler...)
+}
+
+// register registers route described by route info.
+// it panics when the route conflicts with registered route.
+func (r *router) register(info RouteInfo, handler ...HandlerFunc) *Route {
+	route, err := r.tryRegister(info, handler...)
+	if err != nil {
+		panic(err)
+	}
+
+	return route
+}
+
+// tryRegister registers route described by route info.
+// it returns ErrRouteConflict when the same method & pattern is already registered,
+// or when parameter of the pattern is registered using another name at the same position.
+// it returns ErrRoutePattern when parameter of the pattern is malformed, e.g. unnamed or mid-path catch-all,
+// and ErrRouterFrozen when the engine is frozen.
+func (r *router) tryRegister(info RouteInfo, handler ...HandlerFunc) (*Route, error) {
+	requestMethod, urlPattern := info.Method, info.Path
+	if err := r.checkFrozen(fmt.Sprintf("register %s %s", requestMethod, urlPattern)); err != nil {
+		return nil, err
+	}
+
 	urlParts := createURLParts(urlPattern)
 
+	params, err := parsePattern(urlPattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", requestMethod, urlPattern, err)
+	}
+
+	// register route.
+	key := fmt.Sprintf("%s-%s", requestMethod, urlPattern)
+	if r.routeOf(key) != nil {
+		return nil, fmt.Errorf("%w: %s %s is already registered", ErrRouteConflict, requestMethod, urlPattern)
+	}
+
 	rootNode, exists := r.nodes[requestMethod]
 
 	// current request method root node doesn't exists.
@@ -55,18 +139,80 @@ func (r *router) addRoute(requestMethod, urlPattern string, handler ...HandlerFu
 		rootNode = r.nodes[requestMethod]
 	}
 
-	// register route.
-	key := fmt.Sprintf("%s-%s", requestMethod, urlPattern)
-
 	// insert children to tree.
-	rootNode.insertChildren(urlPattern, urlParts, 0)
-	r.handlers[key] = handler
+	if err := rootNode.insertChildren(urlPattern, urlParts, params, 0); err != nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrRouteConflict, requestMethod, err)
+	}
+
+	if len(handler) > 0 {
+		info.HandlerName = nameOfFunction(handler[len(handler)-1])
+	}
+
+	route := &Route{info: info, key: key, handlers: handler, router: r}
+	r.routes = append(r.routes, route)
+	r.setHandlers(info.Method, info.Path, handler)
+
+	return route, nil
+}
+
+// setHandlers stores handlers stack of route and forwards it to custom router.
+func (r *router) setHandlers(requestMethod, urlPattern string, handlers []HandlerFunc) {
+	r.handlers[fmt.Sprintf("%s-%s", requestMethod, urlPattern)] = handlers
+
+	if r.resolver != nil {
+		r.resolver.Add(requestMethod, urlPattern, handlers)
+	}
+}
+
+// setOverride stores route-level options of route key, so middlewares of router groups could skip themselves.
+func (r *router) setOverride(key string, override *routeOverride) {
+	if r.overrides == nil {
+		r.overrides = make(map[string]*routeOverride)
+	}
+
+	r.overrides[key] = override
+}
+
+// Find returns matching route using the built-in tree.
+func (r *router) Find(requestMethod, urlPath string) (RouteMatch, bool) {
+	return r.match(requestMethod, urlPath, false)
+}
+
+// match returns matching route using the built-in tree, static parts are compared
+// case-insensitively when ignoreCase is true.
+func (r *router) match(requestMethod, urlPath string, ignoreCase bool) (RouteMatch, bool) {
+	node, params := r.searchRoute(requestMethod, urlPath, ignoreCase)
+	if node == nil {
+		return RouteMatch{}, false
+	}
+
+	key := fmt.Sprintf("%s-%s", requestMethod, node.urlPattern)
+
+	return RouteMatch{Pattern: node.urlPattern, Handlers: r.handlers[key], Params: params}, true
+}
+
+// routeOf returns registered route by its key, it returns nil when the route doesn't exist.
+func (r *router) routeOf(key string) *Route {
+	for _, route := range r.routes {
+		if route.key == key {
+			return route
+		}
+	}
+
+	return nil
 }
 
 // findRoute finds current request with stored url pattern in node tree.
 // this function also mapping your parameter (which was defined in url pattern) from url request.
 func (r *router) findRoute(requestMethod, urlPath string) (*node, map[string]string) {
-	searchParts := createURLParts(urlPath)
+	return r.searchRoute(requestMethod, urlPath, false)
+}
+
+// searchRoute finds url pattern like findRoute, static parts are compared case-insensitively when ignoreCase is true.
+// url path is split before it's decoded, so encoded slash (%2F) doesn't separate parts.
+func (r *router) searchRoute(requestMethod, urlPath string, ignoreCase bool) (*node, map[string]string) {
+	rawParts := createURLParts(urlPath)
+	searchParts := unescapeParts(rawParts)
 	params := make(map[string]string)
 
 	rootNode, exists := r.nodes[requestMethod]
@@ -77,19 +223,26 @@ func (r *router) findRoute(requestMethod, urlPath string) (*node, map[string]str
 	}
 
 	// scan child node recursively.
-	node := rootNode.findNode(searchParts, 0)
+	node := rootNode.findNode(searchParts, 0, ignoreCase)
 
 	if node != nil {
+		paramParts := searchParts
+		if r.rawPath {
+			paramParts = rawParts
+		}
+
 		// replace param placeholder with current request value.
-		for index, path := range createURLParts(node.urlPattern) {
-			// current pattern is parameter.
-			if path[0] == ':' {
-				params[path[1:]] = searchParts[index]
-			}
+		for index, param := range node.params {
+			switch {
+			// current pattern is static part.
+			case param == nil:
+
+			// current pattern is catch-all parameter, that means all path are used.
+			case param.catchAll:
+				params[param.name] = strings.Join(paramParts[index:], "/")
 
-			// current pattern is * wildcard, that means all path are used.
-			if path[0] == '*' && len(path) > 1 {
-				params[path[1:]] = strings.Join(searchParts[index:], "/")
+			default:
+				params[param.name] = paramParts[index]
 			}
 		}
 
@@ -99,6 +252,22 @@ func (r *router) findRoute(requestMethod, urlPath string) (*node, map[string]str
 	return nil, nil
 }
 
+// unescapeParts returns percent-decoded url parts, part that could not be decoded is kept as is.
+func unescapeParts(rawParts []string) []string {
+	parts := make([]string, len(rawParts))
+
+	for i, part := range rawParts {
+		decoded, err := url.PathUnescape(part)
+		if err != nil {
+			decoded = part
+		}
+
+		parts[i] = decoded
+	}
+
+	return parts
+}
+
 // notFoundHandler is router default handler.
 func (r *router) notFoundHandler() HandlerFunc {
 	return func(c *Context) {
@@ -107,35 +276,125 @@ func (r *router) notFoundHandler() HandlerFunc {
 }
 
 // serveDefaultHandler appends default handler to call stacks.
-// if you not set the default handler, we will set notFoundHandler as default.
+// if you not set the default handler, notFoundHandler is used.
 func (r *router) serveDefaultHandler(c *Context) {
-	// create not found handler when default handler not set yet.
+	c.handlers = append(c.handlers, r.fallbackOf(c))
+	c.Next()
+}
+
+// fallbackOf returns handler of unmatched request. NoMethod handler of the most specific router group is used
+// when the path is registered using another method, then NoRoute handler of the most specific router group,
+// then default handler.
+func (r *router) fallbackOf(c *Context) HandlerFunc {
+	if c.engine == nil {
+		return r.defaultOrNotFound()
+	}
+
+	var noRoute, noMethod *RouterGroup
+	for _, group := range c.engine.groups {
+		if !strings.HasPrefix(c.Request.URL.Path, group.prefix) {
+			continue
+		}
+
+		if group.noRoute != nil && (noRoute == nil || len(group.prefix) > len(noRoute.prefix)) {
+			noRoute = group
+		}
+
+		if group.noMethod != nil && (noMethod == nil || len(group.prefix) > len(noMethod.prefix)) {
+			noMethod = group
+		}
+	}
+
+	if noMethod != nil {
+		if allowed := r.allowedMethods(c); len(allowed) > 0 {
+			c.SetHeader(HeaderAllow, strings.Join(allowed, ", "))
+			return noMethod.noMethod
+		}
+	}
+
+	if noRoute != nil {
+		return noRoute.noRoute
+	}
+
+	return r.defaultOrNotFound()
+}
+
+// defaultOrNotFound returns default handler, or notFoundHandler when it's not set.
+// notFoundHandler isn't stored, so concurrent unmatched requests don't write the router.
+func (r *router) defaultOrNotFound() HandlerFunc {
 	if r.defaultHandler == nil {
-		r.defaultHandler = r.notFoundHandler()
+		return r.notFoundHandler()
 	}
 
-	c.handlers = append(c.handlers, r.defaultHandler)
-	c.Next()
+	return r.defaultHandler
+}
+
+// allowedMethods returns sorted request methods that have route matching request path.
+func (r *router) allowedMethods(c *Context) []string {
+	find, urlPath := r.lookup(c)
+	allowed := make([]string, 0)
+
+	for requestMethod := range r.nodes {
+		if requestMethod == c.Method {
+			continue
+		}
+
+		if _, ok := find(requestMethod, urlPath); ok {
+			allowed = append(allowed, requestMethod)
+		}
+	}
+
+	sort.Strings(allowed)
+
+	return allowed
+}
+
+// lookup returns route finder and request path it expects.
+// the built-in tree is searched using escaped path, so encoded slash stays inside route parameter.
+func (r *router) lookup(c *Context) (func(requestMethod, urlPath string) (RouteMatch, bool), string) {
+	if r.resolver != nil {
+		return r.resolver.Find, c.Path
+	}
+
+	return r.Find, c.Request.URL.EscapedPath()
 }
 
 // handle incoming request. if there is no matching route,
 // router will serve default handler.
 func (r *router) handle(c *Context) {
-	node, params := r.findRoute(c.Method, c.Path)
+	find, urlPath := r.lookup(c)
+	match, ok := find(c.Method, urlPath)
 
-	// current request has a match route.
-	if node != nil {
-		key := fmt.Sprintf("%s-%s", c.Method, node.urlPattern)
-		c.Params = params
-
-		// append current handler to handler stack.
-		// extract route handler(s).
-		c.handlers = append(c.handlers, r.handlers[key]...)
-	} else {
-		// no matching routes, serve default.
+	// trailing slash, letter case, and path cleaning options apply to the built-in tree only.
+	if r.resolver == nil && c.engine != nil {
+		if !ok || c.engine.redirectFixedPath {
+			match, ok = r.matchFixed(c, urlPath, match, ok)
+		}
+
+		if ok && r.redirectCanonical(c, urlPath, match.Pattern) {
+			return
+		}
+	}
+
+	// no matching routes, serve default.
+	if !ok {
 		r.serveDefaultHandler(c)
+		return
+	}
+
+	c.Params = match.Params
+	c.routePattern = match.Pattern
+
+	if len(r.overrides) > 0 {
+		c.override = r.overrides[fmt.Sprintf("%s-%s", c.Method, match.Pattern)]
 	}
 
+	c.emit(EventRouteMatched, 0, nil)
+
+	// append current handler to handler stack.
+	// extract route handler(s).
+	c.handlers = append(c.handlers, match.Handlers...)
+
 	// call handlers stack.
 	c.Next()
 }
@@ -1,6 +1,7 @@
 package nano
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -67,7 +68,7 @@ func TestAddRoute(t *testing.T) {
 		}{
 			{http.MethodGet, "/", "GET-/"},
 			{http.MethodGet, "/about", "GET-/about"},
-			{http.MethodGet, "/downloads/*", "GET-/downloads/*"},
+			{http.MethodGet, "/downloads/*file", "GET-/downloads/*file"},
 			{http.MethodPost, "/articles/:id", "POST-/articles/:id"},
 		}
 
@@ -83,12 +84,12 @@ func TestAddRoute(t *testing.T) {
 	t.Run("handler count", func(st *testing.T) {
 		firstHandler := func(c *Context) {}
 		secondHandler := func(c *Context) {}
-		r.addRoute(http.MethodGet, "/", firstHandler, secondHandler)
+		r.addRoute(http.MethodGet, "/contact", firstHandler, secondHandler)
 
-		route, ok := r.handlers["GET-/"]
+		route, ok := r.handlers["GET-/contact"]
 
 		if !ok {
-			st.Fatalf("expected route GET-/ to found; got not found")
+			st.Fatalf("expected route GET-/contact to found; got not found")
 		}
 
 		if handlerCount := len(route); handlerCount != 2 {
@@ -142,6 +143,86 @@ func TestFindRoute(t *testing.T) {
 	}
 }
 
+func TestRouteConflict(t *testing.T) {
+	emptyHandler := func(c *Context) {}
+
+	tt := []struct {
+		name     string
+		existing string
+		method   string
+		pattern  string
+		err      string
+	}{
+		{"duplicate route", "/users/:id", http.MethodGet, "/users/:id", "route conflict: GET /users/:id is already registered"},
+		{"ambiguous parameter", "/users/:id", http.MethodGet, "/users/:name", "route conflict: GET /users/:name conflicts with /users/:id, parameter :name is already registered as :id"},
+		{"ambiguous nested parameter", "/users/:id/posts", http.MethodGet, "/users/:name/likes", "route conflict: GET /users/:name/likes conflicts with /users/:id/posts, parameter :name is already registered as :id"},
+		{"parameter and wildcard", "/files/:name", http.MethodGet, "/files/*path", "route conflict: GET /files/*path conflicts with /files/:name, parameter *path is already registered as :name"},
+		{"static and parameter", "/users/:id", http.MethodGet, "/users/new", ""},
+		{"same pattern other method", "/users/:id", http.MethodPut, "/users/:id", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.GET(tc.existing, emptyHandler)
+
+			_, err := app.AddRoute(tc.method, tc.pattern, emptyHandler)
+			if tc.err == "" {
+				if err != nil {
+					st.Errorf("expected route to be registered; got %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tc.err {
+				st.Errorf("expected error to be %q; got %v", tc.err, err)
+			}
+
+			defer func() {
+				recovered, _ := recover().(error)
+				if !errors.Is(recovered, ErrRouteConflict) || recovered.Error() != tc.err {
+					st.Errorf("expected registration to panic with %q; got %v", tc.err, recovered)
+				}
+			}()
+
+			app.Match([]string{tc.method}, tc.pattern, emptyHandler)
+		})
+	}
+}
+
+func TestStaticPrecedence(t *testing.T) {
+	r := newRouter()
+	r.addRoute(http.MethodGet, "/users/:id/posts", func(c *Context) {})
+	r.addRoute(http.MethodGet, "/users/new", func(c *Context) {})
+	r.addRoute(http.MethodGet, "/users/:id", func(c *Context) {})
+	r.addRoute(http.MethodGet, "/users/new/draft", func(c *Context) {})
+
+	tt := []struct {
+		path    string
+		pattern string
+	}{
+		{"/users/new", "/users/new"},
+		{"/users/1", "/users/:id"},
+		{"/users/new/draft", "/users/new/draft"},
+		// static node doesn't have the rest of path, so it falls back to parameter.
+		{"/users/new/posts", "/users/:id/posts"},
+		{"/users/1/posts", "/users/:id/posts"},
+	}
+
+	for _, tc := range tt {
+		node, _ := r.findRoute(http.MethodGet, tc.path)
+		if node == nil {
+			t.Errorf("expected %s to match %s; got not found", tc.path, tc.pattern)
+			continue
+		}
+
+		if node.urlPattern != tc.pattern {
+			t.Errorf("expected %s to match %s; got %s", tc.path, tc.pattern, node.urlPattern)
+		}
+	}
+}
+
 func TestDefaultRouteHandler(t *testing.T) {
 	r := newRouter()
 
@@ -234,3 +315,75 @@ func TestHandle(t *testing.T) {
 		})
 	}
 }
+
+// staticRouter is custom router matching exact request path.
+type staticRouter map[string]RouteMatch
+
+func (sr staticRouter) Add(requestMethod, urlPattern string, handlers []HandlerFunc) {
+	sr[requestMethod+urlPattern] = RouteMatch{Pattern: urlPattern, Handlers: handlers}
+}
+
+func (sr staticRouter) Find(requestMethod, urlPath string) (RouteMatch, bool) {
+	match, ok := sr[requestMethod+urlPath]
+	return match, ok
+}
+
+func TestSetRouter(t *testing.T) {
+	app := New()
+
+	app.GET("/ping", func(c *Context) {
+		c.String(http.StatusOK, "pong %s", c.RoutePattern())
+	})
+
+	custom := staticRouter{}
+	app.SetRouter(custom)
+
+	app.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "user")
+	}).Use(func(c *Context) {
+		c.SetHeader("X-Route", "users")
+		c.Next()
+	})
+
+	if _, ok := custom["GET/ping"]; !ok {
+		t.Errorf("expected existing route to be added to custom router")
+	}
+
+	if match := custom["GET/users/:id"]; len(match.Handlers) != 2 {
+		t.Errorf("expected route middleware to be forwarded to custom router; got %d handlers", len(match.Handlers))
+	}
+
+	request := func(app *Engine, url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if body := request(app, "/ping").Body.String(); body != "pong /ping" {
+		t.Errorf("expected custom router to serve /ping; got %s", body)
+	}
+
+	// static router doesn't support route parameter.
+	if rec := request(app, "/users/1"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected custom router not to match /users/1; got %d", rec.Code)
+	}
+
+	restored := New()
+	restored.SetRouter(staticRouter{})
+	restored.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "user")
+	}).Use(func(c *Context) {
+		c.SetHeader("X-Route", "users")
+		c.Next()
+	})
+	restored.SetRouter(nil)
+
+	if rec := request(restored, "/users/1"); rec.Body.String() != "user" || rec.Header().Get("X-Route") != "users" {
+		t.Errorf("expected built-in router to be restored; got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,144 @@
+package nano
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ScheduleConfig defines options of scheduled job.
+type ScheduleConfig struct {
+	// Jitter delays each run by random duration up to Jitter, so replicas don't run the job at the same instant.
+	Jitter time.Duration
+	// AllowOverlap runs the job even when its previous run hasn't completed, by default the run is skipped.
+	AllowOverlap bool
+	// Location is time zone of cron spec, default is time.Local.
+	Location *time.Location
+}
+
+// scheduledJob is job registered by Schedule.
+type scheduledJob struct {
+	spec     string
+	schedule schedule
+	config   ScheduleConfig
+	job      func(ctx context.Context)
+	running  int32 // accessed atomically.
+}
+
+// scheduler runs scheduled jobs between StartScheduler and Shutdown.
+type scheduler struct {
+	mu   sync.Mutex
+	jobs []*scheduledJob
+	// stop is closed when the scheduler is stopped, it's nil before the scheduler is started.
+	stop    chan struct{}
+	stopped bool
+	// loops tracks goroutine of each job, so no run is started after stopScheduler returns.
+	loops sync.WaitGroup
+}
+
+// Schedule functions to run job periodically, using standard 5 fields cron spec (e.g. "*/15 * * * *"),
+// descriptor (@hourly, @daily, @weekly, @monthly, @yearly), or interval (e.g. "@every 10m").
+// Jobs are started by Run (or StartScheduler when you use your own http.Server) and stopped by Shutdown,
+// which waits running jobs like background tasks. Panic of the job is recovered and logged,
+// and a run is skipped while the previous run is still running.
+func (ng *Engine) Schedule(spec string, job func(ctx context.Context)) error {
+	return ng.ScheduleWithConfig(spec, ScheduleConfig{}, job)
+}
+
+// ScheduleWithConfig functions to run job periodically like Schedule, with jitter, overlap, and time zone options.
+func (ng *Engine) ScheduleWithConfig(spec string, config ScheduleConfig, job func(ctx context.Context)) error {
+	parsed, err := parseSchedule(spec, config.Location)
+	if err != nil {
+		return err
+	}
+
+	scheduled := &scheduledJob{spec: spec, schedule: parsed, config: config, job: job}
+
+	ng.scheduler.mu.Lock()
+	defer ng.scheduler.mu.Unlock()
+
+	ng.scheduler.jobs = append(ng.scheduler.jobs, scheduled)
+
+	// job registered after the scheduler is started is scheduled right away.
+	if ng.scheduler.stop != nil && !ng.scheduler.stopped {
+		ng.scheduler.loops.Add(1)
+		go ng.runJob(scheduled, ng.scheduler.stop)
+	}
+
+	return nil
+}
+
+// StartScheduler functions to start scheduled jobs, it's called by Run.
+// Call it when you serve the engine using your own http.Server, calling it more than once does nothing.
+func (ng *Engine) StartScheduler() {
+	ng.scheduler.mu.Lock()
+	defer ng.scheduler.mu.Unlock()
+
+	if ng.scheduler.stop != nil {
+		return
+	}
+
+	ng.scheduler.stop = make(chan struct{})
+	for _, job := range ng.scheduler.jobs {
+		ng.scheduler.loops.Add(1)
+		go ng.runJob(job, ng.scheduler.stop)
+	}
+}
+
+// stopScheduler stops scheduling new runs, running jobs are waited by WaitTasks.
+func (ng *Engine) stopScheduler() {
+	ng.scheduler.mu.Lock()
+
+	if ng.scheduler.stop == nil {
+		ng.scheduler.stop = make(chan struct{})
+	}
+
+	if !ng.scheduler.stopped {
+		ng.scheduler.stopped = true
+		close(ng.scheduler.stop)
+	}
+
+	ng.scheduler.mu.Unlock()
+	ng.scheduler.loops.Wait()
+}
+
+// runJob waits for each activation time of the job and runs it as background task until stop is closed.
+func (ng *Engine) runJob(job *scheduledJob, stop chan struct{}) {
+	defer ng.scheduler.loops.Done()
+
+	for {
+		next := job.schedule.next(time.Now())
+		if next.IsZero() {
+			return
+		}
+
+		delay := time.Until(next)
+		if job.config.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(job.config.Jitter)))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !job.config.AllowOverlap && !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+			ng.taskLogger().Warn("scheduled job is still running, the run is skipped", slog.String("schedule", job.spec))
+			continue
+		}
+
+		ng.Go(func(ctx context.Context) {
+			if !job.config.AllowOverlap {
+				defer atomic.StoreInt32(&job.running, 0)
+			}
+
+			job.job(ctx)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package nano
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Run("lifecycle", func(st *testing.T) {
+		var runs int32
+
+		app := New()
+		if err := app.Schedule("@every 5ms", func(ctx context.Context) { atomic.AddInt32(&runs, 1) }); err != nil {
+			st.Fatalf("expected schedule error to be nil; got %v", err)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		if atomic.LoadInt32(&runs) != 0 {
+			st.Fatalf("expected job not to run before the scheduler is started")
+		}
+
+		app.StartScheduler()
+		app.StartScheduler()
+
+		deadline := time.Now().Add(time.Second)
+		for atomic.LoadInt32(&runs) < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if err := app.Shutdown(context.Background()); err != nil {
+			st.Fatalf("expected shutdown error to be nil; got %v", err)
+		}
+
+		if err := app.WaitTasks(context.Background()); err != nil {
+			st.Fatalf("expected wait error to be nil; got %v", err)
+		}
+
+		stopped := atomic.LoadInt32(&runs)
+		if stopped < 2 {
+			st.Fatalf("expected job to run periodically; got %d runs", stopped)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		if runs := atomic.LoadInt32(&runs); runs != stopped {
+			st.Errorf("expected job not to run after shutdown; got %d more runs", runs-stopped)
+		}
+	})
+
+	t.Run("overlap prevention", func(st *testing.T) {
+		var runs int32
+		release := make(chan struct{})
+
+		app := New()
+		app.Schedule("@every 5ms", func(ctx context.Context) {
+			atomic.AddInt32(&runs, 1)
+			<-release
+		})
+		app.StartScheduler()
+
+		// stop before releasing the job, so released job isn't followed by another run.
+		time.Sleep(40 * time.Millisecond)
+		app.stopScheduler()
+		close(release)
+		app.WaitTasks(context.Background())
+
+		if runs := atomic.LoadInt32(&runs); runs != 1 {
+			st.Errorf("expected overlapping runs to be skipped; got %d runs", runs)
+		}
+	})
+
+	t.Run("invalid spec", func(st *testing.T) {
+		if err := New().Schedule("every day", func(ctx context.Context) {}); !errors.Is(err, ErrCronSpec) {
+			st.Errorf("expected schedule error to be ErrCronSpec; got %v", err)
+		}
+	})
+}
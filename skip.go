@@ -0,0 +1,14 @@
+package nano
+
+// Unless wraps middleware, so it's skipped when predicate returns true,
+// e.g. to serve health check & metrics endpoints without logging or compression.
+func Unless(middleware HandlerFunc, predicate func(c *Context) bool) HandlerFunc {
+	return func(c *Context) {
+		if predicate(c) {
+			c.Next()
+			return
+		}
+
+		middleware(c)
+	}
+}
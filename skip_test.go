@@ -0,0 +1,98 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnless(t *testing.T) {
+	isHealthCheck := func(c *Context) bool {
+		return c.Path == "/health"
+	}
+
+	app := New()
+	app.Use(Unless(func(c *Context) {
+		c.SetHeader("X-Logged", "true")
+		c.Next()
+	}, isHealthCheck))
+	app.GET("/health", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	app.GET("/users", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	tt := []struct {
+		url    string
+		logged string
+	}{
+		{"/health", ""},
+		{"/users", "true"},
+	}
+
+	for _, tc := range tt {
+		req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status code of %s to be 200; got %d", tc.url, rec.Code)
+		}
+
+		if logged := rec.Header().Get("X-Logged"); logged != tc.logged {
+			t.Errorf("expected middleware header of %s to be %q; got %q", tc.url, tc.logged, logged)
+		}
+	}
+}
+
+func TestMiddlewareSkipper(t *testing.T) {
+	skipMetrics := func(c *Context) bool {
+		return c.Path == "/metrics"
+	}
+
+	tt := []struct {
+		name       string
+		middleware HandlerFunc
+		header     string
+	}{
+		{"basic auth", BasicAuthWithConfig(BasicAuthConfig{Accounts: map[string]string{"foo": "bar"}, Skipper: skipMetrics}), HeaderWWWAuthenticate},
+		{"gzip", GzipWithConfig(GzipConfig{Level: 5, Skipper: skipMetrics}), HeaderContentEncoding},
+		{"cors", CORSWithConfig(CORSConfig{Skipper: skipMetrics}), HeaderAccessControlAllowOrigin},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.Use(tc.middleware)
+			app.GET("/metrics", func(c *Context) {
+				c.String(http.StatusOK, "ok")
+			})
+			app.GET("/users", func(c *Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			for _, url := range []string{"/metrics", "/users"} {
+				req, err := http.NewRequest(http.MethodGet, url, nil)
+				if err != nil {
+					log.Fatalf("could not create http request: %v", err)
+				}
+				req.Header.Set(HeaderAcceptEncoding, "gzip")
+				req.Header.Set(HeaderOrigin, "http://example.com")
+
+				rec := httptest.NewRecorder()
+				app.ServeHTTP(rec, req)
+
+				skipped := rec.Header().Get(tc.header) == ""
+				if skipped != (url == "/metrics") {
+					st.Errorf("expected %s middleware to be skipped only for /metrics; got skipped %v for %s", tc.name, skipped, url)
+				}
+			}
+		})
+	}
+}
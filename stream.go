@@ -0,0 +1,103 @@
+package nano
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// streamFlushSize is buffered bytes size before it's flushed to the client.
+const streamFlushSize = 32 << 10
+
+// ErrSendOnlyChannel is returned by JSONStream when the object is send-only channel, which can't be read.
+var ErrSendOnlyChannel = errors.New("json stream could not receive from send-only channel")
+
+// bufferPool reuses buffers of json stream encoding.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// JSONStream writes json as response by encoding directly to the response writer,
+// so large payload isn't marshaled into a single []byte first.
+// Slice, array, and channel are encoded incrementally element by element,
+// channel is read until it's closed, []byte is encoded as base64 string like encoding/json does.
+// Since response header is already sent while encoding, the error is returned instead of writing error response.
+// ErrSendOnlyChannel is returned before anything is written when object is send-only channel.
+func (c *Context) JSONStream(statusCode int, object interface{}) error {
+	value := reflect.ValueOf(object)
+	if value.Kind() == reflect.Chan && value.Type().ChanDir() == reflect.SendDir {
+		return ErrSendOnlyChannel
+	}
+
+	c.SetContentType(MimeJSON)
+	c.Status(statusCode)
+
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return c.jsonCodec().NewEncoder(c.Writer).Encode(object)
+		}
+	case reflect.Array, reflect.Chan:
+	default:
+		return c.jsonCodec().NewEncoder(c.Writer).Encode(object)
+	}
+
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	buffer.Reset()
+	defer bufferPool.Put(buffer)
+
+	encoder := c.jsonCodec().NewEncoder(buffer)
+	buffer.WriteByte('[')
+
+	for i := 0; ; i++ {
+		var elem reflect.Value
+
+		if value.Kind() == reflect.Chan {
+			received, ok := value.Recv()
+			if !ok {
+				break
+			}
+			elem = received
+		} else {
+			if i >= value.Len() {
+				break
+			}
+			elem = value.Index(i)
+		}
+
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		if err := encoder.Encode(elem.Interface()); err != nil {
+			return err
+		}
+
+		if buffer.Len() >= streamFlushSize {
+			if err := c.flushBuffer(buffer); err != nil {
+				return err
+			}
+		}
+	}
+
+	buffer.WriteByte(']')
+
+	return c.flushBuffer(buffer)
+}
+
+// flushBuffer writes buffered bytes to the client and resets the buffer.
+func (c *Context) flushBuffer(buffer *bytes.Buffer) error {
+	if _, err := buffer.WriteTo(c.Writer); err != nil {
+		return err
+	}
+
+	if flusher, ok := c.Writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	stdjson "encoding/json"
+)
+
+func TestJSONStream(t *testing.T) {
+	type Item struct {
+		ID int `json:"id"`
+	}
+
+	items := make(chan Item)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			items <- Item{ID: i}
+		}
+		close(items)
+	}()
+
+	tt := []struct {
+		name   string
+		object interface{}
+		result string
+	}{
+		{"slice", []Item{{1}, {2}}, `[{"id":1},{"id":2}]`},
+		{"empty slice", []Item{}, `[]`},
+		{"channel", items, `[{"id":1},{"id":2},{"id":3}]`},
+		{"object", Item{ID: 1}, `{"id":1}`},
+		{"bytes", []byte("hi"), `"aGk="`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			ctx := newContext(rec, req)
+
+			if err := ctx.JSONStream(http.StatusOK, tc.object); err != nil {
+				st.Fatalf("expected error to be nil; got %v", err)
+			}
+
+			var result, expected interface{}
+			if err := stdjson.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				st.Fatalf("expected valid json; got %s", rec.Body.String())
+			}
+			stdjson.Unmarshal([]byte(tc.result), &expected)
+
+			if a, b := toJSONString(result), toJSONString(expected); a != b {
+				st.Errorf("expected body to be %s; got %s", b, a)
+			}
+
+			if contentType := rec.Header().Get(HeaderContentType); contentType != MimeJSON {
+				st.Errorf("expected content type to be %s; got %s", MimeJSON, contentType)
+			}
+		})
+	}
+}
+
+func TestJSONStreamSendOnlyChannel(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	ctx := newContext(rec, req)
+
+	var items chan<- int = make(chan int)
+	if err := ctx.JSONStream(http.StatusOK, items); err != ErrSendOnlyChannel {
+		t.Errorf("expected error to be ErrSendOnlyChannel; got %v", err)
+	}
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected nothing to be written; got %s", rec.Body.String())
+	}
+}
+
+func toJSONString(v interface{}) string {
+	s, _ := stdjson.Marshal(v)
+	return string(s)
+}
@@ -0,0 +1,96 @@
+package nano
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TenantBagKey is context bag key of tenant slug stored by Tenant middleware.
+const TenantBagKey = "tenant"
+
+// TenantConfig defines nano tenant middleware configuration.
+type TenantConfig struct {
+	// Level is subdomain level of the tenant slug, see Context.Subdomain. default is 1.
+	Level int
+	// Validate returns true when the tenant exists, it's optional.
+	Validate func(c *Context, tenant string) bool
+	// Handler responds request of missing or unknown tenant, default responds 404 status code.
+	Handler HandlerFunc
+}
+
+// SetBaseDomain functions to set domain of the application, e.g. example.com, so Context.Subdomain
+// reads labels on its left. Without base domain, the last two labels of the host are used as base domain,
+// which is wrong for domain like example.co.uk.
+func (ng *Engine) SetBaseDomain(domain string) {
+	ng.baseDomain = strings.ToLower(strings.Trim(domain, "."))
+}
+
+// Subdomain returns subdomain label of request host at level counted from the base domain,
+// e.g. level 1 of acme.api.example.com is api and level 2 is acme. Port is ignored.
+// It returns empty string when host isn't subdomain of the base domain, host is ip address,
+// or level is out of range.
+func (c *Context) Subdomain(level int) string {
+	if level < 1 {
+		return ""
+	}
+
+	host := c.Request.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(strings.Trim(host, "[]"), "."))
+	if host == "" || net.ParseIP(host) != nil {
+		return ""
+	}
+
+	var labels []string
+	if c.engine != nil && c.engine.baseDomain != "" {
+		prefix := strings.TrimSuffix(host, "."+c.engine.baseDomain)
+		if prefix == host {
+			return ""
+		}
+
+		labels = strings.Split(prefix, ".")
+	} else {
+		labels = strings.Split(host, ".")
+		if len(labels) <= 2 {
+			return ""
+		}
+
+		labels = labels[:len(labels)-2]
+	}
+
+	if level > len(labels) {
+		return ""
+	}
+
+	return labels[len(labels)-level]
+}
+
+// Tenant returns middleware reading tenant slug from subdomain, e.g. acme of acme.example.com.
+// The slug is stored in context bag as TenantBagKey, so KeyByBag(TenantBagKey) limits rate per tenant.
+// Request without tenant or unknown tenant rejected by Validate is responded with 404 status code.
+func Tenant(config TenantConfig) HandlerFunc {
+	if config.Level <= 0 {
+		config.Level = 1
+	}
+
+	if config.Handler == nil {
+		config.Handler = func(c *Context) {
+			c.String(http.StatusNotFound, "tenant not found")
+		}
+	}
+
+	return func(c *Context) {
+		tenant := c.Subdomain(config.Level)
+		if tenant == "" || (config.Validate != nil && !config.Validate(c, tenant)) {
+			config.Handler(c)
+			return
+		}
+
+		c.Bag.Set(TenantBagKey, tenant)
+		c.Next()
+	}
+}
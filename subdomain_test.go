@@ -0,0 +1,90 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubdomain(t *testing.T) {
+	tt := []struct {
+		name       string
+		baseDomain string
+		host       string
+		level      int
+		subdomain  string
+	}{
+		{"first level", "", "acme.example.com", 1, "acme"},
+		{"with port", "", "acme.example.com:8080", 1, "acme"},
+		{"upper case", "", "ACME.Example.com", 1, "acme"},
+		{"second level", "", "acme.api.example.com", 2, "acme"},
+		{"level out of range", "", "acme.example.com", 2, ""},
+		{"apex domain", "", "example.com", 1, ""},
+		{"ip address", "", "10.0.0.1:8080", 1, ""},
+		{"ipv6 address", "", "[::1]:8080", 1, ""},
+		{"base domain", "example.co.uk", "acme.example.co.uk", 1, "acme"},
+		{"base domain apex", "example.co.uk", "example.co.uk", 1, ""},
+		{"other domain", "example.co.uk", "acme.evil.com", 1, ""},
+		{"invalid level", "", "acme.example.com", 0, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Host = tc.host
+
+			app := New()
+			app.SetBaseDomain(tc.baseDomain)
+
+			ctx := newContext(httptest.NewRecorder(), req)
+			ctx.engine = app
+
+			if subdomain := ctx.Subdomain(tc.level); subdomain != tc.subdomain {
+				st.Errorf("expected subdomain to be %q; got %q", tc.subdomain, subdomain)
+			}
+		})
+	}
+}
+
+func TestTenant(t *testing.T) {
+	app := New()
+	app.SetBaseDomain("example.com")
+	app.Use(Tenant(TenantConfig{
+		Validate: func(c *Context, tenant string) bool { return tenant == "acme" },
+	}))
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "tenant %s", c.Bag.GetString(TenantBagKey))
+	})
+
+	tt := []struct {
+		name   string
+		host   string
+		status int
+		body   string
+	}{
+		{"known tenant", "acme.example.com", http.StatusOK, "tenant acme"},
+		{"unknown tenant", "globex.example.com", http.StatusNotFound, "tenant not found"},
+		{"missing tenant", "example.com", http.StatusNotFound, "tenant not found"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Host = tc.host
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package nano
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Renderer renders named template as response body, implement it to plug third-party template engine,
+// e.g. pongo2, jet, or templ. c is the current request context, e.g. to read its locale.
+type Renderer interface {
+	Render(w io.Writer, name string, data interface{}, c *Context) error
+}
+
+// SetRenderer functions to set template renderer used by Context.Render.
+// LoadHTMLGlob & LoadHTMLFS set the built-in html/template renderer.
+func (ng *Engine) SetRenderer(renderer Renderer) {
+	ng.renderer = renderer
+}
+
+// LoadHTMLGlob functions to parse html templates from files matching the pattern, e.g. templates/*.html.
+// In debug mode, templates are parsed again when the files change.
+func (ng *Engine) LoadHTMLGlob(pattern string) error {
+	return ng.loadTemplates(&templateRenderer{
+		parse: func() (*template.Template, error) {
+			return template.New("").Funcs(ng.templateFuncs).ParseGlob(pattern)
+		},
+		stamp: func() map[string]fileStamp {
+			names, _ := filepath.Glob(pattern)
+			return stampFiles(names, os.Stat)
+		},
+	})
+}
+
+// LoadHTMLFS functions to parse html templates from file system, e.g. embed.FS, so templates are shipped in the binary.
+// In debug mode, templates are parsed again when the files change, e.g. os.DirFS during development.
+func (ng *Engine) LoadHTMLFS(fsys fs.FS, patterns ...string) error {
+	return ng.loadTemplates(&templateRenderer{
+		parse: func() (*template.Template, error) {
+			return template.New("").Funcs(ng.templateFuncs).ParseFS(fsys, patterns...)
+		},
+		stamp: func() map[string]fileStamp {
+			names := make([]string, 0)
+			for _, pattern := range patterns {
+				matches, _ := fs.Glob(fsys, pattern)
+				names = append(names, matches...)
+			}
+
+			return stampFiles(names, func(name string) (fs.FileInfo, error) {
+				return fs.Stat(fsys, name)
+			})
+		},
+	})
+}
+
+// loadTemplates parses templates of the renderer and sets it as engine renderer.
+func (ng *Engine) loadTemplates(renderer *templateRenderer) error {
+	if err := renderer.reload(); err != nil {
+		return err
+	}
+
+	ng.renderer = renderer
+
+	return nil
+}
+
+// Render writes template rendered by engine renderer as response, see LoadHTMLGlob, LoadHTMLFS, and SetRenderer.
+// template is rendered into buffer first, so failed rendering is responded with 500 status code instead of partial html.
+func (c *Context) Render(statusCode int, name string, data interface{}) {
+	if c.engine == nil || c.engine.renderer == nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	buffer.Reset()
+	defer bufferPool.Put(buffer)
+
+	if err := c.engine.renderer.Render(buffer, name, data, c); err != nil {
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	c.SetContentType(MimeHTML)
+	c.Status(statusCode)
+	c.Writer.Write(buffer.Bytes())
+}
+
+// templateRenderer is the built-in html/template renderer.
+type templateRenderer struct {
+	parse func() (*template.Template, error)
+	// stamp returns version of each template file, it's used to detect changes in debug mode.
+	stamp func() map[string]fileStamp
+
+	mu        sync.RWMutex
+	templates *template.Template
+	stamps    map[string]fileStamp
+}
+
+// Render executes named template, templates are parsed again first when they change in debug mode.
+// the previous templates are kept when parsing fails, so a typo doesn't break other pages.
+func (r *templateRenderer) Render(w io.Writer, name string, data interface{}, c *Context) error {
+	if c.engine != nil && c.engine.debug && r.changed() {
+		r.reload()
+	}
+
+	r.mu.RLock()
+	templates := r.templates
+	r.mu.RUnlock()
+
+	return templates.ExecuteTemplate(w, name, data)
+}
+
+// changed returns true when template files differ from the parsed version.
+func (r *templateRenderer) changed() bool {
+	current := r.stamp()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(changedFiles(r.stamps, current)) > 0
+}
+
+// reload parses templates and records version of their files.
+func (r *templateRenderer) reload() error {
+	stamps := r.stamp()
+
+	templates, err := r.parse()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.templates, r.stamps = templates, stamps
+	r.mu.Unlock()
+
+	return nil
+}
+
+// stampFiles returns version of each file, file that couldn't be stat is skipped.
+func stampFiles(names []string, stat func(name string) (fs.FileInfo, error)) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(names))
+
+	for _, name := range names {
+		if info, err := stat(name); err == nil {
+			stamps[name] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	return stamps
+}
@@ -0,0 +1,158 @@
+package nano
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestStaticFSAndTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"public/css/app.css":   {Data: []byte("body{}")},
+		"templates/hello.html": {Data: []byte(`{{define "hello"}}<p>hello {{.}}</p>{{end}}`)},
+	}
+
+	app := New()
+	app.StaticFS("/assets", fsys, "public")
+	if err := app.LoadHTMLFS(fsys, "templates/*.html"); err != nil {
+		t.Fatalf("expected templates to be loaded; got %v", err)
+	}
+
+	app.GET("/hello", func(c *Context) {
+		c.Render(http.StatusOK, "hello", "<nano>")
+	})
+
+	app.GET("/missing", func(c *Context) {
+		c.Render(http.StatusOK, "missing", nil)
+	})
+
+	request := func(url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	if rec := request("/assets/css/app.css"); rec.Code != http.StatusOK || rec.Body.String() != "body{}" {
+		t.Errorf("expected embedded file to be served; got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := request("/assets/templates/hello.html"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected file outside sub directory not to be served; got %d", rec.Code)
+	}
+
+	if body := request("/hello").Body.String(); body != "<p>hello &lt;nano&gt;</p>" {
+		t.Errorf("expected template to be rendered with escaped data; got %s", body)
+	}
+
+	if rec := request("/missing"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected missing template to be 500; got %d", rec.Code)
+	}
+}
+
+func TestTemplateReload(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "page.html")
+
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("could not write template: %v", err)
+		}
+	}
+
+	write(`{{define "page"}}v1{{end}}`)
+
+	render := func(app *Engine) string {
+		c, _ := CreateTestContext(httptest.NewRecorder())
+		c.engine = app
+
+		buffer := new(bytes.Buffer)
+		if err := app.renderer.Render(buffer, "page", nil, c); err != nil {
+			t.Fatalf("expected template to be rendered; got %v", err)
+		}
+
+		return buffer.String()
+	}
+
+	debugApp, releaseApp := New(), New()
+	debugApp.SetDebug(true)
+
+	for _, app := range []*Engine{debugApp, releaseApp} {
+		if err := app.LoadHTMLGlob(filepath.Join(dir, "*.html")); err != nil {
+			t.Fatalf("expected templates to be loaded; got %v", err)
+		}
+	}
+
+	write(`{{define "page"}}version 2{{end}}`)
+
+	if body := render(debugApp); body != "version 2" {
+		t.Errorf("expected changed template to be parsed again in debug mode; got %s", body)
+	}
+
+	if body := render(releaseApp); body != "v1" {
+		t.Errorf("expected template to be kept outside debug mode; got %s", body)
+	}
+
+	write(`{{define "page"}}{{.Broken}{{end}}`)
+
+	if body := render(debugApp); body != "version 2" {
+		t.Errorf("expected previous template to be kept when parsing fails; got %s", body)
+	}
+}
+
+// upperRenderer renders template name in upper case.
+type upperRenderer struct{}
+
+func (upperRenderer) Render(w io.Writer, name string, data interface{}, c *Context) error {
+	if name == "fail" {
+		return errors.New("render failed")
+	}
+
+	_, err := fmt.Fprintf(w, "%s %v %s", strings.ToUpper(name), data, c.Method)
+	return err
+}
+
+func TestCustomRenderer(t *testing.T) {
+	app := New()
+	app.SetRenderer(upperRenderer{})
+
+	app.GET("/:name", func(c *Context) {
+		c.Render(http.StatusOK, c.Param("name"), 1)
+	})
+
+	tt := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"home", http.StatusOK, "HOME 1 GET"},
+		{"fail", http.StatusInternalServerError, "internal server error"},
+	}
+
+	for _, tc := range tt {
+		req, err := http.NewRequest(http.MethodGet, "/"+tc.name, nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status || rec.Body.String() != tc.body {
+			t.Errorf("expected response to be %d %s; got %d %s", tc.status, tc.body, rec.Code, rec.Body.String())
+		}
+	}
+}
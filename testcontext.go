@@ -0,0 +1,33 @@
+package nano
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// CreateTestContext functions to create context for handler unit test, without going through the router.
+// the context serves GET / request by default, replace it using SetRequest. Params could be set directly.
+func CreateTestContext(w http.ResponseWriter) (*Context, *Engine) {
+	engine := New()
+
+	c := newContext(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	c.engine = engine
+	c.Params = make(map[string]string)
+
+	return c, engine
+}
+
+// SetRequest functions to replace request of the context, e.g. in handler unit test.
+// Method, Path, and Origin are updated from the request.
+func (c *Context) SetRequest(r *http.Request) {
+	c.Request = r
+	c.Method = r.Method
+	c.Path = r.URL.Path
+	c.Origin = r.Header.Get(HeaderOrigin)
+}
+
+// SetHandlers functions to replace handlers stack of the context, they're called by Next starting from the first one.
+func (c *Context) SetHandlers(handlers ...HandlerFunc) {
+	c.handlers = handlers
+	c.cursor = -1
+}
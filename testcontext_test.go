@@ -0,0 +1,53 @@
+package nano
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateTestContext(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, app := CreateTestContext(rec)
+
+	if app == nil || c.engine != app {
+		t.Fatalf("expected context to belong to returned engine")
+	}
+
+	if c.Method != http.MethodGet || c.Path != "/" {
+		t.Errorf("expected default request to be GET /; got %s %s", c.Method, c.Path)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42", strings.NewReader(`{"name":"john"}`))
+	req.Header.Set(HeaderContentType, MimeJSON)
+	c.SetRequest(req)
+	c.Params["id"] = "42"
+
+	calls := make([]string, 0)
+	c.SetHandlers(func(c *Context) {
+		calls = append(calls, "middleware")
+		c.Next()
+	}, func(c *Context) {
+		var user struct {
+			Name string `json:"name"`
+		}
+
+		if err := c.BindJSON(&user); err != nil {
+			c.String(http.StatusBadRequest, "bad request")
+			return
+		}
+
+		calls = append(calls, "handler")
+		c.String(http.StatusOK, "%s %s %s", c.Method, c.Param("id"), user.Name)
+	})
+	c.Next()
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "POST 42 john" {
+		t.Errorf("expected response to be 200 POST 42 john; got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("expected both handlers to be called; got %v", calls)
+	}
+}
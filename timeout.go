@@ -0,0 +1,218 @@
+package nano
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeoutConfig defines nano timeout middleware configuration.
+type TimeoutConfig struct {
+	// Timeout is maximum duration of the rest of handlers stack.
+	Timeout time.Duration
+	// Fallback writes response when the handler times out, it's used when router group doesn't define its own
+	// fallback using OnTimeout. default responds 503 status code.
+	Fallback HandlerFunc
+}
+
+// Timeout returns timeout middleware with given duration.
+func Timeout(timeout time.Duration) HandlerFunc {
+	return TimeoutWithConfig(TimeoutConfig{Timeout: timeout})
+}
+
+// TimeoutWithConfig returns middleware which responds with fallback when the rest of handlers stack
+// takes longer than config.Timeout, and emits EventRequestTimeout. It panics when config.Timeout isn't positive.
+// Handler keeps running in background, its request context is cancelled so it could stop early,
+// and its response is discarded. Its after-response hooks are called once it's done.
+// Response is buffered, so streaming & hijacking are not supported.
+// panic of handler is re-thrown in serving goroutine, so Recovery middleware keeps working.
+func TimeoutWithConfig(config TimeoutConfig) HandlerFunc {
+	return timeoutHandler(config, false)
+}
+
+// timeoutHandler returns timeout middleware, middleware which isn't route-level is skipped
+// when matching route has its own timeout set by Route.WithTimeout.
+// it panics when timeout isn't positive, otherwise every request would time out immediately.
+func timeoutHandler(config TimeoutConfig, routeLevel bool) HandlerFunc {
+	if config.Timeout <= 0 {
+		panic("timeout middleware requires positive timeout")
+	}
+
+	if config.Fallback == nil {
+		config.Fallback = func(c *Context) {
+			c.String(http.StatusServiceUnavailable, "request timeout")
+		}
+	}
+
+	return func(c *Context) {
+		// route-level timeout applies instead.
+		if !routeLevel && c.override != nil && c.override.timeout > 0 {
+			c.Next()
+			return
+		}
+
+		// fallback uses copy of the context, so it doesn't share state with the handler goroutine.
+		// its request isn't cancelled by the timeout, so the fallback could respond.
+		fallbackContext := *c
+		fallbackContext.handlers = nil
+
+		parent := c.Request.Context()
+		ctx, cancel := context.WithTimeout(parent, config.Timeout)
+		defer cancel()
+
+		// handler runs on copy of the context, so handler which keeps running after the timeout
+		// doesn't share state with the serving goroutine.
+		// its hooks & scoped dependencies are fresh, so appending them doesn't race with the fallback,
+		// after-response hooks of the handler are merged back when it's done in time.
+		writer := newTimeoutWriter(c.Writer.Header())
+		handler := *c
+		handler.Request = c.Request.WithContext(ctx)
+		handler.Writer = writer
+		handler.beforeWrite = c.beforeWrite[:len(c.beforeWrite):len(c.beforeWrite)]
+		handler.afterResponse = nil
+		handler.scoped = make(map[string]interface{}, len(c.scoped))
+		for key, value := range c.scoped {
+			handler.scoped[key] = value
+		}
+
+		done := make(chan interface{}, 1)
+		go func() {
+			defer func() { done <- recover() }()
+			handler.Next()
+		}()
+
+		select {
+		case recovered := <-done:
+			afterResponse := c.afterResponse
+			*c = handler
+			c.Writer = fallbackContext.Writer
+			c.Request = handler.Request.WithContext(parent)
+			c.afterResponse = append(afterResponse, handler.afterResponse...)
+			if recovered != nil {
+				panic(recovered)
+			}
+
+			writer.flushTo(fallbackContext.Writer)
+		case <-ctx.Done():
+			writer.timeout()
+
+			// handler still owns its after-response hooks, e.g. closing scoped transaction or removing
+			// uploaded files, so they are called once it's done.
+			go func() {
+				<-done
+				handler.runAfterResponse()
+			}()
+
+			// client has gone away, there is nobody to respond to.
+			if ctx.Err() != context.DeadlineExceeded {
+				return
+			}
+
+			fallbackWriter := newTimeoutWriter(fallbackContext.Writer.Header())
+			response := fallbackContext.Writer
+			fallbackContext.Writer = fallbackWriter
+			config.fallbackOf(&fallbackContext)(&fallbackContext)
+			fallbackWriter.flushTo(response)
+
+			fallbackContext.Writer = response
+			fallbackContext.emit(EventRequestTimeout, fallbackWriter.status, nil)
+		}
+	}
+}
+
+// fallbackOf returns timeout fallback of the most specific router group matching request path.
+func (config TimeoutConfig) fallbackOf(c *Context) HandlerFunc {
+	if c.engine == nil {
+		return config.Fallback
+	}
+
+	fallback, prefix := config.Fallback, -1
+	for _, group := range c.engine.groups {
+		if group.timeoutFallback != nil && len(group.prefix) > prefix && strings.HasPrefix(c.Request.URL.Path, group.prefix) {
+			fallback, prefix = group.timeoutFallback, len(group.prefix)
+		}
+	}
+
+	return fallback
+}
+
+// OnTimeout functions to set response of Timeout middleware for this group, e.g. json error for api
+// and html page for web, so each group could have its own timeout behavior.
+func (rg *RouterGroup) OnTimeout(fallback HandlerFunc) {
+	rg.engine.router.mustNotBeFrozen("set timeout fallback")
+
+	rg.timeoutFallback = fallback
+}
+
+// timeoutWriter buffers response until handler is done, so it could be discarded on timeout.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	status   int
+	body     bytes.Buffer
+	timedOut bool
+}
+
+// newTimeoutWriter creates writer with copy of current response headers.
+func newTimeoutWriter(header http.Header) *timeoutWriter {
+	return &timeoutWriter{header: header.Clone()}
+}
+
+// Header returns buffered response headers.
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader buffers response status code.
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut || w.status != 0 {
+		return
+	}
+
+	w.status = code
+}
+
+// Write buffers response body, it returns http.ErrHandlerTimeout after timeout.
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.body.Write(data)
+}
+
+// timeout discards buffered response.
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.timedOut = true
+}
+
+// flushTo writes buffered response into w.
+func (w *timeoutWriter) flushTo(response http.ResponseWriter) {
+	header := response.Header()
+	for name, values := range w.header {
+		header[name] = values
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	response.WriteHeader(w.status)
+	response.Write(w.body.Bytes())
+}
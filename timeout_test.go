@@ -0,0 +1,161 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	var timeouts []string
+
+	app := New()
+	app.On(EventRequestTimeout, func(event Event) {
+		timeouts = append(timeouts, event.Context.Path)
+	})
+	app.Use(Timeout(20 * time.Millisecond))
+
+	slow := func(c *Context) {
+		<-c.Request.Context().Done()
+		c.String(http.StatusOK, "too late")
+	}
+
+	api := app.Group("/api")
+	api.OnTimeout(func(c *Context) {
+		c.JSON(http.StatusGatewayTimeout, H{"error": "timeout"})
+	})
+	api.GET("/slow", slow)
+
+	web := app.Group("/web")
+	web.OnTimeout(func(c *Context) {
+		c.HTML(http.StatusServiceUnavailable, "<h1>please try again</h1>")
+	})
+	web.GET("/slow", slow)
+
+	app.GET("/slow", slow)
+	app.GET("/fast", func(c *Context) {
+		c.SetHeader("X-Handler", "fast")
+		c.String(http.StatusCreated, "done")
+	})
+
+	tt := []struct {
+		path        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"/api/slow", http.StatusGatewayTimeout, MimeJSON, `{"error":"timeout"}`},
+		{"/web/slow", http.StatusServiceUnavailable, MimeHTML, "<h1>please try again</h1>"},
+		{"/slow", http.StatusServiceUnavailable, MimePlainText, "request timeout"},
+		{"/fast", http.StatusCreated, MimePlainText, "done"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d", tc.status, rec.Code)
+			}
+
+			if contentType := rec.Header().Get(HeaderContentType); contentType != tc.contentType {
+				st.Errorf("expected content type to be %s; got %s", tc.contentType, contentType)
+			}
+
+			if body := rec.Body.String(); body != tc.body {
+				st.Errorf("expected body to be %s; got %s", tc.body, body)
+			}
+		})
+	}
+
+	if len(timeouts) != 3 {
+		t.Errorf("expected 3 timeout events; got %v", timeouts)
+	}
+}
+
+func TestTimeoutWithoutDuration(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected timeout middleware with %v timeout to panic", timeout)
+				}
+			}()
+
+			Timeout(timeout)
+		}()
+	}
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	app := New()
+	app.Use(Recovery())
+	app.Use(Timeout(time.Second))
+	app.GET("/", func(c *Context) {
+		panic("boom")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status code to be %d; got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestTimeoutAfterResponse(t *testing.T) {
+	closed := make(chan *testTx, 1)
+
+	app := New()
+	app.ProvideScoped("tx", func(c *Context) (interface{}, error) {
+		tx := &testTx{}
+		c.AfterResponse(func() {
+			closed <- tx
+		})
+
+		return tx, nil
+	})
+	app.Use(Timeout(20 * time.Millisecond))
+	app.GET("/", func(c *Context) {
+		if _, err := c.Resolve("tx"); err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		<-c.Request.Context().Done()
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status code to be %d; got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	select {
+	case tx := <-closed:
+		if !tx.closed {
+			t.Errorf("expected scoped transaction to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected after-response hooks of timed-out handler to be called")
+	}
+}
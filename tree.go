@@ -1,6 +1,9 @@
 package nano
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // node defines tree node.
 type node struct {
@@ -8,19 +11,25 @@ type node struct {
 	urlPart    string
 	childrens  []*node
 	isWildcard bool
+	// param describes parameter part of the node, it's nil for static part.
+	param *routeParam
+	// params describes parts of url pattern, it's set on node completing url pattern.
+	params []*routeParam
 }
 
 // insertChildren inserts node as children.
 // this function calls recursively as length of urlParts and cursor position (level)
-func (n *node) insertChildren(urlPattern string, urlParts []string, level int) {
+// it returns error when parameter is already registered using another name at the same position.
+func (n *node) insertChildren(urlPattern string, urlParts []string, params []*routeParam, level int) error {
 
 	// last inserted node cause cursor (level) has reached maximum value.
 	// stop recursive calls.
 	if len(urlParts) == level {
 		// fill url pattern to marks current node as complete url pattern.
 		n.urlPattern = urlPattern
+		n.params = params
 
-		return
+		return nil
 	}
 
 	urlPart := urlParts[level]
@@ -30,14 +39,25 @@ func (n *node) insertChildren(urlPattern string, urlParts []string, level int) {
 	if child == nil {
 		// current url part is not already registered as children node.
 		// register children now.
-		isWildcard := urlPart[0] == ':' || urlPart[0] == '*'
-		child = &node{urlPart: urlPart, isWildcard: isWildcard}
+		param := params[level]
+		isWildcard := param != nil
+
+		// only one parameter is allowed at the same position, otherwise the request is ambiguous.
+		if isWildcard {
+			for _, sibling := range n.childrens {
+				if sibling.isWildcard {
+					return fmt.Errorf("%s conflicts with %s, parameter %s is already registered as %s", urlPattern, sibling.anyPattern(), urlPart, sibling.urlPart)
+				}
+			}
+		}
+
+		child = &node{urlPart: urlPart, isWildcard: isWildcard, param: param}
 		n.childrens = append(n.childrens, child)
 	}
 
 	// insert next urlParts as next level children.
 	// moving cursor to next urlParts.
-	child.insertChildren(urlPattern, urlParts, level+1)
+	return child.insertChildren(urlPattern, urlParts, params, level+1)
 }
 
 // findChildren is functions to find children by url part value.
@@ -46,8 +66,7 @@ func (n *node) findChildren(urlPart string) *node {
 
 	// scanning for children
 	for _, child := range n.childrens {
-		// if current child url part is match or contain wildcard, so it's found.
-		if child.urlPart == urlPart || child.isWildcard {
+		if child.urlPart == urlPart {
 			return child
 		}
 	}
@@ -56,12 +75,28 @@ func (n *node) findChildren(urlPart string) *node {
 	return nil
 }
 
+// anyPattern returns url pattern of the node or one of its descendants.
+func (n *node) anyPattern() string {
+	if n.urlPattern != "" {
+		return n.urlPattern
+	}
+
+	for _, child := range n.childrens {
+		if pattern := child.anyPattern(); pattern != "" {
+			return pattern
+		}
+	}
+
+	return ""
+}
+
 // findNode finds a node.
 // first (n *node) may be node that located at router.nodes[requestMethod].
-func (n *node) findNode(searchParts []string, level int) *node {
+// static parts are compared case-insensitively when ignoreCase is true.
+func (n *node) findNode(searchParts []string, level int, ignoreCase bool) *node {
 	// cursor (level) reached maximum position.
 	// or current url part has * wildcard
-	if len(searchParts) == level || strings.HasPrefix(n.urlPart, "*") {
+	if len(searchParts) == level || (n.param != nil && n.param.catchAll) {
 		// if current pattern has no url pattern, this mean current node doesn't complete.
 		// not found.
 		if n.urlPattern == "" {
@@ -76,27 +111,35 @@ func (n *node) findNode(searchParts []string, level int) *node {
 
 	// scan for nested childrens*.
 	// *please read about getChildren.
-	for _, child := range n.getChildren(urlPart) {
+	for _, child := range n.getChildren(urlPart, ignoreCase) {
 		// move cursor, scan recursively.
-		result := child.findNode(searchParts, level+1)
+		result := child.findNode(searchParts, level+1, ignoreCase)
 		// found!
 		if result != nil {
 			return result
 		}
-
-		return nil
 	}
 
 	return nil
 }
 
 // getChildren finds a children that has certain part
-// or it's a wildcard
-func (n *node) getChildren(urlPart string) []*node {
+// or it's a wildcard accepting the part. static children come first, so they take precedence over parameter.
+func (n *node) getChildren(urlPart string, ignoreCase bool) []*node {
 	nodes := make([]*node, 0)
 
 	for _, node := range n.childrens {
-		if node.urlPart == urlPart || node.isWildcard {
+		if node.isWildcard {
+			continue
+		}
+
+		if node.urlPart == urlPart || (ignoreCase && strings.EqualFold(node.urlPart, urlPart)) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	for _, node := range n.childrens {
+		if node.isWildcard && node.param.accepts(urlPart) {
 			nodes = append(nodes, node)
 		}
 	}
@@ -0,0 +1,77 @@
+package nano
+
+import "crypto/subtle"
+
+// HeaderXInternalToken is header used by internal clients to identify themselves.
+const HeaderXInternalToken = "X-Internal-Token"
+
+// TrustedCallerConfig defines how internal clients are verified.
+type TrustedCallerConfig struct {
+	// Header is request header carrying caller token, default to X-Internal-Token.
+	Header string
+	// Tokens is list of accepted internal caller tokens.
+	Tokens []string
+	// ClientNames is list of accepted common names or DNS names of verified TLS client certificate.
+	// it requires tls.Config.ClientAuth to verify client certificates.
+	ClientNames []string
+	// Verify is custom verification, it's called when neither token nor client certificate matches.
+	Verify func(c *Context) bool
+}
+
+// TrustedCaller marks verified internal clients as trusted.
+// Binding of trusted caller skips struct validation, and middlewares wrapped with UnlessTrusted are skipped,
+// so internal traffic avoids expensive checks while public traffic stays fully validated.
+// Attach it per route group, e.g. internal := app.Group("/internal"); internal.Use(nano.TrustedCaller(config)).
+func TrustedCaller(config TrustedCallerConfig) HandlerFunc {
+	if config.Header == "" {
+		config.Header = HeaderXInternalToken
+	}
+
+	return func(c *Context) {
+		c.trusted = config.verify(c)
+		// token must not leak to upstream services or logs.
+		c.Request.Header.Del(config.Header)
+
+		c.Next()
+	}
+}
+
+// verify returns true when request comes from trusted caller.
+func (config TrustedCallerConfig) verify(c *Context) bool {
+	if token := c.Request.Header.Get(config.Header); token != "" {
+		for _, expected := range config.Tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+				return true
+			}
+		}
+	}
+
+	if tls := c.Request.TLS; tls != nil && len(tls.VerifiedChains) > 0 && len(tls.VerifiedChains[0]) > 0 {
+		cert := tls.VerifiedChains[0][0]
+
+		for _, name := range config.ClientNames {
+			if cert.Subject.CommonName == name {
+				return true
+			}
+
+			for _, dnsName := range cert.DNSNames {
+				if dnsName == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return config.Verify != nil && config.Verify(c)
+}
+
+// IsTrusted returns true when request comes from internal client verified by TrustedCaller middleware.
+func (c *Context) IsTrusted() bool {
+	return c.trusted
+}
+
+// UnlessTrusted wraps middleware, so it's skipped for trusted callers.
+// It's useful for expensive sanitization layers that internal traffic doesn't need.
+func UnlessTrusted(middleware HandlerFunc) HandlerFunc {
+	return Unless(middleware, (*Context).IsTrusted)
+}
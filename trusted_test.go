@@ -0,0 +1,94 @@
+package nano
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTrustedCaller(t *testing.T) {
+	type Payload struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	config := TrustedCallerConfig{
+		Tokens:      []string{"secret"},
+		ClientNames: []string{"billing.internal"},
+	}
+
+	tt := []struct {
+		name      string
+		token     string
+		cert      *x509.Certificate
+		status    int
+		sanitized bool
+	}{
+		{"public caller", "", nil, http.StatusUnprocessableEntity, true},
+		{"invalid token", "guess", nil, http.StatusUnprocessableEntity, true},
+		{"valid token", "secret", nil, http.StatusOK, false},
+		{"valid client certificate", "", &x509.Certificate{Subject: pkix.Name{CommonName: "billing.internal"}}, http.StatusOK, false},
+		{"unknown client certificate", "", &x509.Certificate{DNSNames: []string{"other.internal"}}, http.StatusUnprocessableEntity, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if tc.status == http.StatusUnprocessableEntity {
+				skipWithoutValidator(st)
+			}
+
+			sanitized := false
+
+			app := New()
+			internal := app.Group("/internal")
+			internal.Use(TrustedCaller(config))
+			internal.Use(UnlessTrusted(func(c *Context) {
+				sanitized = true
+				c.Next()
+			}))
+			internal.POST("/users", func(c *Context) {
+				if token := c.GetRequestHeader(HeaderXInternalToken); token != "" {
+					c.String(http.StatusInternalServerError, "token leaked")
+					return
+				}
+
+				var payload Payload
+				if err := c.BindJSON(&payload); err != nil {
+					c.String(err.(ErrBinding).Status, err.Error())
+					return
+				}
+
+				c.String(http.StatusOK, "ok")
+			})
+
+			req, err := http.NewRequest(http.MethodPost, "/internal/users", strings.NewReader(`{}`))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeJSON)
+
+			if tc.token != "" {
+				req.Header.Set(HeaderXInternalToken, tc.token)
+			}
+
+			if tc.cert != nil {
+				req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{tc.cert}}}
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				st.Errorf("expected status code to be %d; got %d (%s)", tc.status, rec.Code, rec.Body.String())
+			}
+
+			if sanitized != tc.sanitized {
+				st.Errorf("expected sanitized to be %v; got %v", tc.sanitized, sanitized)
+			}
+		})
+	}
+}
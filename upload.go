@@ -0,0 +1,210 @@
+package nano
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// fileSizeUnits are units of maxsize rule, e.g. maxsize=2MB.
+var fileSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// isFileField returns true when field receives uploaded file, that is *multipart.FileHeader or []*multipart.FileHeader.
+func isFileField(t reflect.Type) bool {
+	return t == fileHeaderType || t == fileHeadersType
+}
+
+// bindFiles maps uploaded files into fields that have `file` tag, e.g. `file:"avatar"`.
+// nested struct shares the same form namespace with its parent.
+func bindFiles(files map[string][]*multipart.FileHeader, structValue reflect.Value) {
+	structType := structValue.Type()
+
+	for i := 0; i < structValue.NumField(); i++ {
+		fieldValue := structValue.Field(i)
+		fieldType := structType.Field(i)
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			bindFiles(files, fieldValue)
+			continue
+		}
+
+		name := strings.SplitN(fieldType.Tag.Get("file"), ",", 2)[0]
+		if name == "" || name == "-" || len(files[name]) == 0 {
+			continue
+		}
+
+		switch fieldType.Type {
+		case fileHeaderType:
+			fieldValue.Set(reflect.ValueOf(files[name][0]))
+		case fileHeadersType:
+			fieldValue.Set(reflect.ValueOf(files[name]))
+		}
+	}
+}
+
+// checkFiles returns error message of each uploaded file violating maxsize or mime rule of `validate` tag,
+// e.g. `validate:"required,maxsize=2MB,mime=image/png image/jpeg"`. missing file is reported by required rule.
+func checkFiles(value reflect.Value) []string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errFields []string
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			errFields = append(errFields, checkFiles(value.Field(i))...)
+			continue
+		}
+
+		if !isFileField(field.Type) {
+			continue
+		}
+
+		var files []*multipart.FileHeader
+		switch file := value.Field(i).Interface().(type) {
+		case *multipart.FileHeader:
+			if file != nil {
+				files = append(files, file)
+			}
+		case []*multipart.FileHeader:
+			files = file
+		}
+
+		name := strings.SplitN(field.Tag.Get("file"), ",", 2)[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		for _, file := range files {
+			errFields = append(errFields, checkFile(file, name, field.Tag.Get("validate"))...)
+		}
+	}
+
+	return errFields
+}
+
+// checkFile returns error message of uploaded file violating maxsize or mime rule.
+// it panics when the rule is malformed.
+func checkFile(file *multipart.FileHeader, name, rules string) []string {
+	var errFields []string
+
+	for _, rule := range strings.Split(rules, ",") {
+		parts := strings.SplitN(rule, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "maxsize":
+			limit, err := parseFileSize(parts[1])
+			if err != nil {
+				panic(fmt.Sprintf("invalid maxsize rule of %s: %v", name, err))
+			}
+
+			if file.Size > limit {
+				errFields = append(errFields, fmt.Sprintf("%s %s must be at most %s", name, file.Filename, parts[1]))
+			}
+
+		case "mime":
+			allowed := strings.Fields(parts[1])
+			if !fileTypeAllowed(fileMimeType(file), allowed) {
+				errFields = append(errFields, fmt.Sprintf("%s %s must be one of [%s]", name, file.Filename, strings.Join(allowed, " ")))
+			}
+		}
+	}
+
+	return errFields
+}
+
+// parseFileSize parses file size of maxsize rule, e.g. 512KB, 2MB, or 1024 (bytes).
+func parseFileSize(text string) (int64, error) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+
+	for _, unit := range fileSizeUnits {
+		if strings.HasSuffix(text, unit.suffix) {
+			size, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(text, unit.suffix)), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid file size %s", text)
+			}
+
+			return int64(size * float64(unit.size)), nil
+		}
+	}
+
+	size, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file size %s", text)
+	}
+
+	return size, nil
+}
+
+// fileMimeType detects content type of uploaded file from its first 512 bytes,
+// content type sent by client is used when the content couldn't be recognized.
+func fileMimeType(file *multipart.FileHeader) string {
+	declared := mediaType(file.Header.Get(HeaderContentType))
+
+	f, err := file.Open()
+	if err != nil {
+		return declared
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, _ := f.Read(head)
+
+	detected := mediaType(http.DetectContentType(head[:n]))
+	if detected == "application/octet-stream" && declared != "" {
+		return declared
+	}
+
+	return detected
+}
+
+// fileTypeAllowed returns true when content type matches one of allowed types, wildcard subtype is supported, e.g. image/*.
+func fileTypeAllowed(contentType string, allowed []string) bool {
+	for _, option := range allowed {
+		option = strings.ToLower(option)
+
+		if option == contentType || (strings.HasSuffix(option, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(option, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+package nano
+
+import (
+	"bytes"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFileBinding(t *testing.T) {
+	type Profile struct {
+		Name        string                  `form:"name"`
+		Avatar      *multipart.FileHeader   `file:"avatar" validate:"required,maxsize=1KB,mime=image/png image/jpeg"`
+		Attachments []*multipart.FileHeader `file:"attachments" validate:"maxsize=16B,mime=text/*"`
+	}
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	type upload struct {
+		field, filename string
+		content         []byte
+	}
+
+	tt := []struct {
+		name    string
+		uploads []upload
+		fields  []string
+	}{
+		{"valid files", []upload{{"avatar", "me.png", png}, {"attachments", "a.txt", []byte("hello")}, {"attachments", "b.txt", []byte("world")}}, nil},
+		{"missing required file", []upload{{"attachments", "a.txt", []byte("hello")}}, []string{"avatar is a required field"}},
+		{"file violates rules", []upload{{"avatar", "me.gif", []byte("GIF89a")}, {"attachments", "a.txt", []byte("this text is too long")}}, []string{
+			"avatar me.gif must be one of [image/png image/jpeg]",
+			"attachments a.txt must be at most 16B",
+		}},
+		{"size is checked", []upload{{"avatar", "me.png", append(png, make([]byte, 1024)...)}}, []string{"avatar me.png must be at most 1KB"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			if tc.name == "missing required file" {
+				skipWithoutValidator(st)
+			}
+
+			body := new(bytes.Buffer)
+			form := multipart.NewWriter(body)
+			form.WriteField("name", "foo")
+			for _, file := range tc.uploads {
+				part, _ := form.CreateFormFile(file.field, file.filename)
+				part.Write(file.content)
+			}
+			form.Close()
+
+			req, err := http.NewRequest(http.MethodPost, "/", body)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, form.FormDataContentType())
+
+			ctx := newContext(httptest.NewRecorder(), req)
+
+			var profile Profile
+			err = ctx.Bind(&profile)
+
+			if tc.fields == nil {
+				if err != nil {
+					st.Fatalf("expected error to be nil; got %v", err)
+				}
+
+				if profile.Name != "foo" || profile.Avatar == nil || profile.Avatar.Filename != "me.png" {
+					st.Errorf("expected avatar me.png to be bound; got %+v", profile)
+				}
+
+				if len(profile.Attachments) != 2 {
+					st.Errorf("expected 2 attachments to be bound; got %d", len(profile.Attachments))
+				}
+
+				return
+			}
+
+			errBinding, ok := err.(ErrBinding)
+			if !ok || errBinding.Status != http.StatusUnprocessableEntity {
+				st.Fatalf("expected error to be 422 ErrBinding; got %v", err)
+			}
+
+			if !reflect.DeepEqual(errBinding.Fields, tc.fields) {
+				st.Errorf("expected fields to be %v; got %v", tc.fields, errBinding.Fields)
+			}
+		})
+	}
+}
+
+func TestParseFileSize(t *testing.T) {
+	tt := map[string]int64{"512": 512, "100B": 100, "2KB": 2048, "2MB": 2 << 20, "1.5mb": 3 << 19, "1GB": 1 << 30}
+
+	for text, expected := range tt {
+		size, err := parseFileSize(text)
+		if err != nil || size != expected {
+			t.Errorf("expected size of %s to be %d; got %d (%v)", text, expected, size, err)
+		}
+	}
+
+	if _, err := parseFileSize("2XB"); err == nil {
+		t.Errorf("expected invalid size to return error")
+	}
+}
@@ -1,44 +1,27 @@
 package nano
 
 import (
+	"errors"
 	"net/http"
 	"reflect"
-	"strings"
-
-	"github.com/go-playground/locales/en"
-	ut "github.com/go-playground/universal-translator"
-	"github.com/go-playground/validator/v10"
-	en_translations "github.com/go-playground/validator/v10/translations/en"
 )
 
-// newTranslator returns validator translation. default using "en"
-func newTranslator() ut.Translator {
-	// NOTE: ommitting allot of error checking for brevity
-	en := en.New()
-	uni := ut.New(en, en)
-
-	// this is usually know or extracted from http 'Accept-Language' header
-	// also see uni.FindTranslator(...)
-	trans, _ := uni.GetTranslator("en")
-	return trans
+// Validator validates struct after request binding, implement it to plug another validation library.
+// c is the current request context, e.g. to translate error messages into its locale.
+// return ErrBinding to respond field errors, message of other error is responded as the only field error.
+type Validator interface {
+	Validate(target interface{}, c *Context) error
 }
 
-func newValidator(trans ut.Translator) *validator.Validate {
-	v10 := validator.New()
-	v10.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("form"), ",", 2)[0]
-
-		if name == "-" {
-			return ""
-		}
-
-		return name
-	})
-
-	en_translations.RegisterDefaultTranslations(v10, trans)
-	return v10
+// SetValidator functions to replace the built-in go-playground validator, pass nil to skip validation,
+// so binding only checks enum & file rules.
+func (ng *Engine) SetValidator(v Validator) {
+	ng.validator = v
 }
 
+// sharedValidator validates request of context created without engine.
+var sharedValidator = newDefaultValidator()
+
 // validate is default struct validator. this function will called when you do request binding to some struct.
 // Current validation rule is only to validate "required" field. To apply field into validation, just add "rules" at field tag.
 // if you apply "required" rule, that is mean you are not allowed to use zero type value in you request body field
@@ -47,20 +30,32 @@ func newValidator(trans ut.Translator) *validator.Validate {
 func validate(c *Context, targetStruct interface{}) error {
 	// only accept pointer
 	if reflect.TypeOf(targetStruct).Kind() != reflect.Ptr {
-		return &ErrBinding{
-			Text:   "expected pointer to target struct, got non-pointer",
-			Status: http.StatusInternalServerError,
-		}
+		return ErrBindNonPointer
 	}
 
-	err := c.validator.Struct(targetStruct)
+	// enum is checked before validator, so the error tells allowed values.
+	errFields := checkEnums(reflect.ValueOf(targetStruct))
+	errFields = append(errFields, checkFiles(reflect.ValueOf(targetStruct))...)
+
+	// internal callers are trusted to send valid payload, so the expensive validator is skipped.
+	if v := c.structValidator(); v != nil && !c.trusted {
+		if err := v.Validate(targetStruct, c); err != nil {
+			var errBinding ErrBinding
+			isErrBinding := errors.As(err, &errBinding)
 
-	if err != nil {
-		var errFields []string
-		for _, err := range err.(validator.ValidationErrors) {
-			errFields = append(errFields, err.Translate(c.translator))
+			// ErrBinding without fields still fails validation.
+			switch {
+			case isErrBinding && len(errBinding.Fields) > 0:
+				errFields = append(errFields, errBinding.Fields...)
+			case isErrBinding && errBinding.Text != "":
+				errFields = append(errFields, errBinding.Text)
+			default:
+				errFields = append(errFields, err.Error())
+			}
 		}
+	}
 
+	if len(errFields) > 0 {
 		return ErrBinding{
 			Status: http.StatusUnprocessableEntity,
 			Text:   "validation error",
@@ -70,3 +65,12 @@ func validate(c *Context, targetStruct interface{}) error {
 
 	return nil
 }
+
+// structValidator returns validator of the engine, it returns nil when validation is disabled.
+func (c *Context) structValidator() Validator {
+	if c.engine == nil {
+		return sharedValidator
+	}
+
+	return c.engine.validator
+}
@@ -0,0 +1,9 @@
+//go:build nano_novalidator
+
+package nano
+
+// newDefaultValidator returns nil, so binding only checks enum & file rules
+// until a validator is plugged using SetValidator.
+func newDefaultValidator() Validator {
+	return nil
+}
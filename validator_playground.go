@@ -0,0 +1,157 @@
+//go:build !nano_novalidator
+
+package nano
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/id"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+	nl_translations "github.com/go-playground/validator/v10/translations/nl"
+	pt_BR_translations "github.com/go-playground/validator/v10/translations/pt_BR"
+	ru_translations "github.com/go-playground/validator/v10/translations/ru"
+	tr_translations "github.com/go-playground/validator/v10/translations/tr"
+	zh_translations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+// validatorLocale defines validator error translation of a locale.
+type validatorLocale struct {
+	locale   func() locales.Translator
+	register func(v *validator.Validate, trans ut.Translator) error
+}
+
+// validatorLocales are locales having validator error translation, keyed by normalized locale.
+var validatorLocales = map[string]validatorLocale{
+	"en":    {en.New, en_translations.RegisterDefaultTranslations},
+	"es":    {es.New, es_translations.RegisterDefaultTranslations},
+	"fr":    {fr.New, fr_translations.RegisterDefaultTranslations},
+	"id":    {id.New, id_translations.RegisterDefaultTranslations},
+	"ja":    {ja.New, ja_translations.RegisterDefaultTranslations},
+	"nl":    {nl.New, nl_translations.RegisterDefaultTranslations},
+	"pt-br": {pt_BR.New, pt_BR_translations.RegisterDefaultTranslations},
+	"ru":    {ru.New, ru_translations.RegisterDefaultTranslations},
+	"tr":    {tr.New, tr_translations.RegisterDefaultTranslations},
+	"zh":    {zh.New, zh_translations.RegisterDefaultTranslations},
+}
+
+// newDefaultValidator returns the built-in go-playground validator.
+func newDefaultValidator() Validator {
+	return &defaultValidator{}
+}
+
+// defaultValidator is the built-in go-playground validator, it's constructed on the first validation,
+// so handlers that never bind request don't pay for it.
+type defaultValidator struct {
+	once     sync.Once
+	validate *validator.Validate
+	// translators are error translations keyed by normalized locale, see validatorLocales.
+	translators map[string]ut.Translator
+}
+
+// Validate validates struct using validate tag and translates errors into locale of the request.
+func (v *defaultValidator) Validate(target interface{}, c *Context) error {
+	v.once.Do(v.init)
+
+	err := v.validate.Struct(target)
+	if err == nil {
+		return nil
+	}
+
+	// target isn't struct, e.g. map, so there is no rule to validate.
+	if _, invalid := err.(*validator.InvalidValidationError); invalid {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	trans := v.translator(c.Locale())
+	errFields := make([]string, 0, len(validationErrors))
+	for _, err := range validationErrors {
+		errFields = append(errFields, err.Translate(trans))
+	}
+
+	return ErrBinding{
+		Status: http.StatusUnprocessableEntity,
+		Text:   "validation error",
+		Fields: errFields,
+	}
+}
+
+// translator returns error translation of the locale, or its base language, or english.
+func (v *defaultValidator) translator(locale string) ut.Translator {
+	locale = normalizeLocale(locale)
+
+	for _, candidate := range []string{locale, baseLocale(locale), defaultLocale} {
+		if trans, ok := v.translators[candidate]; ok {
+			return trans
+		}
+	}
+
+	return nil
+}
+
+// init constructs validator and registers error translations of all supported locales,
+// they're registered once because the validator can't be written while it's used concurrently.
+func (v *defaultValidator) init() {
+	v.validate = newValidator()
+	v.translators = make(map[string]ut.Translator, len(validatorLocales))
+
+	for name, translation := range validatorLocales {
+		language := translation.locale()
+		trans, _ := ut.New(language, language).GetTranslator(language.Locale())
+
+		if err := translation.register(v.validate, trans); err == nil {
+			v.translators[name] = trans
+		}
+	}
+}
+
+// newValidator returns go-playground validator naming fields by their binding tag.
+func newValidator() *validator.Validate {
+	v10 := validator.New()
+	v10.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		// use the first binding tag name found as field name in error message.
+		for _, tag := range []string{"form", "file", "query", "uri", "header"} {
+			name := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]
+
+			if name == "-" {
+				return ""
+			}
+
+			if name != "" {
+				return name
+			}
+		}
+
+		return ""
+	})
+
+	// file rules are checked by checkFiles, they are registered so the validator accepts the tag.
+	for _, rule := range []string{"maxsize", "mime"} {
+		v10.RegisterValidation(rule, func(validator.FieldLevel) bool { return true })
+	}
+
+	return v10
+}
@@ -0,0 +1,28 @@
+//go:build !nano_novalidator
+
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLazyValidator(t *testing.T) {
+	app := New()
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	if v := app.validator.(*defaultValidator); v.validate != nil {
+		t.Errorf("expected validator not to be constructed when request isn't bound")
+	}
+}
@@ -1,8 +1,11 @@
 package nano
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -14,6 +17,16 @@ func setupContext() *Context {
 
 }
 
+// skipWithoutValidator skips test which relies on the built-in validator,
+// e.g. when it's disabled by nano_novalidator build tag.
+func skipWithoutValidator(t testing.TB) {
+	t.Helper()
+
+	if newDefaultValidator() == nil {
+		t.Skip("built-in validator is disabled")
+	}
+}
+
 func TestValidator(t *testing.T) {
 	type Person struct {
 		Name         string `form:"name" json:"name" validate:"required"`
@@ -59,6 +72,8 @@ func TestValidator(t *testing.T) {
 	})
 
 	t.Run("empty value on required fields", func(st *testing.T) {
+		skipWithoutValidator(st)
+
 		person.Name = ""
 		person.Gender = ""
 		person.Email = ""
@@ -102,6 +117,8 @@ func TestValidator(t *testing.T) {
 }
 
 func TestNestedStructValidation(t *testing.T) {
+	skipWithoutValidator(t)
+
 	type Person struct {
 		Name    string `form:"name" json:"name" validate:"required"`
 		Gender  string `form:"gender" json:"gender" validate:"required"`
@@ -155,3 +172,71 @@ func TestNestedStructValidation(t *testing.T) {
 
 	t.Fatalf("expected ErrBinding, got %T", err)
 }
+
+// signupForm is payload validated by minLengthValidator.
+type signupForm struct {
+	Name string `form:"name" validate:"required"`
+}
+
+// minLengthValidator is validator plugged by SetValidator in tests.
+type minLengthValidator struct{}
+
+func (minLengthValidator) Validate(target interface{}, c *Context) error {
+	if form, ok := target.(*signupForm); ok && len(form.Name) < 3 {
+		return errors.New("name is too short")
+	}
+
+	return nil
+}
+
+// textOnlyValidator returns ErrBinding without fields.
+type textOnlyValidator struct{}
+
+func (textOnlyValidator) Validate(target interface{}, c *Context) error {
+	return ErrBinding{Status: http.StatusUnprocessableEntity, Text: "invalid payload"}
+}
+
+func TestSetValidator(t *testing.T) {
+	tt := []struct {
+		name      string
+		validator Validator
+		body      string
+		err       string
+	}{
+		{"custom validator", minLengthValidator{}, "name=jo", "name is too short"},
+		{"error binding without fields", textOnlyValidator{}, "name=john", "invalid payload"},
+		{"validation disabled", nil, "name=", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			app := New()
+			app.SetValidator(tc.validator)
+
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			req.Header.Set(HeaderContentType, MimeFormURLEncoded)
+
+			ctx := newContext(httptest.NewRecorder(), req)
+			ctx.engine = app
+
+			var form signupForm
+			err = ctx.Bind(&form)
+
+			if tc.err == "" {
+				if err != nil {
+					st.Errorf("expected binding to succeed; got %v", err)
+				}
+
+				return
+			}
+
+			var errBinding ErrBinding
+			if !errors.As(err, &errBinding) || len(errBinding.Fields) != 1 || errBinding.Fields[0] != tc.err {
+				st.Errorf("expected field error to be %q; got %v", tc.err, err)
+			}
+		})
+	}
+}
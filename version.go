@@ -0,0 +1,143 @@
+package nano
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HeaderAPIVersion is request header telling requested api version.
+const HeaderAPIVersion = "X-API-Version"
+
+// VersionConfig defines nano version middleware configuration.
+type VersionConfig struct {
+	// Default is version of request which doesn't tell its version, e.g. v1. it's optional.
+	Default string
+	// Header is request header telling the version, default is X-API-Version.
+	Header string
+}
+
+// vendorVersion matches version of vendor media type, e.g. v2 of application/vnd.foo.v2+json.
+var vendorVersion = regexp.MustCompile(`vnd\.[^,;]*?\.(v[0-9]+(?:\.[0-9]+)?)\b`)
+
+// Version returns version middleware with default configuration.
+func Version() HandlerFunc {
+	return VersionWithConfig(VersionConfig{})
+}
+
+// VersionWithConfig returns middleware reading requested api version from url prefix of version group
+// (e.g. /api/v2/users), vendor media type of Accept header (e.g. application/vnd.foo.v2+json),
+// or version header (e.g. X-API-Version: 2), in that order. The version is read by Context.APIVersion.
+// Register it using Engine.Pre, so request telling its version by header is routed into matching version group,
+// e.g. /api/users having Accept application/vnd.foo.v2+json is served by /api/v2/users.
+func VersionWithConfig(config VersionConfig) HandlerFunc {
+	if config.Header == "" {
+		config.Header = HeaderAPIVersion
+	}
+
+	config.Default = normalizeVersion(config.Default)
+
+	return func(c *Context) {
+		if version := c.versionOfPath(); version != "" {
+			c.apiVersion = version
+			c.Next()
+			return
+		}
+
+		var version string
+		if match := vendorVersion.FindStringSubmatch(c.GetRequestHeader(HeaderAccept)); match != nil {
+			version = match[1]
+			addVary(c.Writer.Header(), HeaderAccept)
+		} else if header := c.GetRequestHeader(config.Header); header != "" {
+			version = normalizeVersion(header)
+			addVary(c.Writer.Header(), config.Header)
+		} else {
+			version = config.Default
+		}
+
+		c.apiVersion = version
+		c.routeVersion(version)
+		c.Next()
+	}
+}
+
+// APIVersion returns api version of the request read by Version middleware, e.g. v2.
+func (c *Context) APIVersion() string {
+	return c.apiVersion
+}
+
+// Version functions to create router group of api version, e.g. v2 group of /api serves /api/v2 routes.
+// Version middleware routes request telling its version by header into the group, so each version
+// could have its own handlers of the same path.
+//
+//	v1 := api.Version("v1")
+//	v1.GET("/users", listUsersV1)
+//	v2 := api.Version("v2")
+//	v2.GET("/users", listUsersV2)
+func (rg *RouterGroup) Version(version string) *RouterGroup {
+	version = normalizeVersion(version)
+
+	group := rg.Group("/" + version)
+	group.version = version
+
+	return group
+}
+
+// normalizeVersion returns version having v prefix, e.g. v2 of 2.
+func normalizeVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version != "" && version[0] >= '0' && version[0] <= '9' {
+		return "v" + version
+	}
+
+	return version
+}
+
+// versionOfPath returns version of the most specific version group matching request path.
+func (c *Context) versionOfPath() string {
+	if c.engine == nil {
+		return ""
+	}
+
+	version, prefix := "", -1
+	for _, group := range c.engine.groups {
+		if group.version == "" || len(group.prefix) <= prefix {
+			continue
+		}
+
+		if _, ok := trimBasePath(c.Request.URL.Path, group.prefix); ok {
+			version, prefix = group.version, len(group.prefix)
+		}
+	}
+
+	return version
+}
+
+// routeVersion rewrites request path into version group of its parent group matching request path,
+// the most specific parent group is used. Request path is kept when there is no such group.
+func (c *Context) routeVersion(version string) {
+	if c.engine == nil || version == "" {
+		return
+	}
+
+	var target *RouterGroup
+	for _, group := range c.engine.groups {
+		if group.version != version || (target != nil && len(group.parent.prefix) <= len(target.parent.prefix)) {
+			continue
+		}
+
+		if _, ok := trimBasePath(c.Request.URL.Path, group.parent.prefix); ok {
+			target = group
+		}
+	}
+
+	if target == nil {
+		return
+	}
+
+	urlPath, _ := trimBasePath(c.Request.URL.Path, target.parent.prefix)
+	if urlPath == "/" {
+		urlPath = ""
+	}
+
+	c.setPath(target.prefix+urlPath, "")
+}
@@ -0,0 +1,65 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	app := New()
+	app.Pre(VersionWithConfig(VersionConfig{Default: "1"}))
+
+	handler := func(name string) HandlerFunc {
+		return func(c *Context) {
+			c.String(http.StatusOK, "%s %s", name, c.APIVersion())
+		}
+	}
+
+	api := app.Group("/api")
+	api.Version("v1").GET("/users", handler("users v1"))
+	api.Version("v2").GET("/users", handler("users v2"))
+	api.Version("v2").GET("", handler("index v2"))
+	app.GET("/status", handler("status"))
+
+	tt := []struct {
+		name   string
+		path   string
+		header map[string]string
+		body   string
+		vary   string
+	}{
+		{"url prefix", "/api/v2/users", map[string]string{HeaderAPIVersion: "1"}, "users v2 v2", ""},
+		{"accept header", "/api/users", map[string]string{HeaderAccept: "application/vnd.acme.v2+json"}, "users v2 v2", HeaderAccept},
+		{"version header", "/api/users", map[string]string{HeaderAPIVersion: "2"}, "users v2 v2", HeaderAPIVersion},
+		{"group root", "/api", map[string]string{HeaderAPIVersion: "v2"}, "index v2 v2", HeaderAPIVersion},
+		{"default version", "/api/users", nil, "users v1 v1", ""},
+		{"outside version groups", "/status", map[string]string{HeaderAPIVersion: "2"}, "status v2", HeaderAPIVersion},
+		{"unknown version", "/api/users", map[string]string{HeaderAPIVersion: "3"}, "nano/1.0 not found", HeaderAPIVersion},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+
+			for name, value := range tc.header {
+				req.Header.Set(name, value)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); body != tc.body {
+				st.Errorf("expected response body to be %q; got %q", tc.body, body)
+			}
+
+			if vary := rec.Header().Get(HeaderVary); vary != tc.vary {
+				st.Errorf("expected vary header to be %q; got %q", tc.vary, vary)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package nano
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WarmupFunc defines warm-up task, e.g. cache priming or template parsing.
+type WarmupFunc func(ctx context.Context) error
+
+// WarmupPolicy defines what to do when warm-up task fails.
+type WarmupPolicy int
+
+const (
+	// WarmupAbort stops the server when any warm-up task fails.
+	WarmupAbort WarmupPolicy = iota
+	// WarmupContinue logs the failure and marks the engine as ready anyway.
+	WarmupContinue
+)
+
+// WarmupConfig defines warm-up configuration.
+type WarmupConfig struct {
+	// Timeout is maximum duration of all warm-up tasks, default is 30 seconds.
+	Timeout time.Duration
+	// Policy decides what to do when warm-up task fails, default is WarmupAbort.
+	Policy WarmupPolicy
+}
+
+// Warmup functions to register warm-up task. All tasks are executed concurrently by Run
+// (or RunWarmup when you use your own http.Server), and the engine reports ready after they complete.
+func (ng *Engine) Warmup(task WarmupFunc) {
+	ng.warmups = append(ng.warmups, task)
+}
+
+// SetWarmupConfig functions to set warm-up timeout and failure policy.
+func (ng *Engine) SetWarmupConfig(config WarmupConfig) {
+	ng.warmupConfig = config
+}
+
+// IsReady returns true when all warm-up tasks are completed,
+// or when there is no warm-up task registered.
+func (ng *Engine) IsReady() bool {
+	return len(ng.warmups) == 0 || atomic.LoadInt32(&ng.ready) == 1
+}
+
+// RunWarmup executes all warm-up tasks and marks the engine as ready.
+// The error is returned when any task fails and the policy is WarmupAbort.
+func (ng *Engine) RunWarmup(ctx context.Context) error {
+	timeout := ng.warmupConfig.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errs := make(chan error, len(ng.warmups))
+	var wg sync.WaitGroup
+
+	for _, task := range ng.warmups {
+		wg.Add(1)
+
+		go func(task WarmupFunc) {
+			defer wg.Done()
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					errs <- fmt.Errorf("warm-up task panic: %v", recovered)
+				}
+			}()
+
+			if err := task(ctx); err != nil {
+				errs <- err
+			}
+		}(task)
+	}
+
+	// wait all tasks, or stop waiting when timeout reached.
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("warm-up timeout: %v", ctx.Err())
+	}
+
+	if err == nil && len(errs) > 0 {
+		err = <-errs
+	}
+
+	if err != nil {
+		if ng.warmupConfig.Policy == WarmupAbort {
+			return err
+		}
+
+		log.Printf("[warm-up] %v\n", err)
+	}
+
+	atomic.StoreInt32(&ng.ready, 1)
+
+	return nil
+}
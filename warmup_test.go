@@ -0,0 +1,80 @@
+package nano
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWarmup(t *testing.T) {
+	t.Run("ready without warm-up task", func(st *testing.T) {
+		if app := New(); !app.IsReady() {
+			st.Errorf("expected engine without warm-up task to be ready")
+		}
+	})
+
+	t.Run("ready after warm-up", func(st *testing.T) {
+		app := New()
+		primed := false
+		app.Warmup(func(ctx context.Context) error {
+			primed = true
+			return nil
+		})
+
+		if app.IsReady() {
+			st.Fatalf("expected engine not to be ready before warm-up")
+		}
+
+		if err := app.RunWarmup(context.Background()); err != nil {
+			st.Fatalf("expected error to be nil; got %v", err)
+		}
+
+		if !primed || !app.IsReady() {
+			st.Errorf("expected engine to be ready after warm-up")
+		}
+	})
+
+	t.Run("abort on failure", func(st *testing.T) {
+		app := New()
+		app.Warmup(func(ctx context.Context) error {
+			return errors.New("cache unavailable")
+		})
+
+		if err := app.RunWarmup(context.Background()); err == nil {
+			st.Errorf("expected error to be returned")
+		}
+
+		if app.IsReady() {
+			st.Errorf("expected engine not to be ready")
+		}
+	})
+
+	t.Run("continue on timeout", func(st *testing.T) {
+		app := New()
+		app.SetWarmupConfig(WarmupConfig{Timeout: 10 * time.Millisecond, Policy: WarmupContinue})
+		app.Warmup(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		if err := app.RunWarmup(context.Background()); err != nil {
+			st.Errorf("expected error to be nil; got %v", err)
+		}
+
+		if !app.IsReady() {
+			st.Errorf("expected engine to be ready")
+		}
+	})
+
+	t.Run("run stops on failure", func(st *testing.T) {
+		app := New()
+		app.Warmup(func(ctx context.Context) error {
+			return errors.New("database unavailable")
+		})
+
+		if err := app.Run("127.0.0.1:0"); err == nil || err.Error() != "database unavailable" {
+			st.Errorf("expected warm-up error to be returned; got %v", err)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package nano
+
+import (
+	"sort"
+	"time"
+)
+
+// staticWatchInterval is how often static directories are scanned for changes in debug mode.
+const staticWatchInterval = 500 * time.Millisecond
+
+// OnStaticChange functions to register hook called when files of static directories change.
+// static directories are only watched in debug mode, changed files are passed as path relative to the root directory.
+func (ng *Engine) OnStaticChange(hook func(changed []string)) {
+	ng.staticHooks = append(ng.staticHooks, hook)
+}
+
+// watchStatic scans static directories periodically until stop is closed.
+func (ng *Engine) watchStatic(interval time.Duration, stop <-chan struct{}) {
+	snapshots := make([]map[string]fileStamp, len(ng.staticFiles))
+	for i, files := range ng.staticFiles {
+		snapshots[i] = files.snapshot()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for i, files := range ng.staticFiles {
+				snapshots[i] = ng.pollStatic(files, snapshots[i])
+			}
+		}
+	}
+}
+
+// pollStatic compares current static directory state with previous snapshot.
+// cached entity tags of changed files are dropped and static change hooks are called.
+func (ng *Engine) pollStatic(files *staticFiles, previous map[string]fileStamp) map[string]fileStamp {
+	current := files.snapshot()
+	changed := changedFiles(previous, current)
+
+	if len(changed) > 0 {
+		files.invalidate(changed)
+
+		for _, hook := range ng.staticHooks {
+			hook(changed)
+		}
+	}
+
+	return current
+}
+
+// changedFiles returns sorted names of created, modified, and deleted files.
+func changedFiles(previous, current map[string]fileStamp) []string {
+	changed := make([]string, 0)
+
+	for name, stamp := range current {
+		if old, ok := previous[name]; !ok || old != stamp {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
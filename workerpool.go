@@ -0,0 +1,131 @@
+package nano
+
+import (
+	"net/http"
+	"runtime"
+	"sync"
+)
+
+// WorkerPoolConfig defines nano worker pool configuration.
+type WorkerPoolConfig struct {
+	// Workers is maximum number of handlers executed at the same time, default is number of CPU.
+	Workers int
+	// QueueSize is maximum number of requests waiting for free worker, default is equal to Workers.
+	// request that comes when the queue is full is rejected with 503 Service Unavailable.
+	QueueSize int
+}
+
+// workerJob is request handled by worker pool.
+type workerJob struct {
+	c *Context
+	// done receives recovered panic of handler, nil when handler returns normally.
+	done chan interface{}
+}
+
+// WorkerPool runs the rest of handlers stack on bounded number of worker goroutines.
+// use it for CPU heavy routes, so they can't starve latency sensitive routes.
+type WorkerPool struct {
+	config WorkerPoolConfig
+	jobs   chan *workerJob
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewWorkerPool creates worker pool and starts its workers.
+func NewWorkerPool(config WorkerPoolConfig) *WorkerPool {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+
+	if config.QueueSize <= 0 {
+		config.QueueSize = config.Workers
+	}
+
+	wp := &WorkerPool{
+		config: config,
+		jobs:   make(chan *workerJob, config.QueueSize),
+	}
+
+	wp.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go wp.work()
+	}
+
+	return wp
+}
+
+// work executes queued jobs until the pool is closed.
+func (wp *WorkerPool) work() {
+	defer wp.wg.Done()
+
+	for job := range wp.jobs {
+		wp.run(job)
+	}
+}
+
+// run executes handlers stack of job, panic is sent back to serving goroutine.
+func (wp *WorkerPool) run(job *workerJob) {
+	defer func() {
+		job.done <- recover()
+	}()
+
+	// client has gone while the request was queued.
+	if job.c.Request.Context().Err() != nil {
+		return
+	}
+
+	job.c.Next()
+}
+
+// Handle queues the rest of handlers stack to the pool and waits until it's done.
+// panic of handler is re-thrown in serving goroutine, so Recovery middleware keeps working.
+func (wp *WorkerPool) Handle(c *Context) {
+	job := &workerJob{c: c, done: make(chan interface{}, 1)}
+
+	if !wp.submit(job) {
+		c.SetHeader(HeaderRetryAfter, "1")
+		c.String(http.StatusServiceUnavailable, "service unavailable")
+		return
+	}
+
+	if recovered := <-job.done; recovered != nil {
+		panic(recovered)
+	}
+}
+
+// submit queues job without blocking, it returns false when the queue is full or the pool is closed.
+func (wp *WorkerPool) submit(job *workerJob) bool {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	if wp.closed {
+		return false
+	}
+
+	select {
+	case wp.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// Queued returns number of requests waiting for free worker.
+func (wp *WorkerPool) Queued() int {
+	return len(wp.jobs)
+}
+
+// Close stops accepting requests and waits until queued requests are done.
+func (wp *WorkerPool) Close() {
+	wp.mu.Lock()
+	if wp.closed {
+		wp.mu.Unlock()
+		return
+	}
+	wp.closed = true
+	close(wp.jobs)
+	wp.mu.Unlock()
+
+	wp.wg.Wait()
+}
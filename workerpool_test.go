@@ -0,0 +1,81 @@
+package nano
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{Workers: 1, QueueSize: 1})
+	defer pool.Close()
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+
+	app := New()
+	app.GET("/report", pool.Handle, func(c *Context) {
+		started <- struct{}{}
+		<-release
+		c.String(http.StatusOK, "done")
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, "/report", nil)
+		if err != nil {
+			log.Fatalf("could not create http request: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	results := make(chan *httptest.ResponseRecorder, 2)
+
+	// first request occupies the only worker.
+	go func() { results <- serve() }()
+	<-started
+
+	// second request waits in the queue.
+	go func() { results <- serve() }()
+	for pool.Queued() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// third request is shed since the queue is full.
+	if rec := serve(); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status code to be 503; got %d", rec.Code)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if rec := <-results; rec.Code != http.StatusOK || rec.Body.String() != "done" {
+			t.Errorf("expected queued request to be served; got %d %s", rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestWorkerPoolPanic(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{Workers: 1})
+	defer pool.Close()
+
+	app := New()
+	app.Use(Recovery())
+	app.GET("/", pool.Handle, func(c *Context) {
+		panic("something went wrong")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		log.Fatalf("could not create http request: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected panic in worker to be recovered with status 500; got %d", rec.Code)
+	}
+}
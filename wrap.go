@@ -0,0 +1,63 @@
+package nano
+
+import (
+	"context"
+	"net/http"
+)
+
+// WrapHandler functions to use http.Handler as nano handler, the handler reads nano context using ContextFromRequest.
+//
+//	app.GET("/metrics", nano.WrapHandler(promhttp.Handler()))
+func WrapHandler(handler http.Handler) HandlerFunc {
+	return func(c *Context) {
+		handler.ServeHTTP(c.Writer, c.stdRequest())
+	}
+}
+
+// WrapMiddleware functions to use net/http middleware (e.g. chi middlewares or gorilla handlers) in nano handlers stack.
+// Request & response writer passed by the middleware to the next handler replace c.Request & c.Writer
+// for the rest of handlers stack, so request context values and response writer wrappers are kept.
+// c.Writer is restored once the middleware returns. The rest of handlers stack is skipped when the middleware
+// doesn't call the next handler.
+//
+//	app.Use(nano.WrapMiddleware(middleware.RealIP), nano.WrapMiddleware(handlers.CompressHandler))
+func WrapMiddleware(middleware func(http.Handler) http.Handler) HandlerFunc {
+	wrapped := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := ContextFromRequest(r)
+		if !ok {
+			return
+		}
+
+		c.Request, c.Writer = r, w
+		c.Next()
+	}))
+
+	return func(c *Context) {
+		writer := c.Writer
+		defer func() {
+			c.Writer = writer
+		}()
+
+		wrapped.ServeHTTP(c.Writer, c.stdRequest())
+	}
+}
+
+// contextKey is request context key of nano context passed to net/http handler.
+type contextKey struct{}
+
+// ContextFromRequest returns nano context of request served by net/http handler used in nano,
+// e.g. mounted handler or handler wrapped by WrapHandler & WrapMiddleware. It returns false for other requests.
+func ContextFromRequest(r *http.Request) (*Context, bool) {
+	c, ok := r.Context().Value(contextKey{}).(*Context)
+
+	return c, ok
+}
+
+// stdRequest returns request whose context carries c, so net/http handler could read it using ContextFromRequest.
+func (c *Context) stdRequest() *http.Request {
+	if current, ok := ContextFromRequest(c.Request); ok && current == c {
+		return c.Request
+	}
+
+	return c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, c))
+}
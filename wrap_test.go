@@ -0,0 +1,103 @@
+package nano
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testContextKey struct{}
+
+// upperWriter uppercases response body, like response writer wrappers of net/http middlewares.
+type upperWriter struct {
+	http.ResponseWriter
+}
+
+func (w upperWriter) Write(data []byte) (int, error) {
+	return w.ResponseWriter.Write([]byte(strings.ToUpper(string(data))))
+}
+
+func TestWrapMiddleware(t *testing.T) {
+	requestID := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "42")
+			next.ServeHTTP(upperWriter{w}, r.WithContext(context.WithValue(r.Context(), testContextKey{}, "42")))
+		})
+	}
+
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	var after string
+
+	app := New()
+	app.Use(func(c *Context) {
+		c.Next()
+		after = "restored"
+		if _, wrapped := c.Writer.(upperWriter); wrapped {
+			after = "wrapped"
+		}
+	})
+	app.Use(WrapMiddleware(requestID), WrapMiddleware(auth))
+	app.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "request %v", c.Request.Context().Value(testContextKey{}))
+	})
+	app.GET("/std", WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := ContextFromRequest(r)
+		if !ok {
+			http.Error(w, "missing nano context", http.StatusInternalServerError)
+			return
+		}
+
+		w.Write([]byte("std " + c.RoutePattern()))
+	})))
+
+	tt := []struct {
+		name   string
+		path   string
+		auth   string
+		status int
+		body   string
+	}{
+		{"middleware stops the stack", "/", "", http.StatusUnauthorized, "UNAUTHORIZED\n"},
+		{"request & writer are passed through", "/", "Bearer token", http.StatusOK, "REQUEST 42"},
+		{"wrapped handler", "/std", "Bearer token", http.StatusOK, "STD /STD"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(st *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				log.Fatalf("could not create http request: %v", err)
+			}
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status || rec.Body.String() != tc.body {
+				st.Errorf("expected response to be %d %q; got %d %q", tc.status, tc.body, rec.Code, rec.Body.String())
+			}
+
+			if id := rec.Header().Get("X-Request-Id"); id != "42" {
+				st.Errorf("expected header set by middleware to be 42; got %q", id)
+			}
+
+			if after != "restored" {
+				st.Errorf("expected response writer to be restored after the middleware returns")
+			}
+		})
+	}
+}